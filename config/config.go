@@ -47,9 +47,35 @@ type Config struct {
 
 	MaxFileSize int `env:"MAX_FILE_SIZE"`
 
+	PaymentGateway string `env:"PAYMENT_GATEWAY"`
+
+	MidtransEnvironment     string        `env:"MIDTRANS_ENVIRONMENT"`
 	MidtransClientKey       string        `env:"MIDTRANS_CLIENT_KEY"`
 	MidtransServerKey       string        `env:"MIDTRANS_SERVER_KEY"`
 	MidtransPaymentDuration time.Duration `env:"MIDTRANS_PAYMENT_DURATION"`
+	MidtransVerifyStatus    bool          `env:"MIDTRANS_VERIFY_STATUS"`
+	MidtransAPIURL          string        `env:"MIDTRANS_API_URL"`
+	PendingPaymentMargin    time.Duration `env:"PENDING_PAYMENT_MARGIN"`
+
+	XenditSecretKey     string `env:"XENDIT_SECRET_KEY"`
+	XenditCallbackToken string `env:"XENDIT_CALLBACK_TOKEN"`
+	XenditAPIURL        string `env:"XENDIT_API_URL"`
+
+	RenewalLeadTime    time.Duration `env:"RENEWAL_LEAD_TIME"`
+	RenewalGracePeriod time.Duration `env:"RENEWAL_GRACE_PERIOD"`
+
+	DeliveryCutoff time.Duration `env:"DELIVERY_CUTOFF"`
+
+	TaxRate          float64  `env:"TAX_RATE"`
+	DeliveryZoneFees []string `env:"DELIVERY_ZONE_FEES" envSeparator:","`
+
+	Geocoder                string `env:"GEOCODER"`
+	GeocoderPostalCodesFile string `env:"GEOCODER_POSTAL_CODES_FILE"`
+
+	RefundFee float64 `env:"REFUND_FEE"`
+
+	ReconciliationLookback time.Duration `env:"RECONCILIATION_LOOKBACK"`
+	FinanceEmail           string        `env:"FINANCE_EMAIL"`
 }
 
 func New() (*Config, error) {
@@ -15,67 +15,63 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/google": {
+        "/admin/deliveries/manifest": {
             "get": {
-                "description": "Redirect user to Google OAuth login page.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Google OAuth Login",
-                "responses": {
-                    "303": {
-                        "description": "Redirect to Google OAuth",
-                        "schema": {
-                            "type": "string"
-                        }
+                "security": [
+                    {
+                        "ApiKeyAuth": []
                     }
-                }
-            }
-        },
-        "/auth/google/callback": {
-            "get": {
-                "description": "Callback endpoint for Google OAuth. Handles code and state from Google, then redirects to FE with tokens.",
+                ],
+                "description": "List every meal to be delivered on a date, leaving out skipped ones, with the subscriber's contact, address and allergies (admin only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Delivery"
                 ],
-                "summary": "Google OAuth Callback",
+                "summary": "Get Delivery Manifest",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization code from Google",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "OAuth state",
-                        "name": "state",
+                        "description": "Delivery date (YYYY-MM-DD)",
+                        "name": "date",
                         "in": "query",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "OAuth error",
-                        "name": "error",
-                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "303": {
-                        "description": "Redirect to FE with tokens",
+                    "200": {
+                        "description": "Get delivery manifest successful",
                         "schema": {
-                            "type": "string"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryManifestResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -89,33 +85,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate a user with email and password and get access/refresh tokens.",
-                "consumes": [
-                    "application/json"
+        "/admin/delivery-zones/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "List every delivery zone, active or not (admin only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
-                ],
-                "summary": "Login User",
-                "parameters": [
-                    {
-                        "description": "Login Request",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.LoginRequest"
-                        }
-                    }
+                    "Delivery Zone"
                 ],
+                "summary": "Get All Delivery Zones",
                 "responses": {
                     "200": {
-                        "description": "Login successful, tokens returned.",
+                        "description": "Get all delivery zones successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -125,21 +112,24 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.TokenResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryZoneResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request (validation error)",
+                    "401": {
+                        "description": "Missing or invalid access token",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized (invalid credentials or user not verified)",
+                    "403": {
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -151,11 +141,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/logout": {
+            },
             "post": {
-                "description": "Invalidate the refresh token and logout the user.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create an area we deliver to (admin only). An address is covered when its postal code starts with one of postal_codes, or else when its location lies inside polygon; a zone with neither covers its whole city. Once any zone is active, new subscriptions must be in one.",
                 "consumes": [
                     "application/json"
                 ],
@@ -163,35 +156,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Delivery Zone"
                 ],
-                "summary": "Logout",
+                "summary": "Create Delivery Zone",
                 "parameters": [
                     {
-                        "description": "Logout Request",
+                        "description": "Delivery Zone Request",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.LogoutRequest"
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryZoneRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Logout successful",
+                    "201": {
+                        "description": "Create delivery zone successful",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryZoneResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request (validation error)",
+                        "description": "Invalid request body or validation error",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized (invalid or expired refresh token)",
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Delivery zone name already exists",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -205,33 +222,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh-token": {
-            "post": {
-                "description": "Generate new access and refresh tokens using a valid refresh token.",
-                "consumes": [
-                    "application/json"
+        "/admin/delivery-zones/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Get a delivery zone by its ID (admin only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Delivery Zone"
                 ],
-                "summary": "Refresh Token",
+                "summary": "Get Delivery Zone By ID",
                 "parameters": [
                     {
-                        "description": "Refresh Token Request",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RefreshTokenRequest"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Delivery Zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token refresh successful",
+                        "description": "Get delivery zone by ID successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -241,7 +259,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.TokenResponse"
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryZoneResponse"
                                         }
                                     }
                                 }
@@ -249,65 +267,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request (validation error)",
+                        "description": "Invalid delivery zone ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized (invalid or expired refresh token)",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
+                        "description": "Missing or invalid access token",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
-                    }
-                }
-            }
-        },
-        "/auth/register": {
-            "post": {
-                "description": "Create a new user account and send an OTP for verification.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "Register User",
-                "parameters": [
-                    {
-                        "description": "Register Request",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RegisterRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Registration successful. OTP has been sent to email.",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
-                        }
                     },
-                    "400": {
-                        "description": "Bad Request (e.g., validation error)",
+                    "403": {
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
-                    "409": {
-                        "description": "Conflict (e.g., email already exists)",
+                    "404": {
+                        "description": "Delivery zone not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -319,11 +297,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/auth/verify-otp": {
-            "post": {
-                "description": "Verify the OTP sent to the user's email and get access/refresh tokens.",
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace the settings of a delivery zone (admin only). Subscriptions already in the zone keep the fee and delivery days they were signed up with until their plan is changed; a new cutoff applies to them straight away.",
                 "consumes": [
                     "application/json"
                 ],
@@ -331,23 +312,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Delivery Zone"
                 ],
-                "summary": "Verify OTP",
+                "summary": "Update Delivery Zone",
                 "parameters": [
                     {
-                        "description": "Verify OTP Request",
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Delivery Zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivery Zone Request",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.VerifyOTPRequest"
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryZoneRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Verification successful, tokens returned.",
+                        "description": "Update delivery zone successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -357,7 +346,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.TokenResponse"
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryZoneResponse"
                                         }
                                     }
                                 }
@@ -365,13 +354,31 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad Request (e.g., invalid OTP, validation error)",
+                        "description": "Invalid delivery zone ID, request body or validation error",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
-                    "404": {
-                        "description": "User Not Found",
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Delivery zone name already exists",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -383,38 +390,66 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/meal-plans/": {
-            "get": {
-                "description": "Get all meal plans.",
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a delivery zone no subscription was ever delivered in (admin only). Zones with subscriptions can only be deactivated.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MealPlan"
+                    "Delivery Zone"
+                ],
+                "summary": "Delete Delivery Zone",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Delivery Zone ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get All Meal Plans",
                 "responses": {
                     "200": {
-                        "description": "Get all meal plans successful",
+                        "description": "Delete delivery zone successful",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "payload": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.MealPlanResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid delivery zone ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery zone not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Delivery zone has subscriptions",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
                     "500": {
@@ -424,39 +459,88 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new meal plan.",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/admin/kitchen/forecast": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "Count the portions to cook on a date, or with period=week the Monday to Sunday week it falls in, by meal plan and meal type, with how many are for customers with each allergy (admin only). Meals skipped by customers and days inside a pause are left out. With format=csv the forecast is downloaded as a spreadsheet, and with format=labels as a PDF sheet with a label for every meal.",
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/pdf"
                 ],
                 "tags": [
-                    "MealPlan"
+                    "Kitchen"
                 ],
-                "summary": "Create Meal Plan",
+                "summary": "Get Kitchen Forecast",
                 "parameters": [
                     {
-                        "description": "Create Meal Plan Request",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.CreateMealPlanRequest"
-                        }
+                        "type": "string",
+                        "description": "Date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week"
+                        ],
+                        "type": "string",
+                        "description": "day (default) or week",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "labels"
+                        ],
+                        "type": "string",
+                        "description": "json (default), csv or labels",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Meal plan created successfully",
+                    "200": {
+                        "description": "Get kitchen forecast successful",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.KitchenForecastResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation error",
+                        "description": "Invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -470,28 +554,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/meal-plans/{id}": {
+        "/admin/payments/": {
             "get": {
-                "description": "Get meal plan detail by ID.",
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List all recorded payments, optionally filtered by date range, status and payment channel (admin only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MealPlan"
+                    "Payment"
                 ],
-                "summary": "Get Meal Plan By ID",
+                "summary": "Get Payments",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Meal Plan ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "settlement",
+                        "description": "Transaction status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "bank_transfer",
+                        "description": "Payment channel",
+                        "name": "payment_type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Get meal plan by ID successful",
+                        "description": "Get payments successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -501,7 +609,10 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.MealPlanResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PaymentDetailResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -509,13 +620,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid meal plan ID",
+                        "description": "Invalid request params",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
-                    "404": {
-                        "description": "Meal plan not found",
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -529,24 +646,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/subscriptions/": {
+        "/admin/promos/": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Retrieve all subscriptions for the authenticated user.",
+                "description": "List every promo code with its redemption count (admin only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "Promo"
                 ],
-                "summary": "Get User Subscriptions",
+                "summary": "Get All Promos",
                 "responses": {
                     "200": {
-                        "description": "Get user subscriptions successful",
+                        "description": "Get all promos successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -558,7 +675,7 @@ const docTemplate = `{
                                         "payload": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionResponse"
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PromoResponse"
                                             }
                                         }
                                     }
@@ -572,6 +689,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -586,7 +709,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new meal plan subscription with payment.",
+                "description": "Create a promo code for the first billing period of new subscriptions (admin only). Leave meal_plan_ids empty to allow every meal plan.",
                 "consumes": [
                     "application/json"
                 ],
@@ -594,23 +717,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "Promo"
                 ],
-                "summary": "Create Subscription",
+                "summary": "Create Promo",
                 "parameters": [
                     {
-                        "description": "Create Subscription Request",
+                        "description": "Promo Request",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.CreateSubscriptionRequest"
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PromoRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Subscription created successfully",
+                        "description": "Create promo successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -620,7 +743,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PaymentResponse"
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PromoResponse"
                                         }
                                     }
                                 }
@@ -639,12 +762,24 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
                     "404": {
                         "description": "Meal plan not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
+                    "409": {
+                        "description": "Promo code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -654,24 +789,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/subscriptions/admin/stats/active-total": {
+        "/admin/promos/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get total number of active subscriptions (admin only).",
+                "description": "Get a promo code by its ID (admin only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "Promo"
+                ],
+                "summary": "Get Promo By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promo ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get Total Active Subscriptions",
                 "responses": {
                     "200": {
-                        "description": "Get total active subscriptions success",
+                        "description": "Get promo by ID successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -681,18 +826,19 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "type": "object",
-                                            "properties": {
-                                                "count": {
-                                                    "type": "integer"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PromoResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid promo ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
                     "401": {
                         "description": "Missing or invalid access token",
                         "schema": {
@@ -705,6 +851,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
+                    "404": {
+                        "description": "Promo not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -712,42 +864,46 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/subscriptions/admin/stats/mrr": {
-            "get": {
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get Monthly Recurring Revenue (MRR) in a date range (admin only).",
+                "description": "Replace the settings of a promo code (admin only). Redemptions already made are kept.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "Promo"
                 ],
-                "summary": "Get MRR Stats",
+                "summary": "Update Promo",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Promo ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "description": "Promo Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PromoRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Get MRR stats success",
+                        "description": "Update promo successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -757,12 +913,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "type": "object",
-                                            "properties": {
-                                                "mrr": {
-                                                    "type": "number"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PromoResponse"
                                         }
                                     }
                                 }
@@ -770,7 +921,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request params",
+                        "description": "Invalid promo ID, request body or validation error",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -787,6 +938,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
+                    "404": {
+                        "description": "Promo or meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Promo code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -794,42 +957,95 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/subscriptions/admin/stats/new": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get total number of new subscriptions in a date range (admin only).",
+                "description": "Delete a promo code that has never been redeemed (admin only). Redeemed promos can only be deactivated.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "Promo"
                 ],
-                "summary": "Get New Subscriptions Stats",
+                "summary": "Delete Promo",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Promo ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delete promo successful",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid promo ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Promo not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Promo has been redeemed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reconciliations/": {
+            "get": {
+                "security": [
                     {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
-                        "required": true
+                        "ApiKeyAuth": []
                     }
                 ],
+                "description": "List the daily payment reconciliation runs with their matched, fixed and unresolved counts, newest first (admin only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get Reconciliation Reports",
                 "responses": {
                     "200": {
-                        "description": "Get new subscriptions stats success",
+                        "description": "Get reconciliation reports successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -839,11 +1055,9 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "type": "object",
-                                            "properties": {
-                                                "count": {
-                                                    "type": "integer"
-                                                }
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.ReconciliationReportResponse"
                                             }
                                         }
                                     }
@@ -851,12 +1065,6 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid request params",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
-                        }
-                    },
                     "401": {
                         "description": "Missing or invalid access token",
                         "schema": {
@@ -878,40 +1086,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/subscriptions/admin/stats/reactivations": {
+        "/admin/reconciliations/{id}": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get number of subscriptions that were reactivated in a date range (admin only).",
+                "description": "Retrieve one reconciliation run together with the orders it fixed or could not resolve (admin only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "Payment"
                 ],
-                "summary": "Get Reactivation Stats",
+                "summary": "Get Reconciliation Report",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD)",
-                        "name": "start_date",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD)",
-                        "name": "end_date",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "Reconciliation report ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Get reactivation stats success",
+                        "description": "Get reconciliation report successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -921,12 +1123,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "type": "object",
-                                            "properties": {
-                                                "count": {
-                                                    "type": "integer"
-                                                }
-                                            }
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.ReconciliationReportResponse"
                                         }
                                     }
                                 }
@@ -934,7 +1131,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request params",
+                        "description": "Invalid reconciliation report ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -951,6 +1148,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
+                    "404": {
+                        "description": "Reconciliation report not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -960,56 +1163,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/subscriptions/webhook/midtrans": {
-            "post": {
-                "description": "Handle payment notification from Midtrans payment gateway. This endpoint is called by Midtrans to notify payment status changes.",
-                "consumes": [
-                    "application/json"
+        "/admin/refunds/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
                 ],
+                "description": "List refunds, optionally filtered by status (admin only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "Refund"
                 ],
-                "summary": "Handle Midtrans Webhook",
+                "summary": "Get Refunds",
                 "parameters": [
                     {
-                        "description": "Midtrans Notification",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "order_id": {
-                                    "type": "string"
-                                },
-                                "payment_type": {
-                                    "type": "string"
-                                },
-                                "transaction_status": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                        "enum": [
+                            "requested",
+                            "approved",
+                            "rejected",
+                            "refunded"
+                        ],
+                        "type": "string",
+                        "description": "Refund status",
+                        "name": "status",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Webhook processed successfully",
+                        "description": "Get refunds successful",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RefundResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid notification data",
+                        "description": "Invalid request params",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
-                    "404": {
-                        "description": "Subscription not found",
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -1023,34 +1241,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/subscriptions/{id}": {
-            "delete": {
+        "/admin/refunds/{id}/approve": {
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Permanently cancel a subscription. This action cannot be undone.",
+                "description": "Approve a requested refund (admin only). By default it is credited to the customer's wallet and refunded straight away; with destination=gateway the payment gateway pays it out instead and the refund is marked refunded once the gateway confirms it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "Refund"
                 ],
-                "summary": "Cancel Subscription",
+                "summary": "Approve Refund",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Subscription ID",
+                        "description": "Refund ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "wallet",
+                            "gateway"
+                        ],
+                        "type": "string",
+                        "default": "wallet",
+                        "description": "Where the refund goes",
+                        "name": "destination",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Subscription cancelled successfully",
+                        "description": "Refund approved successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -1060,7 +1289,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionResponse"
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RefundResponse"
                                         }
                                     }
                                 }
@@ -1068,7 +1297,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid subscription ID",
+                        "description": "Invalid refund ID, request params or validation error",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -1080,13 +1309,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Not authorized to cancel this subscription",
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
                     "404": {
-                        "description": "Subscription not found",
+                        "description": "Refund not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Refund has already been approved or rejected",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -1100,14 +1335,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/subscriptions/{id}/pause": {
+        "/admin/refunds/{id}/reject": {
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Temporarily pause a subscription by specifying start and end dates. The subscription duration will be extended by the pause period.",
+                "description": "Reject a requested refund with a reason shown to the customer (admin only).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1115,31 +1350,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Subscription"
+                    "Refund"
                 ],
-                "summary": "Pause Subscription",
+                "summary": "Reject Refund",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Subscription ID",
+                        "description": "Refund ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Pause Subscription Request",
+                        "description": "Reject Refund Request",
                         "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PauseSubscriptionRequest"
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RejectRefundRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Subscription paused successfully",
+                        "description": "Refund rejected successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -1149,7 +1384,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionResponse"
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RefundResponse"
                                         }
                                     }
                                 }
@@ -1157,7 +1392,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid subscription ID or request body",
+                        "description": "Invalid refund ID, request body or validation error",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -1169,13 +1404,19 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Not authorized to pause this subscription",
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
                     "404": {
-                        "description": "Subscription not found",
+                        "description": "Refund not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Refund has already been approved or rejected",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -1189,19 +1430,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/testimonials/": {
-            "get": {
-                "description": "Get all testimonials.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Testimonial"
-                ],
-                "summary": "Get All Testimonials",
+        "/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Make a user a courier or an admin, or back into a customer (admin only). The new role applies from the user's next login or token refresh. Admins cannot change their own role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update User Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update User Role Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UpdateUserRoleRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "Get all testimonials successful",
+                        "description": "User role updated successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -1211,16 +1479,37 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.TestimonialResponse"
-                                            }
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UserResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid user ID, request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required or changing own role",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1228,72 +1517,72 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/wallets/{userId}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Create a new testimonial with photo upload. Only for authenticated users.",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Retrieve the store credit balance and ledger of any user (admin only).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Testimonial"
+                    "Wallet"
                 ],
-                "summary": "Create Testimonial",
+                "summary": "Get User Wallet",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "John Doe",
-                        "description": "Customer Name",
-                        "name": "name",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "example": "The food was delicious",
-                        "description": "Review Message",
-                        "name": "message",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "example": 5,
-                        "description": "Rating (1-5)",
-                        "name": "rating",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Photo of the meal",
-                        "name": "photo",
-                        "in": "formData",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Create testimonial successful",
+                    "200": {
+                        "description": "Get wallet successful",
                         "schema": {
-                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.WalletResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Bad Request (validation error)",
+                        "description": "Invalid user ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -1307,24 +1596,46 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/profile": {
-            "get": {
+        "/admin/wallets/{userId}/adjustments": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get the authenticated user's profile.",
+                "description": "Credit or debit a user's wallet by hand, e.g. as a goodwill gesture (admin only). The note and the admin are recorded in the ledger.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Wallet"
+                ],
+                "summary": "Adjust Wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Adjust Wallet Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.AdjustWalletRequest"
+                        }
+                    }
                 ],
-                "summary": "Get User Profile",
                 "responses": {
-                    "200": {
-                        "description": "Get profile successful",
+                    "201": {
+                        "description": "Wallet adjusted successful",
                         "schema": {
                             "allOf": [
                                 {
@@ -1334,15 +1645,39 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "payload": {
-                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UserResponse"
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.WalletTransactionResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
+                    "400": {
+                        "description": "Invalid user ID, request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Wallet balance is too low",
                         "schema": {
                             "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
                         }
@@ -1355,106 +1690,4587 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_Ablebil_sea-catering-be_internal_domain_dto.CreateMealPlanRequest": {
-            "type": "object",
-            "required": [
-                "description",
-                "name",
-                "photo_url",
-                "price"
-            ],
-            "properties": {
-                "description": {
-                    "type": "string",
-                    "example": "A healthy meal plan"
-                },
-                "name": {
-                    "type": "string",
-                    "maxLength": 255,
-                    "minLength": 3,
-                    "example": "Diet Plan"
-                },
-                "photo_url": {
-                    "type": "string",
-                    "example": "https://..."
-                },
-                "price": {
-                    "type": "number",
-                    "example": 30000
+        },
+        "/auth/google": {
+            "get": {
+                "description": "Redirect user to Google OAuth login page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Google OAuth Login",
+                "responses": {
+                    "303": {
+                        "description": "Redirect to Google OAuth",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
                 }
             }
         },
-        "github_com_Ablebil_sea-catering-be_internal_domain_dto.CreateSubscriptionRequest": {
-            "type": "object",
-            "required": [
-                "delivery_address",
-                "delivery_days",
-                "meal_plan_id",
-                "meal_types",
-                "name",
-                "phone_number"
-            ],
-            "properties": {
-                "allergies": {
-                    "type": "string",
-                    "example": "Peanuts, Shellfish"
-                },
-                "delivery_address": {
-                    "type": "string",
-                    "minLength": 10,
-                    "example": "123 Main St, Jakarta"
-                },
-                "delivery_days": {
-                    "type": "array",
-                    "minItems": 1,
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "monday",
-                        "tuesday",
-                        "wednesday"
-                    ]
-                },
+        "/auth/google/callback": {
+            "get": {
+                "description": "Callback endpoint for Google OAuth. Handles code and state from Google, then redirects to FE with tokens.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Google OAuth Callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code from Google",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "OAuth state",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "OAuth error",
+                        "name": "error",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "303": {
+                        "description": "Redirect to FE with tokens",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate a user with email and password and get access/refresh tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Login User",
+                "parameters": [
+                    {
+                        "description": "Login Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful, tokens returned.",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.TokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request (validation error)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid credentials or user not verified)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Invalidate the refresh token and logout the user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "description": "Logout Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logout successful",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request (validation error)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid or expired refresh token)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh-token": {
+            "post": {
+                "description": "Generate new access and refresh tokens using a valid refresh token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh Token",
+                "parameters": [
+                    {
+                        "description": "Refresh Token Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token refresh successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.TokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request (validation error)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized (invalid or expired refresh token)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Create a new user account and send an OTP for verification.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register User",
+                "parameters": [
+                    {
+                        "description": "Register Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Registration successful. OTP has been sent to email.",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request (e.g., validation error)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict (e.g., email already exists)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-otp": {
+            "post": {
+                "description": "Verify the OTP sent to the user's email and get access/refresh tokens.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify OTP",
+                "parameters": [
+                    {
+                        "description": "Verify OTP Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.VerifyOTPRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verification successful, tokens returned.",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.TokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request (e.g., invalid OTP, validation error)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "User Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/courier/deliveries/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Pick up a scheduled meal due today, then mark it delivered with a proof photo or failed with a reason and optionally a photo of the attempt. Only the courier who picked a meal up, or an admin, can finish it (couriers and admins only).",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courier"
+                ],
+                "summary": "Update Delivery Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "picked_up",
+                            "delivered",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "New status",
+                        "name": "status",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "Nobody at home",
+                        "description": "Why the delivery failed, required when failed",
+                        "name": "failure_reason",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Proof photo, required when delivered",
+                        "name": "photo",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery status updated successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid delivery ID, form data or missing proof photo",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Courier access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Delivery is not due today, not picked up or held by another courier",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/courier/routes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the meals couriers take out on a date, one route per delivery zone and one stop per subscription, ordered by postal code. Subscriptions without a zone are grouped last as \"Unzoned\" (couriers and admins only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courier"
+                ],
+                "summary": "Get Route Manifest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Only this delivery zone",
+                        "name": "zone_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "breakfast",
+                            "lunch",
+                            "dinner"
+                        ],
+                        "type": "string",
+                        "description": "Only this meal type",
+                        "name": "meal_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get route manifest successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RouteManifestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Courier access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/deliveries/{id}/skip": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Skip a single scheduled meal before its cutoff. The meal's share of the subscription price is credited to the customer's wallet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery"
+                ],
+                "summary": "Skip Delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery skipped successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid delivery ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Delivery cannot be skipped or its cutoff has passed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/deliveries/{id}/unskip": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a skipped meal before its cutoff, taking back the credit it added.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery"
+                ],
+                "summary": "Unskip Delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery unskipped successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid delivery ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Delivery is not skipped, its cutoff has passed or its credit was already used",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Stream the authenticated user's events as Server-Sent Events: payment.settled, payment.failed, subscription.status_changed and delivery.status_changed. Each event's data is a JSON payload naming what changed; refetch it for the full picture. Events sent while disconnected are not replayed. Browsers' EventSource may pass the token in access_token instead of the Authorization header.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Event"
+                ],
+                "summary": "Stream Events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token, when it cannot be sent in the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/gifts": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the gift subscriptions the authenticated user bought, newest first, with their codes and whether they were claimed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift"
+                ],
+                "summary": "Get Purchased Gifts",
+                "responses": {
+                    "200": {
+                        "description": "Get gifts successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.GiftResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/gifts/claim": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Claim a gift subscription with the code emailed to its recipient. The subscription moves into the authenticated user's account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Gift"
+                ],
+                "summary": "Claim Gift",
+                "parameters": [
+                    {
+                        "description": "Claim Gift Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.ClaimGiftRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Gift claimed successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.GiftResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Buyers cannot claim their own gift",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Gift not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Gift already claimed, not paid yet or its subscription has ended",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/meal-plans/": {
+            "get": {
+                "description": "Get all meal plans.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MealPlan"
+                ],
+                "summary": "Get All Meal Plans",
+                "responses": {
+                    "200": {
+                        "description": "Get all meal plans successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.MealPlanResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new meal plan.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MealPlan"
+                ],
+                "summary": "Create Meal Plan",
+                "parameters": [
+                    {
+                        "description": "Create Meal Plan Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.CreateMealPlanRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Meal plan created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/meal-plans/{id}": {
+            "get": {
+                "description": "Get meal plan detail by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MealPlan"
+                ],
+                "summary": "Get Meal Plan By ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Meal Plan ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get meal plan by ID successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.MealPlanResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid meal plan ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve all subscriptions for the authenticated user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Get User Subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "Get user subscriptions successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new meal plan subscription with payment. With use_wallet_credit, wallet credit pays as much of the first billing period as it covers and only the rest is charged. With gift, the subscription is bought for the recipient named in the request: it does not auto-renew, and once paid a gift code is emailed to recipient_email for them to claim it. Once delivery zones are set up, the address must fall in an active zone that delivers on every chosen day, and that zone's delivery fee is charged. With address_id, the name, phone number and address come from that address book entry instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Create Subscription",
+                "parameters": [
+                    {
+                        "description": "Create Subscription Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.CreateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subscription created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body, validation error or no city or postal code while delivery zones are active",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Meal plan or address not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "422": {
+                        "description": "Address not covered or delivery day not served by its zone",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/admin/stats/active-total": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get total number of active subscriptions (admin only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Get Total Active Subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "Get total active subscriptions success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "object",
+                                            "properties": {
+                                                "count": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/admin/stats/mrr": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get Monthly Recurring Revenue (MRR) in a date range (admin only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Get MRR Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get MRR stats success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "object",
+                                            "properties": {
+                                                "mrr": {
+                                                    "type": "number"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/admin/stats/new": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get total number of new subscriptions in a date range (admin only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Get New Subscriptions Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get new subscriptions stats success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "object",
+                                            "properties": {
+                                                "count": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/admin/stats/reactivations": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get number of subscriptions that were reactivated in a date range (admin only).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Get Reactivation Stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get reactivation stats success",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "object",
+                                            "properties": {
+                                                "count": {
+                                                    "type": "integer"
+                                                }
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/quote": {
+            "post": {
+                "description": "Preview the itemized price of a subscription before checkout, using the same pricing as subscription creation. With postal_code or coordinates, the address is checked against the delivery zones and priced with its zone's fee.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Quote Subscription",
+                "parameters": [
+                    {
+                        "description": "Quote Subscription Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.QuoteSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Quote subscription successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.QuoteResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "422": {
+                        "description": "Address not covered or delivery day not served by its zone",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/webhook/payment": {
+            "post": {
+                "description": "Handle a payment notification from the configured payment gateway (Midtrans, Xendit or the local fake). The gateway authenticates the delivery: Midtrans signs the body, Xendit sends its callback token in x-callback-token. /subscriptions/webhook/midtrans is kept as an alias for existing Midtrans dashboards.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Handle Payment Webhook",
+                "parameters": [
+                    {
+                        "description": "Gateway notification",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook processed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid notification data or gross amount mismatch",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid signature key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Permanently cancel a subscription. This action cannot be undone. Payments covering days that will no longer be delivered get a pro-rata refund, less a fee, once an admin approves it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Cancel Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription cancelled successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Not authorized to cancel this subscription",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription cannot be cancelled in its current status",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change the meal plan, meal types or delivery days of an active or paused subscription. The price difference for the remaining days is prorated: downgrades are credited to the subscription, upgrades spend existing credit first and return a payment link for the rest. A charged change takes effect once it is paid.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Update Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Subscription Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UpdateSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UpdateSubscriptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID, request body or no changes requested",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription or meal plan not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription cannot be changed or already has a change awaiting payment",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "422": {
+                        "description": "Delivery day not served by the subscription's zone",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/address": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Move an active or paused subscription to an address from the address book, starting on effective_date. The date must fall after the next delivery cutoff and before the subscription ends, and the address must be covered by a delivery zone serving the subscription's delivery days. A change still waiting is replaced. The delivery fee stays as it is until the plan is next changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Change Delivery Address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Change Delivery Address Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.ChangeDeliveryAddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address change scheduled successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionAddressChangeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID, request body or effective date",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription or address not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription can only be changed while active or paused",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "422": {
+                        "description": "Address not covered or delivery day not served by its zone",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Withdraw a delivery address change that has not taken effect yet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Cancel Address Change",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address change cancelled successful",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found or no address change scheduled",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/address-changes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the delivery address changes scheduled for a subscription, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Get Address Changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get address changes successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionAddressChangeResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/auto-renew": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Turn automatic renewal on or off. Turning it off withdraws a renewal that has not been paid yet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Update Auto-Renew",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update Auto-Renew Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UpdateAutoRenewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Auto-renew updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription can no longer be renewed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/billing-periods": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List every billing period of a subscription, oldest first, including unpaid renewals and their payment links.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Get Billing Periods",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get billing periods successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.BillingPeriodResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/changes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the plan changes made to a subscription, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Get Subscription Changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get subscription changes successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionChangeResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the meals scheduled for one of the authenticated user's subscriptions, optionally within a date range. Each meal shows where it is on the day: picked up, delivered with a proof photo, or failed with a reason.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Delivery"
+                ],
+                "summary": "Get Subscription Deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD)",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD)",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get deliveries successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID or request params",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/invoices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the PDF invoices issued for the settled payments of one of the authenticated user's subscriptions, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get Subscription Invoices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get subscription invoices successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.InvoiceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/pause": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Temporarily pause a subscription by specifying start and end dates. The subscription duration will be extended by the pause period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Pause Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pause Subscription Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PauseSubscriptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription paused successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID or request body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "403": {
+                        "description": "Not authorized to pause this subscription",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription cannot be paused in its current status",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/pay": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Issue a new payment link for a subscription still awaiting its first payment. The previous payment link is voided and the new one charges the same amount.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Pay Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Payment link issued successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PaymentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription is not awaiting payment",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/payments": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the payment history of one of the authenticated user's subscriptions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Payment"
+                ],
+                "summary": "Get Subscription Payments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get subscription payments successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PaymentDetailResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/refunds": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the refunds opened when one of the authenticated user's subscriptions was cancelled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Refund"
+                ],
+                "summary": "Get Subscription Refunds",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get subscription refunds successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RefundResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscriptions/{id}/resume": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Resume a paused subscription before its pause window ends, or drop a pause that has not started yet. Unused pause days are taken off the extended end date.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Resume Subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Subscription ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Subscription resumed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid subscription ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Subscription not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "409": {
+                        "description": "Subscription is not paused",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/testimonials/": {
+            "get": {
+                "description": "Get all testimonials.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Testimonial"
+                ],
+                "summary": "Get All Testimonials",
+                "responses": {
+                    "200": {
+                        "description": "Get all testimonials successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.TestimonialResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a new testimonial with photo upload. Only for authenticated users.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Testimonial"
+                ],
+                "summary": "Create Testimonial",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "John Doe",
+                        "description": "Customer Name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "The food was delicious",
+                        "description": "Review Message",
+                        "name": "message",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "example": 5,
+                        "description": "Rating (1-5)",
+                        "name": "rating",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Photo of the meal",
+                        "name": "photo",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Create testimonial successful",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request (validation error)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/addresses": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "List the authenticated user's address book, default address first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Addresses",
+                "responses": {
+                    "200": {
+                        "description": "Get addresses successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UserAddressResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Add an address to the authenticated user's address book. The first address saved becomes the default; saving another with is_default moves the default to it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create Address",
+                "parameters": [
+                    {
+                        "description": "User Address Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UserAddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Address created successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UserAddressResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/addresses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get an address from the authenticated user's address book.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get Address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Address ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Get address successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UserAddressResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid address ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Address not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Replace an address in the authenticated user's address book. Subscriptions placed with it keep the address they were placed with. The default address stays the default until another address is made the default.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update Address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Address ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User Address Request",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UserAddressRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address updated successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UserAddressResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid address ID, request body or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Address not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Remove an address from the authenticated user's address book. When it was the default, the oldest remaining address becomes the default.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete Address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Address ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Address deleted successful",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid address ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "404": {
+                        "description": "Address not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the authenticated user's profile.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get User Profile",
+                "responses": {
+                    "200": {
+                        "description": "Get profile successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        },
+        "/wallet": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Retrieve the store credit balance of the authenticated user together with its ledger, newest first. Refunds and skipped deliveries are credited here and can be spent on a new subscription.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Wallet"
+                ],
+                "summary": "Get Wallet",
+                "responses": {
+                    "200": {
+                        "description": "Get wallet successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Res"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "payload": {
+                                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.WalletResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid access token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_infra_response.Err"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.AdjustWalletRequest": {
+            "type": "object",
+            "required": [
+                "amount",
+                "note",
+                "type"
+            ],
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "note": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Apology for the late delivery on 12 January"
+                },
+                "reference": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "TICKET-1042"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "credit",
+                        "debit"
+                    ],
+                    "example": "credit"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.BillingPeriodResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 180600
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-03-12"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "RENEW-b3e1f8e2..."
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "2025-02-08T10:00:00Z"
+                },
+                "payment_url": {
+                    "type": "string",
+                    "example": "https://app.sandbox.midtrans.com/snap/v3/redirection/66e4fa55..."
+                },
+                "period_number": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-02-10"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "paid"
+                },
+                "wallet_credit": {
+                    "type": "number",
+                    "example": 0
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.ChangeDeliveryAddressRequest": {
+            "type": "object",
+            "required": [
+                "address_id",
+                "effective_date"
+            ],
+            "properties": {
+                "address_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "effective_date": {
+                    "type": "string",
+                    "example": "2025-01-20"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.ClaimGiftRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20,
+                    "example": "GIFT-7KQ2-M9XD-4HPA"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.CreateMealPlanRequest": {
+            "type": "object",
+            "required": [
+                "description",
+                "name",
+                "photo_url",
+                "price"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "A healthy meal plan"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 3,
+                    "example": "Diet Plan"
+                },
+                "photo_url": {
+                    "type": "string",
+                    "example": "https://..."
+                },
+                "price": {
+                    "type": "number",
+                    "example": 30000
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.CreateSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "delivery_days",
+                "meal_plan_id",
+                "meal_types"
+            ],
+            "properties": {
+                "address_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "allergies": {
+                    "type": "string",
+                    "example": "Peanuts, Shellfish"
+                },
+                "auto_renew": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Jakarta Selatan"
+                },
+                "delivery_address": {
+                    "type": "string",
+                    "minLength": 10,
+                    "example": "123 Main St, Jakarta"
+                },
+                "delivery_days": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "monday",
+                        "tuesday",
+                        "wednesday"
+                    ]
+                },
+                "delivery_notes": {
+                    "type": "string",
+                    "example": "Please leave at the front door"
+                },
+                "gift": {
+                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.GiftRequest"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90,
+                    "example": -6.2443
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 106.8007
+                },
+                "meal_plan_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "meal_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "breakfast",
+                        "lunch"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "John Doe"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "minLength": 10,
+                    "example": "081234567890"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "12110"
+                },
+                "promo_code": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 3,
+                    "example": "HEALTHY10"
+                },
+                "use_wallet_credit": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryManifestItem": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "type": "string",
+                    "example": "Peanuts, Shellfish"
+                },
+                "delivery_address": {
+                    "type": "string",
+                    "example": "123 Main St, Jakarta"
+                },
+                "delivery_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
                 "delivery_notes": {
                     "type": "string",
-                    "example": "Please leave at the front door"
+                    "example": "Please leave at the front door"
+                },
+                "meal_plan": {
+                    "type": "string",
+                    "example": "Protein Plan"
+                },
+                "meal_type": {
+                    "type": "string",
+                    "example": "lunch"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "08123456789"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "scheduled"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryManifestResponse": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-15"
+                },
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryManifestItem"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "credit_amount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "delivered_at": {
+                    "type": "string",
+                    "example": "2025-01-15T11:45:00+07:00"
+                },
+                "delivery_date": {
+                    "type": "string",
+                    "example": "2025-01-15"
+                },
+                "failed_at": {
+                    "type": "string"
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "meal_type": {
+                    "type": "string",
+                    "example": "lunch"
+                },
+                "picked_up_at": {
+                    "type": "string",
+                    "example": "2025-01-15T10:30:00+07:00"
+                },
+                "proof_photo_url": {
+                    "type": "string",
+                    "example": "https://..."
+                },
+                "status": {
+                    "type": "string",
+                    "example": "delivered"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryZoneRequest": {
+            "type": "object",
+            "required": [
+                "city",
+                "delivery_days",
+                "is_active",
+                "name"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Bekasi"
+                },
+                "cutoff_minutes": {
+                    "type": "integer",
+                    "maximum": 10080,
+                    "minimum": 0,
+                    "example": 360
+                },
+                "delivery_days": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "monday",
+                        "wednesday",
+                        "friday"
+                    ]
+                },
+                "delivery_fee": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 10000
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Bekasi"
+                },
+                "polygon": {
+                    "type": "array",
+                    "minItems": 3,
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "postal_codes": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "171",
+                        "174"
+                    ]
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.DeliveryZoneResponse": {
+            "type": "object",
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "Bekasi"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-10T10:00:00+07:00"
+                },
+                "cutoff_minutes": {
+                    "type": "integer",
+                    "example": 360
+                },
+                "delivery_days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "monday",
+                        "wednesday",
+                        "friday"
+                    ]
+                },
+                "delivery_fee": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Bekasi"
+                },
+                "polygon": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "postal_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "171",
+                        "174"
+                    ]
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.GiftRequest": {
+            "type": "object",
+            "required": [
+                "recipient_email"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Happy birthday, Mom!"
+                },
+                "recipient_email": {
+                    "type": "string",
+                    "example": "mom@example.com"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.GiftResponse": {
+            "type": "object",
+            "properties": {
+                "claimed_at": {
+                    "type": "string",
+                    "example": "2025-01-11T08:00:00+07:00"
+                },
+                "code": {
+                    "type": "string",
+                    "example": "GIFT-7KQ2-M9XD-4HPA"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-10T10:00:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "issued_at": {
+                    "type": "string",
+                    "example": "2025-01-10T10:05:00+07:00"
+                },
+                "meal_plan": {
+                    "type": "string",
+                    "example": "Protein Plan"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Happy birthday, Mom!"
+                },
+                "recipient_email": {
+                    "type": "string",
+                    "example": "mom@example.com"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "example": "Jane Doe"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "issued"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "subscription_status": {
+                    "type": "string",
+                    "example": "active"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.InvoiceResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 859140
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-10T10:00:00+07:00"
+                },
+                "download_url": {
+                    "type": "string",
+                    "example": "https://example.supabase.co/storage/v1/object/public/media/invoices/b3e1f8e2.../INV-202501-000042.pdf"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "number": {
+                    "type": "string",
+                    "example": "INV-202501-000042"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "SUBS-b3e1f8e2..."
+                },
+                "paid_at": {
+                    "type": "string",
+                    "example": "2025-01-10T10:00:00+07:00"
+                },
+                "payment_type": {
+                    "type": "string",
+                    "example": "bank_transfer"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.KitchenAllergyCount": {
+            "type": "object",
+            "properties": {
+                "allergy": {
+                    "type": "string",
+                    "example": "peanuts"
+                },
+                "portions": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.KitchenForecastDay": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2025-01-15"
+                },
+                "day": {
+                    "type": "string",
+                    "example": "wednesday"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.KitchenForecastItem"
+                    }
+                },
+                "portions": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.KitchenForecastItem": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.KitchenAllergyCount"
+                    }
+                },
+                "meal_plan": {
+                    "type": "string",
+                    "example": "Protein Plan"
+                },
+                "meal_plan_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "meal_type": {
+                    "type": "string",
+                    "example": "lunch"
+                },
+                "portions": {
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.KitchenForecastResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.KitchenForecastDay"
+                    }
+                },
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-19"
+                },
+                "portions": {
+                    "type": "integer",
+                    "example": 640
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-13"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "example": "john@example.com"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "Str0ngP@ssword"
+                },
+                "remember_me": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.LogoutRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string",
+                    "example": "eyJhbGciOiJI..."
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.MealPlanResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "example": "A healthy meal plan"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Diet Plan"
+                },
+                "photo_url": {
+                    "type": "string",
+                    "example": "https://..."
+                },
+                "price": {
+                    "type": "number",
+                    "example": 30000
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.PauseSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "end_date",
+                "start_date"
+            ],
+            "properties": {
+                "end_date": {
+                    "type": "string",
+                    "example": "2025-01-30"
+                },
+                "start_date": {
+                    "type": "string",
+                    "example": "2025-01-15"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.PaymentDetailResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-10T10:00:00+07:00"
+                },
+                "fraud_status": {
+                    "type": "string",
+                    "example": "accept"
+                },
+                "gross_amount": {
+                    "type": "number",
+                    "example": 180600
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "SUBS-b3e1f8e2..."
+                },
+                "payment_type": {
+                    "type": "string",
+                    "example": "bank_transfer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "settlement"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "transaction_id": {
+                    "type": "string",
+                    "example": "9aed5972-5b6a-401e-894b-a32c91ed1a3a"
+                },
+                "transaction_time": {
+                    "type": "string",
+                    "example": "2025-01-10T10:00:00+07:00"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.PaymentResponse": {
+            "type": "object",
+            "properties": {
+                "redirect_url": {
+                    "type": "string",
+                    "example": "https://app.sandbox.midtrans.com/snap/v3/redirection/66e4fa55..."
+                },
+                "token": {
+                    "type": "string",
+                    "example": "66e4fa55..."
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.PromoRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "description",
+                "discount_type",
+                "discount_value",
+                "is_active",
+                "valid_from",
+                "valid_until"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 30,
+                    "minLength": 3,
+                    "example": "HEALTHY10"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "10% off your first month"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percentage",
+                        "fixed"
+                    ],
+                    "example": "percentage"
+                },
+                "discount_value": {
+                    "type": "number",
+                    "example": 10
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_discount": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "max_redemptions": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 100
+                },
+                "meal_plan_ids": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "b3e1f8e2..."
+                    ]
+                },
+                "min_spend": {
+                    "type": "number",
+                    "minimum": 0,
+                    "example": 200000
+                },
+                "per_user_limit": {
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.PromoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "HEALTHY10"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "description": {
+                    "type": "string",
+                    "example": "10% off your first month"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "discount_value": {
+                    "type": "number",
+                    "example": 10
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "is_active": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "max_discount": {
+                    "type": "number",
+                    "example": 50000
+                },
+                "max_redemptions": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "meal_plan_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "min_spend": {
+                    "type": "number",
+                    "example": 200000
+                },
+                "per_user_limit": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "redemption_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "valid_from": {
+                    "type": "string",
+                    "example": "2025-01-01"
+                },
+                "valid_until": {
+                    "type": "string",
+                    "example": "2025-01-31"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.QuoteItemResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer",
+                    "example": 387000
+                },
+                "id": {
+                    "type": "string",
+                    "example": "meal-breakfast"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "meal_type"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Breakfast meals"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "base_price": {
+                    "type": "integer",
+                    "example": 30000
+                },
+                "discount": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "fees": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.QuoteItemResponse"
+                    }
+                },
+                "meal_plan": {
+                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.MealPlanResponse"
+                },
+                "per_day": {
+                    "type": "integer",
+                    "example": 258000
+                },
+                "per_meal_type": {
+                    "type": "integer",
+                    "example": 387000
+                },
+                "subtotal": {
+                    "type": "integer",
+                    "example": 774000
+                },
+                "tax": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 774000
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.QuoteSubscriptionRequest": {
+            "type": "object",
+            "required": [
+                "delivery_days",
+                "meal_plan_id",
+                "meal_types"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Bekasi"
+                },
+                "delivery_address": {
+                    "type": "string",
+                    "example": "123 Main St, Bekasi"
+                },
+                "delivery_days": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "monday",
+                        "tuesday",
+                        "wednesday"
+                    ]
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90,
+                    "example": -6.2383
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 106.9756
+                },
+                "meal_plan_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "meal_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "breakfast",
+                        "lunch"
+                    ]
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "17141"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.ReconciliationItemResponse": {
+            "type": "object",
+            "properties": {
+                "gateway_status": {
+                    "type": "string",
+                    "example": "settlement"
+                },
+                "local_status": {
+                    "type": "string",
+                    "example": "pending"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Gross amount does not match subscription price"
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "SUBS-b3e1f8e2..."
+                },
+                "outcome": {
+                    "type": "string",
+                    "example": "fixed"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.ReconciliationReportResponse": {
+            "type": "object",
+            "properties": {
+                "finished_at": {
+                    "type": "string",
+                    "example": "2025-01-10T02:31:12+07:00"
                 },
-                "meal_plan_id": {
+                "fixed": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "id": {
                     "type": "string",
                     "example": "b3e1f8e2..."
                 },
-                "meal_types": {
+                "items": {
                     "type": "array",
-                    "minItems": 1,
                     "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "breakfast",
-                        "lunch"
-                    ]
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.ReconciliationItemResponse"
+                    }
                 },
-                "name": {
+                "matched": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "started_at": {
                     "type": "string",
-                    "maxLength": 50,
-                    "minLength": 3,
-                    "example": "John Doe"
+                    "example": "2025-01-10T02:30:00+07:00"
                 },
-                "phone_number": {
+                "unresolved": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
                     "type": "string",
-                    "minLength": 10,
-                    "example": "081234567890"
+                    "example": "eyJhbGciOiJI..."
                 }
             }
         },
-        "github_com_Ablebil_sea-catering-be_internal_domain_dto.LoginRequest": {
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RefundResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 419570
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-10T10:00:00+07:00"
+                },
+                "destination": {
+                    "type": "string",
+                    "example": "wallet"
+                },
+                "fee": {
+                    "type": "number",
+                    "example": 10000
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "order_id": {
+                    "type": "string",
+                    "example": "SUBS-b3e1f8e2..."
+                },
+                "paid_amount": {
+                    "type": "number",
+                    "example": 859140
+                },
+                "refunded_at": {
+                    "type": "string",
+                    "example": "2025-01-12T10:05:00+07:00"
+                },
+                "reject_reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "2025-01-12T10:00:00+07:00"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "requested"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "total_days": {
+                    "type": "integer",
+                    "example": 30
+                },
+                "undelivered_days": {
+                    "type": "integer",
+                    "example": 15
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RegisterRequest": {
             "type": "object",
             "required": [
                 "email",
+                "name",
                 "password"
             ],
             "properties": {
@@ -1462,35 +6278,178 @@ const docTemplate = `{
                     "type": "string",
                     "example": "john@example.com"
                 },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
                 "password": {
                     "type": "string",
                     "minLength": 8,
                     "example": "Str0ngP@ssword"
-                },
-                "remember_me": {
-                    "type": "boolean",
-                    "example": true
                 }
             }
         },
-        "github_com_Ablebil_sea-catering-be_internal_domain_dto.LogoutRequest": {
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RejectRefundRequest": {
             "type": "object",
             "required": [
-                "refresh_token"
+                "reason"
             ],
             "properties": {
-                "refresh_token": {
+                "reason": {
                     "type": "string",
-                    "example": "eyJhbGciOiJI..."
+                    "maxLength": 500,
+                    "example": "Subscription was cancelled after the refund window"
                 }
             }
         },
-        "github_com_Ablebil_sea-catering-be_internal_domain_dto.MealPlanResponse": {
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RouteManifestResponse": {
             "type": "object",
             "properties": {
-                "description": {
+                "date": {
                     "type": "string",
-                    "example": "A healthy meal plan"
+                    "example": "2025-01-15"
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "zones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RouteZone"
+                    }
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RouteStop": {
+            "type": "object",
+            "properties": {
+                "allergies": {
+                    "type": "string",
+                    "example": "Peanuts, Shellfish"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Jakarta Selatan"
+                },
+                "delivery_address": {
+                    "type": "string",
+                    "example": "123 Main St, Jakarta"
+                },
+                "delivery_notes": {
+                    "type": "string",
+                    "example": "Please leave at the front door"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": -6.2443
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 106.8007
+                },
+                "meal_plan": {
+                    "type": "string",
+                    "example": "Protein Plan"
+                },
+                "meals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RouteStopMeal"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "example": "John Doe"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "08123456789"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "12110"
+                },
+                "subscription_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RouteStopMeal": {
+            "type": "object",
+            "properties": {
+                "delivery_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "meal_type": {
+                    "type": "string",
+                    "example": "lunch"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "scheduled"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RouteZone": {
+            "type": "object",
+            "properties": {
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.RouteStop"
+                    }
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "zone_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "zone_name": {
+                    "type": "string",
+                    "example": "Jakarta Selatan"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionAddressChangeResponse": {
+            "type": "object",
+            "properties": {
+                "address_id": {
+                    "type": "string",
+                    "example": "c4f2a9f3..."
+                },
+                "applied_at": {
+                    "type": "string",
+                    "example": "2025-01-20T00:10:00Z"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Jakarta Pusat"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-15T09:55:00Z"
+                },
+                "delivery_address": {
+                    "type": "string",
+                    "example": "45 Office Park, Jakarta"
+                },
+                "delivery_notes": {
+                    "type": "string",
+                    "example": "Leave at the lobby"
+                },
+                "delivery_zone_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "effective_date": {
+                    "type": "string",
+                    "example": "2025-01-20"
                 },
                 "id": {
                     "type": "string",
@@ -1498,80 +6457,117 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string",
-                    "example": "Diet Plan"
+                    "example": "John Doe"
                 },
-                "photo_url": {
+                "phone_number": {
                     "type": "string",
-                    "example": "https://..."
+                    "example": "081234567890"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "10220"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "pending"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionChangeResponse": {
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string",
+                    "example": "2025-01-20T10:00:00Z"
+                },
+                "charge_amount": {
+                    "type": "number",
+                    "example": 30100
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-20T09:55:00Z"
+                },
+                "credit_applied": {
+                    "type": "number",
+                    "example": 0
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "new_delivery_days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "monday",
+                        "friday"
+                    ]
+                },
+                "new_meal_plan_id": {
+                    "type": "string",
+                    "example": "c4f2a9f3..."
+                },
+                "new_meal_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "breakfast",
+                        "dinner"
+                    ]
+                },
+                "new_total_price": {
+                    "type": "number",
+                    "example": 240800
+                },
+                "old_delivery_days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "monday",
+                        "tuesday",
+                        "wednesday"
+                    ]
+                },
+                "old_meal_plan_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "old_meal_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "breakfast",
+                        "lunch"
+                    ]
                 },
-                "price": {
+                "old_total_price": {
                     "type": "number",
-                    "example": 30000
-                }
-            }
-        },
-        "github_com_Ablebil_sea-catering-be_internal_domain_dto.PauseSubscriptionRequest": {
-            "type": "object",
-            "required": [
-                "end_date",
-                "start_date"
-            ],
-            "properties": {
-                "end_date": {
-                    "type": "string",
-                    "example": "2025-01-30"
+                    "example": 180600
                 },
-                "start_date": {
-                    "type": "string",
-                    "example": "2025-01-15"
-                }
-            }
-        },
-        "github_com_Ablebil_sea-catering-be_internal_domain_dto.PaymentResponse": {
-            "type": "object",
-            "properties": {
-                "redirect_url": {
+                "payment_url": {
                     "type": "string",
                     "example": "https://app.sandbox.midtrans.com/snap/v3/redirection/66e4fa55..."
                 },
-                "token": {
-                    "type": "string",
-                    "example": "66e4fa55..."
-                }
-            }
-        },
-        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RefreshTokenRequest": {
-            "type": "object",
-            "required": [
-                "refresh_token"
-            ],
-            "properties": {
-                "refresh_token": {
-                    "type": "string",
-                    "example": "eyJhbGciOiJI..."
-                }
-            }
-        },
-        "github_com_Ablebil_sea-catering-be_internal_domain_dto.RegisterRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "name",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "type": "string",
-                    "example": "john@example.com"
+                "prorated_amount": {
+                    "type": "number",
+                    "example": 30100
                 },
-                "name": {
-                    "type": "string",
-                    "example": "John Doe"
+                "remaining_days": {
+                    "type": "integer",
+                    "example": 15
                 },
-                "password": {
+                "status": {
                     "type": "string",
-                    "minLength": 8,
-                    "example": "Str0ngP@ssword"
+                    "example": "pending"
                 }
             }
         },
@@ -1582,10 +6578,22 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Peanuts, Shellfish"
                 },
+                "auto_renew": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Jakarta Selatan"
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2025-01-10"
                 },
+                "credit_balance": {
+                    "type": "number",
+                    "example": 0
+                },
                 "delivery_address": {
                     "type": "string"
                 },
@@ -1600,9 +6608,17 @@ const docTemplate = `{
                         "wednesday"
                     ]
                 },
+                "delivery_fee": {
+                    "type": "number",
+                    "example": 0
+                },
                 "delivery_notes": {
                     "type": "string"
                 },
+                "delivery_zone_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
                 "end_date": {
                     "type": "string",
                     "example": "2025-02-10"
@@ -1611,6 +6627,14 @@ const docTemplate = `{
                     "type": "string",
                     "example": "b3e1f8e2..."
                 },
+                "latitude": {
+                    "type": "number",
+                    "example": -6.2443
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 106.8007
+                },
                 "meal_plan": {
                     "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.MealPlanResponse"
                 },
@@ -1640,6 +6664,10 @@ const docTemplate = `{
                     "type": "string",
                     "example": "08123456789"
                 },
+                "postal_code": {
+                    "type": "string",
+                    "example": "12110"
+                },
                 "start_date": {
                     "type": "string",
                     "example": "2025-01-10"
@@ -1648,9 +6676,17 @@ const docTemplate = `{
                     "type": "string",
                     "example": "pending"
                 },
+                "subtotal": {
+                    "type": "number",
+                    "example": 774000
+                },
+                "tax_amount": {
+                    "type": "number",
+                    "example": 85140
+                },
                 "total_price": {
                     "type": "number",
-                    "example": 180000
+                    "example": 859140
                 }
             }
         },
@@ -1692,6 +6728,198 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.UpdateAutoRenewRequest": {
+            "type": "object",
+            "required": [
+                "auto_renew"
+            ],
+            "properties": {
+                "auto_renew": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.UpdateSubscriptionRequest": {
+            "type": "object",
+            "properties": {
+                "delivery_days": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "monday",
+                        "friday"
+                    ]
+                },
+                "meal_plan_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "meal_types": {
+                    "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "breakfast",
+                        "dinner"
+                    ]
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.UpdateSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionChangeResponse"
+                },
+                "payment": {
+                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.PaymentResponse"
+                },
+                "subscription": {
+                    "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.SubscriptionResponse"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.UpdateUserRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "courier",
+                        "admin"
+                    ],
+                    "example": "courier"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.UserAddressRequest": {
+            "type": "object",
+            "required": [
+                "address",
+                "city",
+                "label",
+                "phone_number",
+                "postal_code",
+                "recipient_name"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "minLength": 10,
+                    "example": "123 Main St, Jakarta"
+                },
+                "city": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "example": "Jakarta Selatan"
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "example": "Home"
+                },
+                "latitude": {
+                    "type": "number",
+                    "maximum": 90,
+                    "minimum": -90,
+                    "example": -6.2443
+                },
+                "longitude": {
+                    "type": "number",
+                    "maximum": 180,
+                    "minimum": -180,
+                    "example": 106.8007
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Please leave at the front door"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "minLength": 10,
+                    "example": "081234567890"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "12110"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "John Doe"
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.UserAddressResponse": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string",
+                    "example": "123 Main St, Jakarta"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Jakarta Selatan"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-10T10:00:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "is_default": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "label": {
+                    "type": "string",
+                    "example": "Home"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": -6.2443
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 106.8007
+                },
+                "notes": {
+                    "type": "string",
+                    "example": "Please leave at the front door"
+                },
+                "phone_number": {
+                    "type": "string",
+                    "example": "081234567890"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "12110"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "example": "John Doe"
+                }
+            }
+        },
         "github_com_Ablebil_sea-catering-be_internal_domain_dto.UserResponse": {
             "type": "object",
             "properties": {
@@ -1702,6 +6930,10 @@ const docTemplate = `{
                 "name": {
                     "type": "string",
                     "example": "John Doe"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "user"
                 }
             }
         },
@@ -1722,6 +6954,62 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.WalletResponse": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "number",
+                    "example": 75000
+                },
+                "transactions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_Ablebil_sea-catering-be_internal_domain_dto.WalletTransactionResponse"
+                    }
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                }
+            }
+        },
+        "github_com_Ablebil_sea-catering-be_internal_domain_dto.WalletTransactionResponse": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number",
+                    "example": 25000
+                },
+                "balance_after": {
+                    "type": "number",
+                    "example": 75000
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-01-12T10:00:00+07:00"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "b3e1f8e2..."
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Apology for the late delivery on 12 January"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "adjustment"
+                },
+                "reference": {
+                    "type": "string",
+                    "example": "TICKET-1042"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "credit"
+                }
+            }
+        },
         "github_com_Ablebil_sea-catering-be_internal_infra_response.Err": {
             "type": "object",
             "properties": {
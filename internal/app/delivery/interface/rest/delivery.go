@@ -0,0 +1,281 @@
+package rest
+
+import (
+	"mime/multipart"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+	"github.com/Ablebil/sea-catering-be/internal/app/delivery/usecase"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/helper"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DeliveryHandler struct {
+	Validator       *validator.Validate
+	DeliveryUsecase usecase.DeliveryUsecaseItf
+	helper          helper.HelperItf
+	conf            *conf.Config
+}
+
+func NewDeliveryHandler(routerGroup fiber.Router, validator *validator.Validate, deliveryUsecase usecase.DeliveryUsecaseItf, middleware middleware.MiddlewareItf, helper helper.HelperItf, conf *conf.Config) {
+	deliveryHandler := DeliveryHandler{
+		Validator:       validator,
+		DeliveryUsecase: deliveryUsecase,
+		helper:          helper,
+		conf:            conf,
+	}
+
+	routerGroup.Get("/subscriptions/:id/deliveries", middleware.Authentication, deliveryHandler.GetSubscriptionDeliveries)
+	routerGroup.Put("/deliveries/:id/skip", middleware.Authentication, deliveryHandler.SkipDelivery)
+	routerGroup.Put("/deliveries/:id/unskip", middleware.Authentication, deliveryHandler.UnskipDelivery)
+
+	adminRouterGroup := routerGroup.Group("/admin/deliveries", middleware.Authentication, middleware.Authorization)
+	adminRouterGroup.Get("/manifest", deliveryHandler.GetDeliveryManifest)
+
+	courierRouterGroup := routerGroup.Group("/courier", middleware.Authentication, middleware.CourierAuthorization)
+	courierRouterGroup.Get("/routes", deliveryHandler.GetRouteManifest)
+	courierRouterGroup.Put("/deliveries/:id/status", deliveryHandler.UpdateDeliveryStatus)
+}
+
+// @Summary      Get Subscription Deliveries
+// @Description  List the meals scheduled for one of the authenticated user's subscriptions, optionally within a date range. Each meal shows where it is on the day: picked up, delivered with a proof photo, or failed with a reason.
+// @Tags         Delivery
+// @Produce      json
+// @Param        id         path  string true  "Subscription ID" Format(uuid)
+// @Param        start_date query string false "Start date (YYYY-MM-DD)"
+// @Param        end_date   query string false "End date (YYYY-MM-DD)"
+// @Success      200  {object}  res.Res{payload=[]dto.DeliveryResponse} "Get deliveries successful"
+// @Failure      400  {object}  res.Err "Invalid subscription ID or request params"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/deliveries [get]
+func (h DeliveryHandler) GetSubscriptionDeliveries(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	req := new(dto.GetDeliveriesRequest)
+	if err := ctx.QueryParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestParams)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	deliveries, resErr := h.DeliveryUsecase.GetSubscriptionDeliveries(userID, id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, deliveries, res.GetDeliveriesSuccess)
+}
+
+// @Summary      Skip Delivery
+// @Description  Skip a single scheduled meal before its cutoff. The meal's share of the subscription price is credited to the customer's wallet.
+// @Tags         Delivery
+// @Produce      json
+// @Param        id   path      string  true  "Delivery ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=dto.DeliveryResponse} "Delivery skipped successfully"
+// @Failure      400  {object}  res.Err "Invalid delivery ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Delivery not found"
+// @Failure      409  {object}  res.Err "Delivery cannot be skipped or its cutoff has passed"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /deliveries/{id}/skip [put]
+func (h DeliveryHandler) SkipDelivery(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidDeliveryID)
+	}
+
+	delivery, resErr := h.DeliveryUsecase.SkipDelivery(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, delivery, res.SkipDeliverySuccess)
+}
+
+// @Summary      Unskip Delivery
+// @Description  Restore a skipped meal before its cutoff, taking back the credit it added.
+// @Tags         Delivery
+// @Produce      json
+// @Param        id   path      string  true  "Delivery ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=dto.DeliveryResponse} "Delivery unskipped successfully"
+// @Failure      400  {object}  res.Err "Invalid delivery ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Delivery not found"
+// @Failure      409  {object}  res.Err "Delivery is not skipped, its cutoff has passed or its credit was already used"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /deliveries/{id}/unskip [put]
+func (h DeliveryHandler) UnskipDelivery(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidDeliveryID)
+	}
+
+	delivery, resErr := h.DeliveryUsecase.UnskipDelivery(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, delivery, res.UnskipDeliverySuccess)
+}
+
+// @Summary      Get Delivery Manifest
+// @Description  List every meal to be delivered on a date, leaving out skipped ones, with the subscriber's contact, address and allergies (admin only).
+// @Tags         Delivery
+// @Produce      json
+// @Param        date query string true "Delivery date (YYYY-MM-DD)"
+// @Success      200  {object}  res.Res{payload=dto.DeliveryManifestResponse} "Get delivery manifest successful"
+// @Failure      400  {object}  res.Err "Invalid request params"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/deliveries/manifest [get]
+func (h DeliveryHandler) GetDeliveryManifest(ctx *fiber.Ctx) error {
+	req := new(dto.GetDeliveryManifestRequest)
+	if err := ctx.QueryParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestParams)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	manifest, err := h.DeliveryUsecase.GetDeliveryManifest(*req)
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, manifest, res.GetDeliveryManifestSuccess)
+}
+
+// @Summary      Get Route Manifest
+// @Description  List the meals couriers take out on a date, one route per delivery zone and one stop per subscription, ordered by postal code. Subscriptions without a zone are grouped last as "Unzoned" (couriers and admins only).
+// @Tags         Courier
+// @Produce      json
+// @Param        date      query string true  "Delivery date (YYYY-MM-DD)"
+// @Param        zone_id   query string false "Only this delivery zone" Format(uuid)
+// @Param        meal_type query string false "Only this meal type" Enums(breakfast, lunch, dinner)
+// @Success      200  {object}  res.Res{payload=dto.RouteManifestResponse} "Get route manifest successful"
+// @Failure      400  {object}  res.Err "Invalid request params"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Courier access required"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /courier/routes [get]
+func (h DeliveryHandler) GetRouteManifest(ctx *fiber.Ctx) error {
+	req := new(dto.GetRouteManifestRequest)
+	if err := ctx.QueryParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestParams)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	manifest, err := h.DeliveryUsecase.GetRouteManifest(*req)
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, manifest, res.GetRouteManifestSuccess)
+}
+
+// @Summary      Update Delivery Status
+// @Description  Pick up a scheduled meal due today, then mark it delivered with a proof photo or failed with a reason and optionally a photo of the attempt. Only the courier who picked a meal up, or an admin, can finish it (couriers and admins only).
+// @Tags         Courier
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id             path     string true  "Delivery ID" Format(uuid)
+// @Param        status         formData string true  "New status" Enums(picked_up, delivered, failed)
+// @Param        failure_reason formData string false "Why the delivery failed, required when failed" example(Nobody at home)
+// @Param        photo          formData file   false "Proof photo, required when delivered"
+// @Success      200  {object}  res.Res{payload=dto.DeliveryResponse} "Delivery status updated successful"
+// @Failure      400  {object}  res.Err "Invalid delivery ID, form data or missing proof photo"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Courier access required"
+// @Failure      404  {object}  res.Err "Delivery not found"
+// @Failure      409  {object}  res.Err "Delivery is not due today, not picked up or held by another courier"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /courier/deliveries/{id}/status [put]
+func (h DeliveryHandler) UpdateDeliveryStatus(ctx *fiber.Ctx) error {
+	courierID := ctx.Locals("userID").(uuid.UUID)
+	role := ctx.Locals("role").(entity.UserRole)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidDeliveryID)
+	}
+
+	req := new(dto.UpdateDeliveryStatusRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.InvalidFormData)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	var file multipart.File
+	fileHeader, err := ctx.FormFile("photo")
+	if err == nil {
+		file, err = fileHeader.Open()
+		if err != nil {
+			return res.ErrInternalServerError(res.FailedToOpenFile)
+		}
+
+		maxSize := int64(h.conf.MaxFileSize) * 1024 * 1024
+		if err := h.helper.ValidateImageFile(file, fileHeader, maxSize); err != nil {
+			file.Close()
+			return err
+		}
+	}
+
+	delivery, resErr := h.DeliveryUsecase.UpdateDeliveryStatus(courierID, role, id, *req, file, fileHeader)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, delivery, res.UpdateDeliveryStatusSuccess)
+}
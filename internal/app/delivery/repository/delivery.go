@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeliveryRepositoryItf interface {
+	WithTx(tx *gorm.DB) DeliveryRepositoryItf
+	CreateDeliveries(deliveries []entity.Delivery) error
+	UpdateDelivery(delivery *entity.Delivery) error
+	DeleteScheduledDeliveriesFrom(subscriptionID uuid.UUID, from time.Time) error
+	DeleteDeliveries(ids []uuid.UUID) error
+	GetDeliveryByID(id uuid.UUID) (*entity.Delivery, error)
+	GetDeliveryByIDForUpdate(id uuid.UUID) (*entity.Delivery, error)
+	GetSkippedDeliveriesFrom(subscriptionID uuid.UUID, from time.Time) ([]entity.Delivery, error)
+	GetDeliveriesBySubscriptionID(subscriptionID uuid.UUID, start *time.Time, end *time.Time) ([]entity.Delivery, error)
+	GetDeliveriesByDate(date time.Time) ([]entity.Delivery, error)
+	GetRouteDeliveries(date time.Time, zoneID *uuid.UUID, mealType string) ([]entity.Delivery, error)
+}
+
+type DeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewDeliveryRepository(db *gorm.DB) DeliveryRepositoryItf {
+	return &DeliveryRepository{
+		db: db,
+	}
+}
+
+func (r *DeliveryRepository) WithTx(tx *gorm.DB) DeliveryRepositoryItf {
+	return &DeliveryRepository{
+		db: tx,
+	}
+}
+
+// CreateDeliveries inserts the given deliveries, leaving alone any slot that
+// already has one so delivered meals keep their record.
+func (r *DeliveryRepository) CreateDeliveries(deliveries []entity.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(deliveries, 100).Error
+}
+
+func (r *DeliveryRepository) UpdateDelivery(delivery *entity.Delivery) error {
+	return r.db.Save(delivery).Error
+}
+
+func (r *DeliveryRepository) DeleteScheduledDeliveriesFrom(subscriptionID uuid.UUID, from time.Time) error {
+	return r.db.Where("subscription_id = ? AND delivery_date >= ? AND status = ?", subscriptionID, from, entity.DeliveryScheduled).
+		Delete(&entity.Delivery{}).Error
+}
+
+func (r *DeliveryRepository) DeleteDeliveries(ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return r.db.Where("id IN ?", ids).Delete(&entity.Delivery{}).Error
+}
+
+func (r *DeliveryRepository) GetDeliveryByID(id uuid.UUID) (*entity.Delivery, error) {
+	var delivery entity.Delivery
+	err := r.db.Preload("Subscription.DeliveryZone").Where("id = ?", id).First(&delivery).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveryByIDForUpdate locks the delivery row until the surrounding
+// transaction ends. It must be called on a repository from WithTx.
+func (r *DeliveryRepository) GetDeliveryByIDForUpdate(id uuid.UUID) (*entity.Delivery, error) {
+	var delivery entity.Delivery
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&delivery).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &delivery, nil
+}
+
+func (r *DeliveryRepository) GetSkippedDeliveriesFrom(subscriptionID uuid.UUID, from time.Time) ([]entity.Delivery, error) {
+	var deliveries []entity.Delivery
+	err := r.db.Where("subscription_id = ? AND delivery_date >= ? AND status = ?", subscriptionID, from, entity.DeliverySkipped).Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *DeliveryRepository) GetDeliveriesBySubscriptionID(subscriptionID uuid.UUID, start *time.Time, end *time.Time) ([]entity.Delivery, error) {
+	var deliveries []entity.Delivery
+	query := r.db.Where("subscription_id = ?", subscriptionID)
+
+	if start != nil && end != nil {
+		query = query.Where("delivery_date BETWEEN ? AND ?", *start, *end)
+	}
+
+	err := query.Order("delivery_date asc, meal_type asc").Find(&deliveries).Error
+	return deliveries, err
+}
+
+func (r *DeliveryRepository) GetDeliveriesByDate(date time.Time) ([]entity.Delivery, error) {
+	var deliveries []entity.Delivery
+	err := r.db.Preload("Subscription").Preload("Subscription.MealPlan").
+		Where("delivery_date = ? AND status <> ?", date, entity.DeliverySkipped).
+		Order("subscription_id asc, meal_type asc").
+		Find(&deliveries).Error
+	return deliveries, err
+}
+
+// GetRouteDeliveries lists the meals couriers take out on a date, optionally
+// only those in one delivery zone or of one meal type, with the subscription,
+// its meal plan and its zone loaded.
+func (r *DeliveryRepository) GetRouteDeliveries(date time.Time, zoneID *uuid.UUID, mealType string) ([]entity.Delivery, error) {
+	var deliveries []entity.Delivery
+	query := r.db.Preload("Subscription.MealPlan").Preload("Subscription.DeliveryZone").
+		Where("delivery_date = ? AND status <> ?", date, entity.DeliverySkipped)
+
+	if zoneID != nil {
+		query = query.Where("subscription_id IN (?)", r.db.Model(&entity.Subscription{}).Select("id").Where("delivery_zone_id = ?", *zoneID))
+	}
+
+	if mealType != "" {
+		query = query.Where("meal_type = ?", mealType)
+	}
+
+	err := query.Order("subscription_id asc, meal_type asc").Find(&deliveries).Error
+	return deliveries, err
+}
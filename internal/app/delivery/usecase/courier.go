@@ -0,0 +1,291 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"mime/multipart"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/infra/event"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const proofPhotoBucket = "media"
+
+// unzonedRoute names the route of subscriptions placed before delivery zones
+// were set up, or whose zone has since been deleted.
+const unzonedRoute = "Unzoned"
+
+var mealOrder = map[string]int{
+	"breakfast": 0,
+	"lunch":     1,
+	"dinner":    2,
+}
+
+// GetRouteManifest lists a day's meals for couriers, one route per delivery
+// zone and one stop per subscription, so meals for the same address go out
+// together. Stops are ordered by postal code to keep neighbours close.
+func (uc *DeliveryUsecase) GetRouteManifest(req dto.GetRouteManifestRequest) (*dto.RouteManifestResponse, *res.Err) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, res.ErrBadRequest(res.InvalidDate)
+	}
+
+	var zoneID *uuid.UUID
+	if req.ZoneID != "" {
+		id, err := uuid.Parse(req.ZoneID)
+		if err != nil {
+			return nil, res.ErrBadRequest(res.InvalidDeliveryZoneID)
+		}
+
+		zoneID = &id
+	}
+
+	deliveries, err := uc.DeliveryRepository.GetRouteDeliveries(date, zoneID, req.MealType)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetDeliveries)
+	}
+
+	type route struct {
+		zone  dto.RouteZone
+		stops []*dto.RouteStop
+	}
+
+	routes := make(map[string]*route)
+	stops := make(map[uuid.UUID]*dto.RouteStop)
+
+	for _, d := range deliveries {
+		sub := d.Subscription
+		if sub == nil {
+			continue
+		}
+
+		key, zone := "", dto.RouteZone{ZoneName: unzonedRoute}
+		if sub.DeliveryZone != nil {
+			key = sub.DeliveryZone.ID.String()
+			zone = dto.RouteZone{ZoneID: &sub.DeliveryZone.ID, ZoneName: sub.DeliveryZone.Name}
+		}
+
+		r, ok := routes[key]
+		if !ok {
+			r = &route{zone: zone}
+			routes[key] = r
+		}
+
+		stop, ok := stops[sub.ID]
+		if !ok {
+			stop = toRouteStop(sub)
+			stops[sub.ID] = stop
+			r.stops = append(r.stops, stop)
+		}
+
+		stop.Meals = append(stop.Meals, dto.RouteStopMeal{
+			DeliveryID: d.ID,
+			MealType:   d.MealType,
+			Status:     string(d.Status),
+		})
+		r.zone.Total++
+	}
+
+	zones := make([]dto.RouteZone, 0, len(routes))
+	for _, r := range routes {
+		sort.Slice(r.stops, func(i, j int) bool {
+			a, b := r.stops[i], r.stops[j]
+			if derefString(a.PostalCode) != derefString(b.PostalCode) {
+				return derefString(a.PostalCode) < derefString(b.PostalCode)
+			}
+
+			return a.DeliveryAddress < b.DeliveryAddress
+		})
+
+		r.zone.Stops = make([]dto.RouteStop, 0, len(r.stops))
+		for _, stop := range r.stops {
+			sort.SliceStable(stop.Meals, func(i, j int) bool {
+				return mealOrder[stop.Meals[i].MealType] < mealOrder[stop.Meals[j].MealType]
+			})
+
+			r.zone.Stops = append(r.zone.Stops, *stop)
+		}
+
+		zones = append(zones, r.zone)
+	}
+
+	sort.Slice(zones, func(i, j int) bool {
+		if (zones[i].ZoneID == nil) != (zones[j].ZoneID == nil) {
+			return zones[j].ZoneID == nil
+		}
+
+		return zones[i].ZoneName < zones[j].ZoneName
+	})
+
+	return &dto.RouteManifestResponse{
+		Date:  req.Date,
+		Total: len(deliveries),
+		Zones: zones,
+	}, nil
+}
+
+// UpdateDeliveryStatus moves a meal along its run. A courier picks up a
+// scheduled meal on its delivery date, then marks it delivered, which needs a
+// proof photo, or failed, which needs a reason and may carry a photo of the
+// attempt. Only the courier who picked a meal up, or an admin, can finish it.
+func (uc *DeliveryUsecase) UpdateDeliveryStatus(courierID uuid.UUID, role entity.UserRole, deliveryID uuid.UUID, req dto.UpdateDeliveryStatusRequest, photo multipart.File, photoHeader *multipart.FileHeader) (*dto.DeliveryResponse, *res.Err) {
+	if photo != nil {
+		defer photo.Close()
+	}
+
+	status := entity.DeliveryStatus(req.Status)
+	if status == entity.DeliveryDelivered && photo == nil {
+		return nil, res.ErrBadRequest(res.ProofPhotoRequired)
+	}
+
+	delivery, err := uc.DeliveryRepository.GetDeliveryByID(deliveryID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetDeliveries)
+	}
+
+	if delivery == nil || delivery.Subscription == nil {
+		return nil, res.ErrNotFound(res.DeliveryNotFound)
+	}
+
+	customerID := delivery.Subscription.UserID
+
+	// Checked here as well as under the lock so a photo is not uploaded for
+	// a change that cannot be made.
+	if resErr := checkDeliveryStatus(delivery, status, courierID, role); resErr != nil {
+		return nil, resErr
+	}
+
+	var photoURL *string
+	var fileName string
+	if photo != nil && status != entity.DeliveryPickedUp {
+		fileName = fmt.Sprintf("deliveries/%s-%s%s", delivery.ID, uuid.New(), filepath.Ext(photoHeader.Filename))
+
+		url, err := uc.supabase.UploadFile(photo, proofPhotoBucket, fileName, photoHeader.Header.Get("Content-Type"))
+		if err != nil {
+			return nil, res.ErrInternalServerError(res.FailedUploadFile)
+		}
+
+		photoURL = &url
+	}
+
+	var resErr *res.Err
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		deliveryRepo := uc.DeliveryRepository.WithTx(tx)
+
+		delivery, err = deliveryRepo.GetDeliveryByIDForUpdate(deliveryID)
+		if err != nil {
+			return err
+		}
+
+		if delivery == nil {
+			resErr = res.ErrNotFound(res.DeliveryNotFound)
+			return resErr
+		}
+
+		if resErr = checkDeliveryStatus(delivery, status, courierID, role); resErr != nil {
+			return resErr
+		}
+
+		now := time.Now()
+		delivery.Status = status
+
+		switch status {
+		case entity.DeliveryPickedUp:
+			delivery.CourierID = &courierID
+			delivery.PickedUpAt = &now
+		case entity.DeliveryDelivered:
+			delivery.DeliveredAt = &now
+		case entity.DeliveryFailed:
+			reason := req.FailureReason
+			delivery.FailedAt = &now
+			delivery.FailureReason = &reason
+		}
+
+		if photoURL != nil {
+			delivery.ProofPhotoURL = photoURL
+		}
+
+		return deliveryRepo.UpdateDelivery(delivery)
+	})
+
+	if (resErr != nil || err != nil) && photoURL != nil {
+		go uc.supabase.DeleteFile(proofPhotoBucket, []string{fileName})
+	}
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedUpdateDeliveryStatus)
+	}
+
+	if err := uc.event.Publish(customerID, event.DeliveryStatusChanged, dto.DeliveryStatusEvent{
+		DeliveryID:     delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		DeliveryDate:   delivery.DeliveryDate,
+		MealType:       delivery.MealType,
+		Status:         string(delivery.Status),
+	}); err != nil {
+		log.Printf("Failed to publish %s event for user %s: %v", event.DeliveryStatusChanged, customerID, err)
+	}
+
+	resp := toDeliveryResponse(delivery)
+	return &resp, nil
+}
+
+func checkDeliveryStatus(delivery *entity.Delivery, status entity.DeliveryStatus, courierID uuid.UUID, role entity.UserRole) *res.Err {
+	if status == entity.DeliveryPickedUp {
+		if delivery.Status != entity.DeliveryScheduled || delivery.DeliveryDate.Format("2006-01-02") != time.Now().Format("2006-01-02") {
+			return res.ErrConflict(res.DeliveryNotPickable)
+		}
+
+		return nil
+	}
+
+	if delivery.Status != entity.DeliveryPickedUp {
+		return res.ErrConflict(res.DeliveryNotPickedUp)
+	}
+
+	if role != entity.RoleAdmin && (delivery.CourierID == nil || *delivery.CourierID != courierID) {
+		return res.ErrConflict(res.DeliveryHeldByOther)
+	}
+
+	return nil
+}
+
+func toRouteStop(sub *entity.Subscription) *dto.RouteStop {
+	stop := &dto.RouteStop{
+		SubscriptionID:  sub.ID,
+		Name:            sub.Name,
+		PhoneNumber:     sub.PhoneNumber,
+		DeliveryAddress: sub.DeliveryAddress,
+		City:            sub.City,
+		PostalCode:      sub.PostalCode,
+		Latitude:        sub.Latitude,
+		Longitude:       sub.Longitude,
+		DeliveryNotes:   sub.DeliveryNotes,
+		Allergies:       sub.Allergies,
+	}
+
+	if sub.MealPlan != nil {
+		stop.MealPlan = sub.MealPlan.Name
+	}
+
+	return stop
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"mime/multipart"
+	"strings"
+	"time"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+	deliveryRepository "github.com/Ablebil/sea-catering-be/internal/app/delivery/repository"
+	subscriptionRepository "github.com/Ablebil/sea-catering-be/internal/app/subscription/repository"
+	walletRepository "github.com/Ablebil/sea-catering-be/internal/app/wallet/repository"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/infra/event"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/infra/supabase"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/helper"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DeliveryUsecaseItf interface {
+	GetSubscriptionDeliveries(userID uuid.UUID, subscriptionID uuid.UUID, req dto.GetDeliveriesRequest) ([]dto.DeliveryResponse, *res.Err)
+	GetDeliveryManifest(req dto.GetDeliveryManifestRequest) (*dto.DeliveryManifestResponse, *res.Err)
+	SkipDelivery(userID uuid.UUID, deliveryID uuid.UUID) (*dto.DeliveryResponse, *res.Err)
+	UnskipDelivery(userID uuid.UUID, deliveryID uuid.UUID) (*dto.DeliveryResponse, *res.Err)
+	GetRouteManifest(req dto.GetRouteManifestRequest) (*dto.RouteManifestResponse, *res.Err)
+	UpdateDeliveryStatus(courierID uuid.UUID, role entity.UserRole, deliveryID uuid.UUID, req dto.UpdateDeliveryStatusRequest, photo multipart.File, photoHeader *multipart.FileHeader) (*dto.DeliveryResponse, *res.Err)
+}
+
+type DeliveryUsecase struct {
+	DeliveryRepository     deliveryRepository.DeliveryRepositoryItf
+	SubscriptionRepository subscriptionRepository.SubscriptionRepositoryItf
+	WalletRepository       walletRepository.WalletRepositoryItf
+	db                     *gorm.DB
+	conf                   *conf.Config
+	supabase               supabase.SupabaseItf
+	event                  event.EventItf
+	helper                 helper.HelperItf
+}
+
+func NewDeliveryUsecase(deliveryRepository deliveryRepository.DeliveryRepositoryItf, subscriptionRepository subscriptionRepository.SubscriptionRepositoryItf, walletRepository walletRepository.WalletRepositoryItf, db *gorm.DB, conf *conf.Config, supabase supabase.SupabaseItf, event event.EventItf, helper helper.HelperItf) DeliveryUsecaseItf {
+	return &DeliveryUsecase{
+		DeliveryRepository:     deliveryRepository,
+		SubscriptionRepository: subscriptionRepository,
+		WalletRepository:       walletRepository,
+		db:                     db,
+		conf:                   conf,
+		supabase:               supabase,
+		event:                  event,
+		helper:                 helper,
+	}
+}
+
+func (uc *DeliveryUsecase) GetSubscriptionDeliveries(userID uuid.UUID, subscriptionID uuid.UUID, req dto.GetDeliveriesRequest) ([]dto.DeliveryResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	var start, end *time.Time
+	if req.StartDate != "" && req.EndDate != "" {
+		startDate, endDate, err := uc.helper.ParseDateRange(req.StartDate, req.EndDate)
+		if err != nil {
+			return nil, err
+		}
+
+		start, end = &startDate, &endDate
+	}
+
+	deliveries, err := uc.DeliveryRepository.GetDeliveriesBySubscriptionID(sub.ID, start, end)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetDeliveries)
+	}
+
+	result := make([]dto.DeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		result = append(result, toDeliveryResponse(&deliveries[i]))
+	}
+
+	return result, nil
+}
+
+func (uc *DeliveryUsecase) GetDeliveryManifest(req dto.GetDeliveryManifestRequest) (*dto.DeliveryManifestResponse, *res.Err) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, res.ErrBadRequest(res.InvalidDate)
+	}
+
+	deliveries, err := uc.DeliveryRepository.GetDeliveriesByDate(date)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetDeliveries)
+	}
+
+	items := make([]dto.DeliveryManifestItem, 0, len(deliveries))
+	for _, d := range deliveries {
+		item := dto.DeliveryManifestItem{
+			DeliveryID:     d.ID,
+			SubscriptionID: d.SubscriptionID,
+			MealType:       d.MealType,
+			Status:         string(d.Status),
+		}
+
+		if sub := d.Subscription; sub != nil {
+			item.Name = sub.Name
+			item.PhoneNumber = sub.PhoneNumber
+			item.DeliveryAddress = sub.DeliveryAddress
+			item.DeliveryNotes = sub.DeliveryNotes
+			item.Allergies = sub.Allergies
+
+			if sub.MealPlan != nil {
+				item.MealPlan = sub.MealPlan.Name
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return &dto.DeliveryManifestResponse{
+		Date:       req.Date,
+		Total:      len(items),
+		Deliveries: items,
+	}, nil
+}
+
+// SkipDelivery cancels a single meal before its cutoff. The meal's share of
+// the subscription price is credited to the customer's wallet.
+func (uc *DeliveryUsecase) SkipDelivery(userID uuid.UUID, deliveryID uuid.UUID) (*dto.DeliveryResponse, *res.Err) {
+	return uc.updateSkip(userID, deliveryID, func(walletRepo walletRepository.WalletRepositoryItf, delivery *entity.Delivery, sub *entity.Subscription) *res.Err {
+		if delivery.Status != entity.DeliveryScheduled || sub.Status != entity.StatusActive {
+			return res.ErrConflict(res.DeliveryNotSkippable)
+		}
+
+		delivery.Status = entity.DeliverySkipped
+		delivery.CreditAmount = mealPrice(sub)
+
+		reference := delivery.ID.String()
+		if err := walletRepo.Credit(&entity.WalletTransaction{
+			UserID:    sub.UserID,
+			Amount:    delivery.CreditAmount,
+			Reason:    entity.WalletSkipCredit,
+			Reference: &reference,
+		}); err != nil {
+			return res.ErrInternalServerError(res.FailedSkipDelivery)
+		}
+
+		return nil
+	}, res.FailedSkipDelivery)
+}
+
+// UnskipDelivery restores a skipped meal before its cutoff and takes back the
+// credit it earned. It fails once that credit has been spent.
+func (uc *DeliveryUsecase) UnskipDelivery(userID uuid.UUID, deliveryID uuid.UUID) (*dto.DeliveryResponse, *res.Err) {
+	return uc.updateSkip(userID, deliveryID, func(walletRepo walletRepository.WalletRepositoryItf, delivery *entity.Delivery, sub *entity.Subscription) *res.Err {
+		if delivery.Status != entity.DeliverySkipped {
+			return res.ErrConflict(res.DeliveryNotSkipped)
+		}
+
+		reference := delivery.ID.String()
+		credited, err := walletRepo.GetTransactionByReference(sub.UserID, entity.WalletSkipCredit, reference)
+		if err != nil {
+			return res.ErrInternalServerError(res.FailedUnskipDelivery)
+		}
+
+		// Skips from before wallets existed were credited to the subscription.
+		if credited == nil {
+			if sub.CreditBalance < delivery.CreditAmount {
+				return res.ErrConflict(res.SkipCreditAlreadyUsed)
+			}
+
+			sub.CreditBalance -= delivery.CreditAmount
+		} else {
+			ok, err := walletRepo.Debit(&entity.WalletTransaction{
+				UserID:    sub.UserID,
+				Amount:    delivery.CreditAmount,
+				Reason:    entity.WalletSkipReversal,
+				Reference: &reference,
+			})
+			if err != nil {
+				return res.ErrInternalServerError(res.FailedUnskipDelivery)
+			}
+
+			if !ok {
+				return res.ErrConflict(res.SkipCreditAlreadyUsed)
+			}
+		}
+
+		delivery.Status = entity.DeliveryScheduled
+		delivery.CreditAmount = 0
+
+		return nil
+	}, res.FailedUnskipDelivery)
+}
+
+// updateSkip loads a delivery owned by the user, checks its cutoff and runs
+// apply with both the delivery and its subscription locked.
+func (uc *DeliveryUsecase) updateSkip(userID uuid.UUID, deliveryID uuid.UUID, apply func(walletRepo walletRepository.WalletRepositoryItf, delivery *entity.Delivery, sub *entity.Subscription) *res.Err, failure string) (*dto.DeliveryResponse, *res.Err) {
+	delivery, err := uc.DeliveryRepository.GetDeliveryByID(deliveryID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetDeliveries)
+	}
+
+	if delivery == nil || delivery.Subscription == nil || delivery.Subscription.UserID != userID {
+		return nil, res.ErrNotFound(res.DeliveryNotFound)
+	}
+
+	if uc.cutoffPassed(delivery.DeliveryDate, delivery.Subscription.DeliveryZone) {
+		return nil, res.ErrConflict(res.DeliveryCutoffPassed)
+	}
+
+	var resErr *res.Err
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		subscriptionRepo := uc.SubscriptionRepository.WithTx(tx)
+		deliveryRepo := uc.DeliveryRepository.WithTx(tx)
+
+		sub, err := subscriptionRepo.GetSubscriptionByIDForUpdate(delivery.SubscriptionID)
+		if err != nil {
+			return err
+		}
+
+		if sub == nil {
+			resErr = res.ErrNotFound(res.SubscriptionNotFound)
+			return resErr
+		}
+
+		delivery, err = deliveryRepo.GetDeliveryByIDForUpdate(deliveryID)
+		if err != nil {
+			return err
+		}
+
+		if delivery == nil {
+			resErr = res.ErrNotFound(res.DeliveryNotFound)
+			return resErr
+		}
+
+		if resErr = apply(uc.WalletRepository.WithTx(tx), delivery, sub); resErr != nil {
+			return resErr
+		}
+
+		if err := deliveryRepo.UpdateDelivery(delivery); err != nil {
+			return err
+		}
+
+		return subscriptionRepo.UpdateSubscription(sub)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(failure)
+	}
+
+	resp := toDeliveryResponse(delivery)
+	return &resp, nil
+}
+
+// cutoffPassed reports whether changes to a delivery date have closed. The
+// cutoff is measured back from the start of the delivery day, so a 4h
+// cutoff closes a day at 20:00 the evening before. A delivery zone may set
+// its own cutoff in place of the default one.
+func (uc *DeliveryUsecase) cutoffPassed(deliveryDate time.Time, zone *entity.DeliveryZone) bool {
+	cutoff := uc.conf.DeliveryCutoff
+	if zone != nil && zone.CutoffMinutes != nil {
+		cutoff = time.Duration(*zone.CutoffMinutes) * time.Minute
+	}
+
+	dayStart := time.Date(deliveryDate.Year(), deliveryDate.Month(), deliveryDate.Day(), 0, 0, 0, 0, time.Local)
+	return !time.Now().Before(dayStart.Add(-cutoff))
+}
+
+// mealPrice is what a single meal costs within the subscription's price,
+// rounded to the rupiah.
+func mealPrice(sub *entity.Subscription) float64 {
+	mealTypes := len(strings.Split(sub.MealTypes, ","))
+	deliveryDays := len(strings.Split(sub.DeliveryDays, ","))
+	return pricing.PerMeal(pricing.FromFloat(sub.TotalPrice), mealTypes, deliveryDays).Float64()
+}
+
+func toDeliveryResponse(d *entity.Delivery) dto.DeliveryResponse {
+	return dto.DeliveryResponse{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		DeliveryDate:   d.DeliveryDate,
+		MealType:       d.MealType,
+		Status:         string(d.Status),
+		CreditAmount:   d.CreditAmount,
+		PickedUpAt:     d.PickedUpAt,
+		DeliveredAt:    d.DeliveredAt,
+		FailedAt:       d.FailedAt,
+		FailureReason:  d.FailureReason,
+		ProofPhotoURL:  d.ProofPhotoURL,
+	}
+}
@@ -0,0 +1,184 @@
+package rest
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/app/delivery_zone/usecase"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type DeliveryZoneHandler struct {
+	Validator           *validator.Validate
+	DeliveryZoneUsecase usecase.DeliveryZoneUsecaseItf
+}
+
+func NewDeliveryZoneHandler(routerGroup fiber.Router, validator *validator.Validate, deliveryZoneUsecase usecase.DeliveryZoneUsecaseItf, middleware middleware.MiddlewareItf) {
+	deliveryZoneHandler := DeliveryZoneHandler{
+		Validator:           validator,
+		DeliveryZoneUsecase: deliveryZoneUsecase,
+	}
+
+	adminRouterGroup := routerGroup.Group("/admin/delivery-zones", middleware.Authentication, middleware.Authorization)
+	adminRouterGroup.Post("/", deliveryZoneHandler.CreateDeliveryZone)
+	adminRouterGroup.Get("/", deliveryZoneHandler.GetAllDeliveryZones)
+	adminRouterGroup.Get("/:id", deliveryZoneHandler.GetDeliveryZoneByID)
+	adminRouterGroup.Put("/:id", deliveryZoneHandler.UpdateDeliveryZone)
+	adminRouterGroup.Delete("/:id", deliveryZoneHandler.DeleteDeliveryZone)
+}
+
+// @Summary      Create Delivery Zone
+// @Description  Create an area we deliver to (admin only). An address is covered when its postal code starts with one of postal_codes, or else when its location lies inside polygon; a zone with neither covers its whole city. Once any zone is active, new subscriptions must be in one.
+// @Tags         Delivery Zone
+// @Accept       json
+// @Produce      json
+// @Param        payload body dto.DeliveryZoneRequest true "Delivery Zone Request"
+// @Success      201  {object}  res.Res{payload=dto.DeliveryZoneResponse} "Create delivery zone successful"
+// @Failure      400  {object}  res.Err "Invalid request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      409  {object}  res.Err "Delivery zone name already exists"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/delivery-zones/ [post]
+func (h DeliveryZoneHandler) CreateDeliveryZone(ctx *fiber.Ctx) error {
+	req := new(dto.DeliveryZoneRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	zone, err := h.DeliveryZoneUsecase.CreateDeliveryZone(*req)
+	if err != nil {
+		return err
+	}
+
+	return res.Created(ctx, zone, res.CreateDeliveryZoneSuccess)
+}
+
+// @Summary      Get All Delivery Zones
+// @Description  List every delivery zone, active or not (admin only).
+// @Tags         Delivery Zone
+// @Produce      json
+// @Success      200  {object}  res.Res{payload=[]dto.DeliveryZoneResponse} "Get all delivery zones successful"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/delivery-zones/ [get]
+func (h DeliveryZoneHandler) GetAllDeliveryZones(ctx *fiber.Ctx) error {
+	zones, err := h.DeliveryZoneUsecase.GetAllDeliveryZones()
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, zones, res.GetAllDeliveryZonesSuccess)
+}
+
+// @Summary      Get Delivery Zone By ID
+// @Description  Get a delivery zone by its ID (admin only).
+// @Tags         Delivery Zone
+// @Produce      json
+// @Param        id   path      string  true  "Delivery Zone ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=dto.DeliveryZoneResponse} "Get delivery zone by ID successful"
+// @Failure      400  {object}  res.Err "Invalid delivery zone ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Delivery zone not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/delivery-zones/{id} [get]
+func (h DeliveryZoneHandler) GetDeliveryZoneByID(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidDeliveryZoneID)
+	}
+
+	zone, resErr := h.DeliveryZoneUsecase.GetDeliveryZoneByID(id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, zone, res.GetDeliveryZoneByIDSuccess)
+}
+
+// @Summary      Update Delivery Zone
+// @Description  Replace the settings of a delivery zone (admin only). Subscriptions already in the zone keep the fee and delivery days they were signed up with until their plan is changed; a new cutoff applies to them straight away.
+// @Tags         Delivery Zone
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string                   true  "Delivery Zone ID" Format(uuid)
+// @Param        payload body  dto.DeliveryZoneRequest  true  "Delivery Zone Request"
+// @Success      200  {object}  res.Res{payload=dto.DeliveryZoneResponse} "Update delivery zone successful"
+// @Failure      400  {object}  res.Err "Invalid delivery zone ID, request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Delivery zone not found"
+// @Failure      409  {object}  res.Err "Delivery zone name already exists"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/delivery-zones/{id} [put]
+func (h DeliveryZoneHandler) UpdateDeliveryZone(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidDeliveryZoneID)
+	}
+
+	req := new(dto.DeliveryZoneRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	zone, resErr := h.DeliveryZoneUsecase.UpdateDeliveryZone(id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, zone, res.UpdateDeliveryZoneSuccess)
+}
+
+// @Summary      Delete Delivery Zone
+// @Description  Delete a delivery zone no subscription was ever delivered in (admin only). Zones with subscriptions can only be deactivated.
+// @Tags         Delivery Zone
+// @Produce      json
+// @Param        id   path      string  true  "Delivery Zone ID" Format(uuid)
+// @Success      200  {object}  res.Res "Delete delivery zone successful"
+// @Failure      400  {object}  res.Err "Invalid delivery zone ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Delivery zone not found"
+// @Failure      409  {object}  res.Err "Delivery zone has subscriptions"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/delivery-zones/{id} [delete]
+func (h DeliveryZoneHandler) DeleteDeliveryZone(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidDeliveryZoneID)
+	}
+
+	if resErr := h.DeliveryZoneUsecase.DeleteDeliveryZone(id); resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, nil, res.DeleteDeliveryZoneSuccess)
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DeliveryZoneRepositoryItf interface {
+	WithTx(tx *gorm.DB) DeliveryZoneRepositoryItf
+	CreateDeliveryZone(zone *entity.DeliveryZone) error
+	UpdateDeliveryZone(zone *entity.DeliveryZone) error
+	DeleteDeliveryZone(id uuid.UUID) error
+	GetAllDeliveryZones() ([]entity.DeliveryZone, error)
+	GetActiveDeliveryZones() ([]entity.DeliveryZone, error)
+	GetDeliveryZoneByID(id uuid.UUID) (*entity.DeliveryZone, error)
+	GetDeliveryZoneByName(name string) (*entity.DeliveryZone, error)
+	CountDeliveryZoneSubscriptions(id uuid.UUID) (int64, error)
+}
+
+type DeliveryZoneRepository struct {
+	db *gorm.DB
+}
+
+func NewDeliveryZoneRepository(db *gorm.DB) DeliveryZoneRepositoryItf {
+	return &DeliveryZoneRepository{
+		db: db,
+	}
+}
+
+func (r *DeliveryZoneRepository) WithTx(tx *gorm.DB) DeliveryZoneRepositoryItf {
+	return &DeliveryZoneRepository{
+		db: tx,
+	}
+}
+
+func (r *DeliveryZoneRepository) CreateDeliveryZone(zone *entity.DeliveryZone) error {
+	return r.db.Create(zone).Error
+}
+
+func (r *DeliveryZoneRepository) UpdateDeliveryZone(zone *entity.DeliveryZone) error {
+	return r.db.Save(zone).Error
+}
+
+func (r *DeliveryZoneRepository) DeleteDeliveryZone(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&entity.DeliveryZone{}).Error
+}
+
+func (r *DeliveryZoneRepository) GetAllDeliveryZones() ([]entity.DeliveryZone, error) {
+	var zones []entity.DeliveryZone
+	err := r.db.Order("name asc").Find(&zones).Error
+	return zones, err
+}
+
+func (r *DeliveryZoneRepository) GetActiveDeliveryZones() ([]entity.DeliveryZone, error) {
+	var zones []entity.DeliveryZone
+	err := r.db.Where("is_active = ?", true).Order("name asc").Find(&zones).Error
+	return zones, err
+}
+
+func (r *DeliveryZoneRepository) GetDeliveryZoneByID(id uuid.UUID) (*entity.DeliveryZone, error) {
+	var zone entity.DeliveryZone
+	err := r.db.Where("id = ?", id).First(&zone).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+func (r *DeliveryZoneRepository) GetDeliveryZoneByName(name string) (*entity.DeliveryZone, error) {
+	var zone entity.DeliveryZone
+	err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&zone).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &zone, nil
+}
+
+// CountDeliveryZoneSubscriptions counts the subscriptions delivered in a
+// zone, whatever their status.
+func (r *DeliveryZoneRepository) CountDeliveryZoneSubscriptions(id uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Subscription{}).Where("delivery_zone_id = ?", id).Count(&count).Error
+	return count, err
+}
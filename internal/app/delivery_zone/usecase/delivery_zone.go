@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	deliveryZoneRepository "github.com/Ablebil/sea-catering-be/internal/app/delivery_zone/repository"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/geo"
+	"github.com/google/uuid"
+)
+
+type DeliveryZoneUsecaseItf interface {
+	CreateDeliveryZone(req dto.DeliveryZoneRequest) (*dto.DeliveryZoneResponse, *res.Err)
+	GetAllDeliveryZones() ([]dto.DeliveryZoneResponse, *res.Err)
+	GetDeliveryZoneByID(id uuid.UUID) (*dto.DeliveryZoneResponse, *res.Err)
+	UpdateDeliveryZone(id uuid.UUID, req dto.DeliveryZoneRequest) (*dto.DeliveryZoneResponse, *res.Err)
+	DeleteDeliveryZone(id uuid.UUID) *res.Err
+}
+
+type DeliveryZoneUsecase struct {
+	DeliveryZoneRepository deliveryZoneRepository.DeliveryZoneRepositoryItf
+}
+
+func NewDeliveryZoneUsecase(deliveryZoneRepository deliveryZoneRepository.DeliveryZoneRepositoryItf) DeliveryZoneUsecaseItf {
+	return &DeliveryZoneUsecase{
+		DeliveryZoneRepository: deliveryZoneRepository,
+	}
+}
+
+func (uc *DeliveryZoneUsecase) CreateDeliveryZone(req dto.DeliveryZoneRequest) (*dto.DeliveryZoneResponse, *res.Err) {
+	if resErr := uc.checkName(uuid.Nil, req.Name); resErr != nil {
+		return nil, resErr
+	}
+
+	zone := &entity.DeliveryZone{}
+	if resErr := fillDeliveryZone(zone, req); resErr != nil {
+		return nil, resErr
+	}
+
+	if err := uc.DeliveryZoneRepository.CreateDeliveryZone(zone); err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSaveDeliveryZone)
+	}
+
+	return toDeliveryZoneResponse(zone), nil
+}
+
+func (uc *DeliveryZoneUsecase) GetAllDeliveryZones() ([]dto.DeliveryZoneResponse, *res.Err) {
+	zones, err := uc.DeliveryZoneRepository.GetAllDeliveryZones()
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetAllDeliveryZones)
+	}
+
+	result := make([]dto.DeliveryZoneResponse, 0, len(zones))
+	for i := range zones {
+		result = append(result, *toDeliveryZoneResponse(&zones[i]))
+	}
+
+	return result, nil
+}
+
+func (uc *DeliveryZoneUsecase) GetDeliveryZoneByID(id uuid.UUID) (*dto.DeliveryZoneResponse, *res.Err) {
+	zone, err := uc.DeliveryZoneRepository.GetDeliveryZoneByID(id)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetDeliveryZoneByID)
+	}
+
+	if zone == nil {
+		return nil, res.ErrNotFound(res.DeliveryZoneNotFound)
+	}
+
+	return toDeliveryZoneResponse(zone), nil
+}
+
+// UpdateDeliveryZone replaces a zone's settings. Subscriptions already in the
+// zone keep the fee and delivery days they were signed up with until their
+// plan is changed; a new cutoff applies to them straight away.
+func (uc *DeliveryZoneUsecase) UpdateDeliveryZone(id uuid.UUID, req dto.DeliveryZoneRequest) (*dto.DeliveryZoneResponse, *res.Err) {
+	zone, err := uc.DeliveryZoneRepository.GetDeliveryZoneByID(id)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetDeliveryZoneByID)
+	}
+
+	if zone == nil {
+		return nil, res.ErrNotFound(res.DeliveryZoneNotFound)
+	}
+
+	if resErr := uc.checkName(id, req.Name); resErr != nil {
+		return nil, resErr
+	}
+
+	if resErr := fillDeliveryZone(zone, req); resErr != nil {
+		return nil, resErr
+	}
+
+	if err := uc.DeliveryZoneRepository.UpdateDeliveryZone(zone); err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSaveDeliveryZone)
+	}
+
+	return toDeliveryZoneResponse(zone), nil
+}
+
+// DeleteDeliveryZone removes a zone no subscription was ever delivered in.
+// Zones with subscriptions stay for the record and can only be deactivated.
+func (uc *DeliveryZoneUsecase) DeleteDeliveryZone(id uuid.UUID) *res.Err {
+	zone, err := uc.DeliveryZoneRepository.GetDeliveryZoneByID(id)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetDeliveryZoneByID)
+	}
+
+	if zone == nil {
+		return res.ErrNotFound(res.DeliveryZoneNotFound)
+	}
+
+	subscriptions, err := uc.DeliveryZoneRepository.CountDeliveryZoneSubscriptions(id)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetDeliveryZoneByID)
+	}
+
+	if subscriptions > 0 {
+		return res.ErrConflict(res.DeliveryZoneInUse)
+	}
+
+	if err := uc.DeliveryZoneRepository.DeleteDeliveryZone(id); err != nil {
+		return res.ErrInternalServerError(res.FailedDeleteDeliveryZone)
+	}
+
+	return nil
+}
+
+func (uc *DeliveryZoneUsecase) checkName(id uuid.UUID, name string) *res.Err {
+	existing, err := uc.DeliveryZoneRepository.GetDeliveryZoneByName(strings.TrimSpace(name))
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetDeliveryZoneByID)
+	}
+
+	if existing != nil && existing.ID != id {
+		return res.ErrConflict(res.DeliveryZoneNameAlreadyExists)
+	}
+
+	return nil
+}
+
+// fillDeliveryZone copies a request onto a zone after checking what the
+// validator cannot: that every polygon point is a real coordinate.
+func fillDeliveryZone(zone *entity.DeliveryZone, req dto.DeliveryZoneRequest) *res.Err {
+	var polygon geo.Polygon
+	for _, pair := range req.Polygon {
+		lat, lng := pair[0], pair[1]
+		if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+			return res.ErrBadRequest(res.InvalidDeliveryZonePolygon)
+		}
+
+		polygon = append(polygon, geo.Point{Latitude: lat, Longitude: lng})
+	}
+
+	zone.Name = strings.TrimSpace(req.Name)
+	zone.City = strings.TrimSpace(req.City)
+	zone.PostalCodes = nil
+	zone.Polygon = nil
+	zone.DeliveryFee = req.DeliveryFee
+	zone.DeliveryDays = strings.Join(req.DeliveryDays, ",")
+	zone.CutoffMinutes = req.CutoffMinutes
+	zone.IsActive = *req.IsActive
+
+	if len(req.PostalCodes) > 0 {
+		postalCodes := strings.Join(req.PostalCodes, ",")
+		zone.PostalCodes = &postalCodes
+	}
+
+	if len(polygon) > 0 {
+		encoded := polygon.Encode()
+		zone.Polygon = &encoded
+	}
+
+	return nil
+}
+
+func toDeliveryZoneResponse(zone *entity.DeliveryZone) *dto.DeliveryZoneResponse {
+	postalCodes := []string{}
+	if zone.PostalCodes != nil && *zone.PostalCodes != "" {
+		postalCodes = strings.Split(*zone.PostalCodes, ",")
+	}
+
+	polygon := [][]float64{}
+	if zone.Polygon != nil {
+		points, err := geo.ParsePolygon(*zone.Polygon)
+		if err != nil {
+			log.Printf("Delivery zone %s has an unreadable polygon: %v", zone.ID, err)
+		}
+
+		for _, point := range points {
+			polygon = append(polygon, []float64{point.Latitude, point.Longitude})
+		}
+	}
+
+	var createdAt time.Time
+	if zone.CreatedAt != nil {
+		createdAt = *zone.CreatedAt
+	}
+
+	return &dto.DeliveryZoneResponse{
+		ID:            zone.ID,
+		Name:          zone.Name,
+		City:          zone.City,
+		PostalCodes:   postalCodes,
+		Polygon:       polygon,
+		DeliveryFee:   zone.DeliveryFee,
+		DeliveryDays:  strings.Split(zone.DeliveryDays, ","),
+		CutoffMinutes: zone.CutoffMinutes,
+		IsActive:      zone.IsActive,
+		CreatedAt:     createdAt,
+	}
+}
@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/infra/event"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// keepAlive is how often an idle stream gets a comment line, so proxies do
+// not close it and a client that went away is noticed.
+const keepAlive = 15 * time.Second
+
+type EventHandler struct {
+	event event.EventItf
+}
+
+func NewEventHandler(routerGroup fiber.Router, event event.EventItf, middleware middleware.MiddlewareItf) {
+	eventHandler := EventHandler{
+		event: event,
+	}
+
+	routerGroup.Get("/events", tokenFromQuery, middleware.Authentication, eventHandler.StreamEvents)
+}
+
+// tokenFromQuery lets the access token come in the access_token query
+// parameter, since the browser's EventSource cannot send headers. A token in
+// the Authorization header still wins.
+func tokenFromQuery(ctx *fiber.Ctx) error {
+	if token := ctx.Query("access_token"); token != "" && ctx.Get(fiber.HeaderAuthorization) == "" {
+		ctx.Request().Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+
+	return ctx.Next()
+}
+
+// @Summary      Stream Events
+// @Description  Stream the authenticated user's events as Server-Sent Events: payment.settled, payment.failed, subscription.status_changed and delivery.status_changed. Each event's data is a JSON payload naming what changed; refetch it for the full picture. Events sent while disconnected are not replayed. Browsers' EventSource may pass the token in access_token instead of the Authorization header.
+// @Tags         Event
+// @Produce      text/event-stream
+// @Param        access_token query string false "Access token, when it cannot be sent in the Authorization header"
+// @Success      200  {string}  string "Event stream"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Security     ApiKeyAuth
+// @Router       /events [get]
+func (h EventHandler) StreamEvents(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals("userID").(uuid.UUID)
+	if !ok {
+		return res.ErrUnauthorized(res.MissingAccessToken)
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no")
+
+	events, unsubscribe := h.event.Subscribe(userID)
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		ticker := time.NewTicker(keepAlive)
+		defer ticker.Stop()
+
+		fmt.Fprint(w, "retry: 5000\n\n")
+
+		for {
+			// Flushing fails once the client has gone, which ends the stream.
+			if err := w.Flush(); err != nil {
+				return
+			}
+
+			select {
+			case e, ok := <-events:
+				// The hub closes the channel when the server shuts down.
+				if !ok {
+					return
+				}
+
+				fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+			case <-ticker.C:
+				fmt.Fprint(w, ": keep-alive\n\n")
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package rest
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/app/gift/usecase"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type GiftHandler struct {
+	Validator   *validator.Validate
+	GiftUsecase usecase.GiftUsecaseItf
+}
+
+func NewGiftHandler(routerGroup fiber.Router, validator *validator.Validate, giftUsecase usecase.GiftUsecaseItf, middleware middleware.MiddlewareItf) {
+	giftHandler := GiftHandler{
+		Validator:   validator,
+		GiftUsecase: giftUsecase,
+	}
+
+	routerGroup = routerGroup.Group("/gifts", middleware.Authentication)
+	routerGroup.Get("/", giftHandler.GetPurchasedGifts)
+	routerGroup.Post("/claim", giftHandler.ClaimGift)
+}
+
+// @Summary      Get Purchased Gifts
+// @Description  List the gift subscriptions the authenticated user bought, newest first, with their codes and whether they were claimed.
+// @Tags         Gift
+// @Produce      json
+// @Success      200  {object}  res.Res{payload=[]dto.GiftResponse} "Get gifts successful"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /gifts [get]
+func (h GiftHandler) GetPurchasedGifts(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	gifts, err := h.GiftUsecase.GetPurchasedGifts(userID)
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, gifts, res.GetGiftsSuccess)
+}
+
+// @Summary      Claim Gift
+// @Description  Claim a gift subscription with the code emailed to its recipient. The subscription moves into the authenticated user's account.
+// @Tags         Gift
+// @Accept       json
+// @Produce      json
+// @Param        payload body      dto.ClaimGiftRequest  true  "Claim Gift Request"
+// @Success      200  {object}  res.Res{payload=dto.GiftResponse} "Gift claimed successful"
+// @Failure      400  {object}  res.Err "Invalid request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Buyers cannot claim their own gift"
+// @Failure      404  {object}  res.Err "Gift not found"
+// @Failure      409  {object}  res.Err "Gift already claimed, not paid yet or its subscription has ended"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /gifts/claim [post]
+func (h GiftHandler) ClaimGift(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	req := new(dto.ClaimGiftRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	gift, resErr := h.GiftUsecase.ClaimGift(userID, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, gift, res.ClaimGiftSuccess)
+}
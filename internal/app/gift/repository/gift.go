@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GiftRepositoryItf interface {
+	WithTx(tx *gorm.DB) GiftRepositoryItf
+	CreateGift(gift *entity.Gift) error
+	UpdateGift(gift *entity.Gift) error
+	GetGiftBySubscriptionID(subscriptionID uuid.UUID) (*entity.Gift, error)
+	GetGiftBySubscriptionIDForUpdate(subscriptionID uuid.UUID) (*entity.Gift, error)
+	GetGiftByCodeForUpdate(code string) (*entity.Gift, error)
+	GetGiftsByPurchaserID(purchaserID uuid.UUID) ([]entity.Gift, error)
+	MarkGiftNotified(id uuid.UUID) (bool, error)
+}
+
+type GiftRepository struct {
+	db *gorm.DB
+}
+
+func NewGiftRepository(db *gorm.DB) GiftRepositoryItf {
+	return &GiftRepository{
+		db: db,
+	}
+}
+
+func (r *GiftRepository) WithTx(tx *gorm.DB) GiftRepositoryItf {
+	return &GiftRepository{
+		db: tx,
+	}
+}
+
+func (r *GiftRepository) CreateGift(gift *entity.Gift) error {
+	return r.db.Create(gift).Error
+}
+
+func (r *GiftRepository) UpdateGift(gift *entity.Gift) error {
+	return r.db.Save(gift).Error
+}
+
+func (r *GiftRepository) GetGiftBySubscriptionID(subscriptionID uuid.UUID) (*entity.Gift, error) {
+	return r.first(r.db, "subscription_id = ?", subscriptionID)
+}
+
+// GetGiftBySubscriptionIDForUpdate locks the gift of a subscription until
+// the surrounding transaction ends. It must be called on a repository from
+// WithTx.
+func (r *GiftRepository) GetGiftBySubscriptionIDForUpdate(subscriptionID uuid.UUID) (*entity.Gift, error) {
+	return r.first(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), "subscription_id = ?", subscriptionID)
+}
+
+// GetGiftByCodeForUpdate locks the gift with the given code until the
+// surrounding transaction ends. It must be called on a repository from
+// WithTx.
+func (r *GiftRepository) GetGiftByCodeForUpdate(code string) (*entity.Gift, error) {
+	return r.first(r.db.Clauses(clause.Locking{Strength: "UPDATE"}), "code = ?", code)
+}
+
+// GetGiftsByPurchaserID lists the gifts a user bought, newest first, with
+// their subscription and meal plan.
+func (r *GiftRepository) GetGiftsByPurchaserID(purchaserID uuid.UUID) ([]entity.Gift, error) {
+	var gifts []entity.Gift
+	err := r.db.Preload("Subscription.MealPlan").
+		Where("purchaser_id = ?", purchaserID).
+		Order("created_at desc").
+		Find(&gifts).Error
+	return gifts, err
+}
+
+// MarkGiftNotified records that the recipient was emailed. It reports false
+// when that was already recorded, so the email goes out only once.
+func (r *GiftRepository) MarkGiftNotified(id uuid.UUID) (bool, error) {
+	result := r.db.Model(&entity.Gift{}).
+		Where("id = ? AND notified_at IS NULL", id).
+		Update("notified_at", time.Now())
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *GiftRepository) first(db *gorm.DB, query string, args ...any) (*entity.Gift, error) {
+	var gift entity.Gift
+	err := db.Where(query, args...).First(&gift).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &gift, nil
+}
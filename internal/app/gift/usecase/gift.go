@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	giftRepository "github.com/Ablebil/sea-catering-be/internal/app/gift/repository"
+	subscriptionRepository "github.com/Ablebil/sea-catering-be/internal/app/subscription/repository"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GiftUsecaseItf interface {
+	GetPurchasedGifts(userID uuid.UUID) ([]dto.GiftResponse, *res.Err)
+	ClaimGift(userID uuid.UUID, req dto.ClaimGiftRequest) (*dto.GiftResponse, *res.Err)
+}
+
+type GiftUsecase struct {
+	GiftRepository         giftRepository.GiftRepositoryItf
+	SubscriptionRepository subscriptionRepository.SubscriptionRepositoryItf
+	db                     *gorm.DB
+}
+
+func NewGiftUsecase(giftRepository giftRepository.GiftRepositoryItf, subscriptionRepository subscriptionRepository.SubscriptionRepositoryItf, db *gorm.DB) GiftUsecaseItf {
+	return &GiftUsecase{
+		GiftRepository:         giftRepository,
+		SubscriptionRepository: subscriptionRepository,
+		db:                     db,
+	}
+}
+
+// GetPurchasedGifts lists the gifts a user bought, claimed or not, so the
+// buyer keeps them in their purchase history after the subscription moved to
+// the recipient's account.
+func (uc *GiftUsecase) GetPurchasedGifts(userID uuid.UUID) ([]dto.GiftResponse, *res.Err) {
+	gifts, err := uc.GiftRepository.GetGiftsByPurchaserID(userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetGifts)
+	}
+
+	result := make([]dto.GiftResponse, 0, len(gifts))
+	for i := range gifts {
+		result = append(result, toGiftResponse(&gifts[i], gifts[i].Subscription))
+	}
+
+	return result, nil
+}
+
+// ClaimGift moves a paid gift subscription into the claiming user's account.
+// Each code can be claimed once, and only while the subscription is still
+// running.
+func (uc *GiftUsecase) ClaimGift(userID uuid.UUID, req dto.ClaimGiftRequest) (*dto.GiftResponse, *res.Err) {
+	code := strings.ToUpper(strings.TrimSpace(req.Code))
+
+	var gift *entity.Gift
+	var sub *entity.Subscription
+	var resErr *res.Err
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		giftRepo := uc.GiftRepository.WithTx(tx)
+		subRepo := uc.SubscriptionRepository.WithTx(tx)
+
+		var err error
+		gift, err = giftRepo.GetGiftByCodeForUpdate(code)
+		if err != nil {
+			return err
+		}
+
+		if gift == nil {
+			resErr = res.ErrNotFound(res.GiftNotFound)
+			return resErr
+		}
+
+		if gift.Status == entity.GiftClaimed {
+			resErr = res.ErrConflict(res.GiftAlreadyClaimed)
+			return resErr
+		}
+
+		if gift.PurchaserID == userID {
+			resErr = res.ErrForbidden(res.CannotClaimOwnGift)
+			return resErr
+		}
+
+		sub, err = subRepo.GetSubscriptionByIDForUpdate(gift.SubscriptionID)
+		if err != nil {
+			return err
+		}
+
+		if sub == nil {
+			resErr = res.ErrNotFound(res.SubscriptionNotFound)
+			return resErr
+		}
+
+		if gift.Status != entity.GiftIssued || (sub.Status != entity.StatusActive && sub.Status != entity.StatusPaused) {
+			resErr = res.ErrConflict(res.GiftNotClaimable)
+			return resErr
+		}
+
+		sub.UserID = userID
+		if err := subRepo.UpdateSubscription(sub); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		gift.Status = entity.GiftClaimed
+		gift.ClaimedBy = &userID
+		gift.ClaimedAt = &now
+		return giftRepo.UpdateGift(gift)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedClaimGift)
+	}
+
+	resp := toGiftResponse(gift, sub)
+	return &resp, nil
+}
+
+func toGiftResponse(gift *entity.Gift, sub *entity.Subscription) dto.GiftResponse {
+	resp := dto.GiftResponse{
+		ID:             gift.ID,
+		SubscriptionID: gift.SubscriptionID,
+		RecipientEmail: gift.RecipientEmail,
+		Message:        gift.Message,
+		Code:           gift.Code,
+		Status:         string(gift.Status),
+		IssuedAt:       gift.IssuedAt,
+		ClaimedAt:      gift.ClaimedAt,
+		CreatedAt:      gift.CreatedAt,
+	}
+
+	if sub != nil {
+		resp.RecipientName = sub.Name
+		resp.SubscriptionStatus = string(sub.Status)
+		if sub.MealPlan != nil {
+			resp.MealPlan = sub.MealPlan.Name
+		}
+	}
+
+	return resp
+}
@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/app/kitchen/usecase"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+)
+
+type KitchenHandler struct {
+	Validator      *validator.Validate
+	KitchenUsecase usecase.KitchenUsecaseItf
+}
+
+func NewKitchenHandler(routerGroup fiber.Router, validator *validator.Validate, kitchenUsecase usecase.KitchenUsecaseItf, middleware middleware.MiddlewareItf) {
+	kitchenHandler := KitchenHandler{
+		Validator:      validator,
+		KitchenUsecase: kitchenUsecase,
+	}
+
+	adminRouterGroup := routerGroup.Group("/admin/kitchen", middleware.Authentication, middleware.Authorization)
+	adminRouterGroup.Get("/forecast", kitchenHandler.GetForecast)
+}
+
+// @Summary      Get Kitchen Forecast
+// @Description  Count the portions to cook on a date, or with period=week the Monday to Sunday week it falls in, by meal plan and meal type, with how many are for customers with each allergy (admin only). Meals skipped by customers and days inside a pause are left out. With format=csv the forecast is downloaded as a spreadsheet, and with format=labels as a PDF sheet with a label for every meal.
+// @Tags         Kitchen
+// @Produce      json,text/csv,application/pdf
+// @Param        date   query string true  "Date (YYYY-MM-DD)"
+// @Param        period query string false "day (default) or week" Enums(day, week)
+// @Param        format query string false "json (default), csv or labels" Enums(json, csv, labels)
+// @Success      200  {object}  res.Res{payload=dto.KitchenForecastResponse} "Get kitchen forecast successful"
+// @Failure      400  {object}  res.Err "Invalid request params"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/kitchen/forecast [get]
+func (h KitchenHandler) GetForecast(ctx *fiber.Ctx) error {
+	req := new(dto.GetKitchenForecastRequest)
+	if err := ctx.QueryParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestParams)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	switch req.Format {
+	case "csv":
+		body, err := h.KitchenUsecase.ExportForecastCSV(*req)
+		if err != nil {
+			return err
+		}
+
+		ctx.Attachment("kitchen-forecast-" + req.Date + ".csv")
+		ctx.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		return ctx.Send(body)
+	case "labels":
+		body, err := h.KitchenUsecase.PrintMealLabels(*req)
+		if err != nil {
+			return err
+		}
+
+		ctx.Set(fiber.HeaderContentType, "application/pdf")
+		ctx.Set(fiber.HeaderContentDisposition, `inline; filename="meal-labels-`+req.Date+`.pdf"`)
+		return ctx.Send(body)
+	}
+
+	forecast, err := h.KitchenUsecase.GetForecast(*req)
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, forecast, res.GetKitchenForecastSuccess)
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PortionCount is how many meals of one meal type of a plan are due on a
+// date.
+type PortionCount struct {
+	DeliveryDate time.Time
+	MealPlanID   uuid.UUID
+	MealPlanName string
+	MealType     string
+	Portions     int64
+}
+
+// AllergyCount is how many of those meals are for customers with a given
+// allergy.
+type AllergyCount struct {
+	DeliveryDate time.Time
+	MealPlanID   uuid.UUID
+	MealType     string
+	Allergy      string
+	Portions     int64
+}
+
+// MealLabel is what goes on the box of a single meal.
+type MealLabel struct {
+	DeliveryDate    time.Time
+	MealPlanName    string
+	MealType        string
+	Name            string
+	PhoneNumber     string
+	DeliveryAddress string
+	DeliveryNotes   *string
+	Allergies       *string
+}
+
+type KitchenRepositoryItf interface {
+	GetPortionCounts(start time.Time, end time.Time) ([]PortionCount, error)
+	GetAllergyCounts(start time.Time, end time.Time) ([]AllergyCount, error)
+	GetMealLabels(start time.Time, end time.Time) ([]MealLabel, error)
+}
+
+type KitchenRepository struct {
+	db *gorm.DB
+}
+
+func NewKitchenRepository(db *gorm.DB) KitchenRepositoryItf {
+	return &KitchenRepository{
+		db: db,
+	}
+}
+
+// The kitchen cooks what the delivery schedule says is owed. Deliveries are
+// only planned for the days a subscription is running and not paused, so
+// counting the ones not skipped of subscriptions still running gives the
+// portions without reading each subscription's days, meal types and pause.
+var cookableStatuses = []entity.SubscriptionStatus{entity.StatusActive, entity.StatusPaused}
+
+// GetPortionCounts counts the meals due each day between start and end, by
+// meal plan and meal type.
+func (r *KitchenRepository) GetPortionCounts(start time.Time, end time.Time) ([]PortionCount, error) {
+	var counts []PortionCount
+	err := r.db.Raw(`
+		SELECT d.delivery_date, s.meal_plan_id, mp.name AS meal_plan_name, d.meal_type, COUNT(*) AS portions
+		FROM deliveries d
+		JOIN subscriptions s ON s.id = d.subscription_id
+		JOIN meal_plans mp ON mp.id = s.meal_plan_id
+		WHERE d.delivery_date BETWEEN ? AND ? AND d.status <> ? AND s.status IN ?
+		GROUP BY d.delivery_date, s.meal_plan_id, mp.name, d.meal_type
+		ORDER BY d.delivery_date, mp.name, ARRAY_POSITION(ARRAY['breakfast', 'lunch', 'dinner'], d.meal_type::text)`,
+		start, end, entity.DeliverySkipped, cookableStatuses,
+	).Scan(&counts).Error
+	return counts, err
+}
+
+// GetAllergyCounts breaks the same meals down by allergy. Allergies are
+// written by customers as a comma separated list, so each entry is counted
+// on its own, trimmed and lowercased so "Peanuts" and " peanuts" add up.
+func (r *KitchenRepository) GetAllergyCounts(start time.Time, end time.Time) ([]AllergyCount, error) {
+	var counts []AllergyCount
+	err := r.db.Raw(`
+		SELECT d.delivery_date, s.meal_plan_id, d.meal_type, a.allergy, COUNT(*) AS portions
+		FROM deliveries d
+		JOIN subscriptions s ON s.id = d.subscription_id
+		CROSS JOIN LATERAL (
+			SELECT DISTINCT LOWER(TRIM(entry)) AS allergy
+			FROM UNNEST(STRING_TO_ARRAY(s.allergies, ',')) AS entry
+		) a
+		WHERE d.delivery_date BETWEEN ? AND ? AND d.status <> ? AND s.status IN ?
+			AND s.allergies IS NOT NULL AND a.allergy <> ''
+		GROUP BY d.delivery_date, s.meal_plan_id, d.meal_type, a.allergy
+		ORDER BY d.delivery_date, portions DESC, a.allergy`,
+		start, end, entity.DeliverySkipped, cookableStatuses,
+	).Scan(&counts).Error
+	return counts, err
+}
+
+// GetMealLabels lists every meal due between start and end with what its
+// label needs, in the order meals are packed: by day, then breakfast, lunch
+// and dinner, then plan.
+func (r *KitchenRepository) GetMealLabels(start time.Time, end time.Time) ([]MealLabel, error) {
+	var labels []MealLabel
+	err := r.db.Raw(`
+		SELECT d.delivery_date, mp.name AS meal_plan_name, d.meal_type,
+			s.name, s.phone_number, s.delivery_address, s.delivery_notes, s.allergies
+		FROM deliveries d
+		JOIN subscriptions s ON s.id = d.subscription_id
+		JOIN meal_plans mp ON mp.id = s.meal_plan_id
+		WHERE d.delivery_date BETWEEN ? AND ? AND d.status <> ? AND s.status IN ?
+		ORDER BY d.delivery_date, ARRAY_POSITION(ARRAY['breakfast', 'lunch', 'dinner'], d.meal_type::text), mp.name, s.name, s.id`,
+		start, end, entity.DeliverySkipped, cookableStatuses,
+	).Scan(&labels).Error
+	return labels, err
+}
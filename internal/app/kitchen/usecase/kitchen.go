@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	kitchenRepository "github.com/Ablebil/sea-catering-be/internal/app/kitchen/repository"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/label"
+	"github.com/google/uuid"
+)
+
+type KitchenUsecaseItf interface {
+	GetForecast(req dto.GetKitchenForecastRequest) (*dto.KitchenForecastResponse, *res.Err)
+	ExportForecastCSV(req dto.GetKitchenForecastRequest) ([]byte, *res.Err)
+	PrintMealLabels(req dto.GetKitchenForecastRequest) ([]byte, *res.Err)
+}
+
+type KitchenUsecase struct {
+	KitchenRepository kitchenRepository.KitchenRepositoryItf
+}
+
+func NewKitchenUsecase(kitchenRepository kitchenRepository.KitchenRepositoryItf) KitchenUsecaseItf {
+	return &KitchenUsecase{
+		KitchenRepository: kitchenRepository,
+	}
+}
+
+// GetForecast counts the portions to cook each day of a date or its week, by
+// meal plan and meal type, with how many of them are for customers with each
+// allergy. Every day of the range is listed, including days with nothing to
+// cook.
+func (uc *KitchenUsecase) GetForecast(req dto.GetKitchenForecastRequest) (*dto.KitchenForecastResponse, *res.Err) {
+	start, end, resErr := forecastRange(req)
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	portions, err := uc.KitchenRepository.GetPortionCounts(start, end)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetKitchenForecast)
+	}
+
+	allergies, err := uc.KitchenRepository.GetAllergyCounts(start, end)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetKitchenForecast)
+	}
+
+	allergiesByItem := make(map[string][]dto.KitchenAllergyCount)
+	for _, a := range allergies {
+		key := forecastKey(a.DeliveryDate, a.MealPlanID, a.MealType)
+		allergiesByItem[key] = append(allergiesByItem[key], dto.KitchenAllergyCount{
+			Allergy:  a.Allergy,
+			Portions: a.Portions,
+		})
+	}
+
+	result := &dto.KitchenForecastResponse{
+		StartDate: start.Format("2006-01-02"),
+		EndDate:   end.Format("2006-01-02"),
+		Days:      []dto.KitchenForecastDay{},
+	}
+
+	dayIndex := make(map[string]int)
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		dayIndex[date.Format("2006-01-02")] = len(result.Days)
+		result.Days = append(result.Days, dto.KitchenForecastDay{
+			Date:  date.Format("2006-01-02"),
+			Day:   strings.ToLower(date.Weekday().String()),
+			Items: []dto.KitchenForecastItem{},
+		})
+	}
+
+	for _, p := range portions {
+		i, ok := dayIndex[p.DeliveryDate.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+
+		itemAllergies := allergiesByItem[forecastKey(p.DeliveryDate, p.MealPlanID, p.MealType)]
+		if itemAllergies == nil {
+			itemAllergies = []dto.KitchenAllergyCount{}
+		}
+
+		day := &result.Days[i]
+		day.Items = append(day.Items, dto.KitchenForecastItem{
+			MealPlanID: p.MealPlanID,
+			MealPlan:   p.MealPlanName,
+			MealType:   p.MealType,
+			Portions:   p.Portions,
+			Allergies:  itemAllergies,
+		})
+		day.Portions += p.Portions
+		result.Portions += p.Portions
+	}
+
+	return result, nil
+}
+
+// ExportForecastCSV writes the forecast as a spreadsheet, one row per day,
+// meal plan and meal type, with allergies summed up in the last column.
+func (uc *KitchenUsecase) ExportForecastCSV(req dto.GetKitchenForecastRequest) ([]byte, *res.Err) {
+	forecast, resErr := uc.GetForecast(req)
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"date", "day", "meal_plan", "meal_type", "portions", "allergies"})
+
+	for _, day := range forecast.Days {
+		for _, item := range day.Items {
+			allergies := make([]string, 0, len(item.Allergies))
+			for _, a := range item.Allergies {
+				allergies = append(allergies, fmt.Sprintf("%s: %d", a.Allergy, a.Portions))
+			}
+
+			w.Write([]string{
+				day.Date,
+				day.Day,
+				item.MealPlan,
+				item.MealType,
+				strconv.FormatInt(item.Portions, 10),
+				strings.Join(allergies, "; "),
+			})
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, res.ErrInternalServerError(res.FailedExportKitchenForecast)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PrintMealLabels renders a printable sheet with a label for every meal of
+// the forecast, showing who it is for, where it goes and their allergies.
+func (uc *KitchenUsecase) PrintMealLabels(req dto.GetKitchenForecastRequest) ([]byte, *res.Err) {
+	start, end, resErr := forecastRange(req)
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	meals, err := uc.KitchenRepository.GetMealLabels(start, end)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetMealLabels)
+	}
+
+	labels := make([]label.Label, 0, len(meals))
+	for _, m := range meals {
+		l := label.Label{
+			Date:        m.DeliveryDate,
+			Name:        m.Name,
+			PhoneNumber: m.PhoneNumber,
+			Address:     m.DeliveryAddress,
+			MealPlan:    m.MealPlanName,
+			MealType:    m.MealType,
+		}
+
+		if m.Allergies != nil {
+			l.Allergies = strings.TrimSpace(*m.Allergies)
+		}
+
+		if m.DeliveryNotes != nil {
+			l.Notes = strings.TrimSpace(*m.DeliveryNotes)
+		}
+
+		labels = append(labels, l)
+	}
+
+	return label.Render(labels), nil
+}
+
+// forecastRange is the date asked for, or with period=week the Monday to
+// Sunday week it falls in.
+func forecastRange(req dto.GetKitchenForecastRequest) (time.Time, time.Time, *res.Err) {
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return time.Time{}, time.Time{}, res.ErrBadRequest(res.InvalidDate)
+	}
+
+	if req.Period != "week" {
+		return date, date, nil
+	}
+
+	monday := date.AddDate(0, 0, -(int(date.Weekday())+6)%7)
+	return monday, monday.AddDate(0, 0, 6), nil
+}
+
+func forecastKey(date time.Time, mealPlanID uuid.UUID, mealType string) string {
+	return date.Format("2006-01-02") + "|" + mealPlanID.String() + "|" + mealType
+}
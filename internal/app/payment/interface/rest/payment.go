@@ -0,0 +1,173 @@
+package rest
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/app/payment/usecase"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PaymentHandler struct {
+	Validator      *validator.Validate
+	PaymentUsecase usecase.PaymentUsecaseItf
+}
+
+func NewPaymentHandler(routerGroup fiber.Router, validator *validator.Validate, paymentUsecase usecase.PaymentUsecaseItf, middleware middleware.MiddlewareItf) {
+	paymentHandler := PaymentHandler{
+		Validator:      validator,
+		PaymentUsecase: paymentUsecase,
+	}
+
+	routerGroup.Get("/subscriptions/:id/payments", middleware.Authentication, paymentHandler.GetSubscriptionPayments)
+	routerGroup.Get("/subscriptions/:id/invoices", middleware.Authentication, paymentHandler.GetSubscriptionInvoices)
+
+	adminRouterGroup := routerGroup.Group("/admin/payments", middleware.Authentication, middleware.Authorization)
+	adminRouterGroup.Get("/", paymentHandler.GetPayments)
+
+	reconciliationRouterGroup := routerGroup.Group("/admin/reconciliations", middleware.Authentication, middleware.Authorization)
+	reconciliationRouterGroup.Get("/", paymentHandler.GetReconciliationReports)
+	reconciliationRouterGroup.Get("/:id", paymentHandler.GetReconciliationReport)
+}
+
+// @Summary      Get Subscription Payments
+// @Description  Retrieve the payment history of one of the authenticated user's subscriptions.
+// @Tags         Payment
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=[]dto.PaymentDetailResponse} "Get subscription payments successful"
+// @Failure      400  {object}  res.Err "Invalid subscription ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/payments [get]
+func (h PaymentHandler) GetSubscriptionPayments(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	payments, resErr := h.PaymentUsecase.GetSubscriptionPayments(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, payments, res.GetSubscriptionPaymentsSuccess)
+}
+
+// @Summary      Get Subscription Invoices
+// @Description  List the PDF invoices issued for the settled payments of one of the authenticated user's subscriptions, newest first.
+// @Tags         Payment
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=[]dto.InvoiceResponse} "Get subscription invoices successful"
+// @Failure      400  {object}  res.Err "Invalid subscription ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/invoices [get]
+func (h PaymentHandler) GetSubscriptionInvoices(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	invoices, resErr := h.PaymentUsecase.GetSubscriptionInvoices(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, invoices, res.GetSubscriptionInvoicesSuccess)
+}
+
+// @Summary      Get Payments
+// @Description  List all recorded payments, optionally filtered by date range, status and payment channel (admin only).
+// @Tags         Payment
+// @Produce      json
+// @Param        start_date   query string false "Start date (YYYY-MM-DD)"
+// @Param        end_date     query string false "End date (YYYY-MM-DD)"
+// @Param        status       query string false "Transaction status" example(settlement)
+// @Param        payment_type query string false "Payment channel" example(bank_transfer)
+// @Success      200  {object}  res.Res{payload=[]dto.PaymentDetailResponse} "Get payments successful"
+// @Failure      400  {object}  res.Err "Invalid request params"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/payments/ [get]
+func (h PaymentHandler) GetPayments(ctx *fiber.Ctx) error {
+	req := new(dto.GetPaymentsRequest)
+	if err := ctx.QueryParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestParams)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	payments, err := h.PaymentUsecase.GetPayments(*req)
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, payments, res.GetPaymentsSuccess)
+}
+
+// @Summary      Get Reconciliation Reports
+// @Description  List the daily payment reconciliation runs with their matched, fixed and unresolved counts, newest first (admin only).
+// @Tags         Payment
+// @Produce      json
+// @Success      200  {object}  res.Res{payload=[]dto.ReconciliationReportResponse} "Get reconciliation reports successful"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/reconciliations/ [get]
+func (h PaymentHandler) GetReconciliationReports(ctx *fiber.Ctx) error {
+	reports, err := h.PaymentUsecase.GetReconciliationReports()
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, reports, res.GetReconciliationReportsSuccess)
+}
+
+// @Summary      Get Reconciliation Report
+// @Description  Retrieve one reconciliation run together with the orders it fixed or could not resolve (admin only).
+// @Tags         Payment
+// @Produce      json
+// @Param        id   path      string  true  "Reconciliation report ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=dto.ReconciliationReportResponse} "Get reconciliation report successful"
+// @Failure      400  {object}  res.Err "Invalid reconciliation report ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Reconciliation report not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/reconciliations/{id} [get]
+func (h PaymentHandler) GetReconciliationReport(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidReconciliationReportID)
+	}
+
+	report, resErr := h.PaymentUsecase.GetReconciliationReport(id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, report, res.GetReconciliationReportSuccess)
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InvoiceRepositoryItf interface {
+	CreateInvoice(invoice *entity.Invoice) (bool, error)
+	UpdateInvoice(invoice *entity.Invoice) error
+	GetInvoiceByOrderID(orderID string) (*entity.Invoice, error)
+	GetInvoicesBySubscriptionID(subscriptionID uuid.UUID) ([]entity.Invoice, error)
+}
+
+type InvoiceRepository struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) InvoiceRepositoryItf {
+	return &InvoiceRepository{
+		db: db,
+	}
+}
+
+// CreateInvoice inserts the invoice and reports whether it was new; false
+// means an invoice for the same order already exists.
+func (r *InvoiceRepository) CreateInvoice(invoice *entity.Invoice) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "order_id"}},
+		DoNothing: true,
+	}).Create(invoice)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+func (r *InvoiceRepository) UpdateInvoice(invoice *entity.Invoice) error {
+	return r.db.Save(invoice).Error
+}
+
+func (r *InvoiceRepository) GetInvoiceByOrderID(orderID string) (*entity.Invoice, error) {
+	var invoice entity.Invoice
+	err := r.db.Where("order_id = ?", orderID).First(&invoice).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+// GetInvoicesBySubscriptionID lists the invoices whose PDF has been stored,
+// newest first.
+func (r *InvoiceRepository) GetInvoicesBySubscriptionID(subscriptionID uuid.UUID) ([]entity.Invoice, error) {
+	var invoices []entity.Invoice
+	err := r.db.Where("subscription_id = ? AND file_url IS NOT NULL", subscriptionID).Order("sequence desc").Find(&invoices).Error
+	return invoices, err
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PaymentRepositoryItf interface {
+	WithTx(tx *gorm.DB) PaymentRepositoryItf
+	CreateNotification(notification *entity.PaymentNotification) (bool, error)
+	SavePayment(payment *entity.Payment) error
+	GetPaymentByOrderID(orderID string) (*entity.Payment, error)
+	GetPaymentsBySubscriptionID(subscriptionID uuid.UUID) ([]entity.Payment, error)
+	GetPayments(start *time.Time, end *time.Time, status string, paymentType string) ([]entity.Payment, error)
+}
+
+type PaymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepositoryItf {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+func (r *PaymentRepository) WithTx(tx *gorm.DB) PaymentRepositoryItf {
+	return &PaymentRepository{
+		db: tx,
+	}
+}
+
+// CreateNotification stores the notification key and reports whether it was
+// new; false means the same notification has already been processed.
+func (r *PaymentRepository) CreateNotification(notification *entity.PaymentNotification) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// SavePayment inserts the payment or, when one already exists for the order,
+// overwrites it with the latest notification.
+func (r *PaymentRepository) SavePayment(payment *entity.Payment) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "order_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"transaction_id", "gross_amount", "payment_type", "fraud_status",
+			"status", "transaction_time", "raw_notification", "updated_at",
+		}),
+	}).Create(payment).Error
+}
+
+func (r *PaymentRepository) GetPaymentByOrderID(orderID string) (*entity.Payment, error) {
+	var payment entity.Payment
+	err := r.db.Where("order_id = ?", orderID).First(&payment).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment, nil
+}
+
+func (r *PaymentRepository) GetPaymentsBySubscriptionID(subscriptionID uuid.UUID) ([]entity.Payment, error) {
+	var payments []entity.Payment
+	err := r.db.Where("subscription_id = ?", subscriptionID).Order("created_at desc").Find(&payments).Error
+	return payments, err
+}
+
+func (r *PaymentRepository) GetPayments(start *time.Time, end *time.Time, status string, paymentType string) ([]entity.Payment, error) {
+	var payments []entity.Payment
+	query := r.db.Model(&entity.Payment{})
+
+	if start != nil && end != nil {
+		query = query.Where("COALESCE(transaction_time, created_at) BETWEEN ? AND ?", *start, *end)
+	}
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	if paymentType != "" {
+		query = query.Where("payment_type = ?", paymentType)
+	}
+
+	err := query.Order("created_at desc").Find(&payments).Error
+	return payments, err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReconciliationRepositoryItf interface {
+	GetOrderIDsToReconcile(changedSince time.Time) ([]string, error)
+	CreateReport(report *entity.ReconciliationReport) error
+	GetReports() ([]entity.ReconciliationReport, error)
+	GetReportByID(id uuid.UUID) (*entity.ReconciliationReport, error)
+}
+
+type ReconciliationRepository struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepository(db *gorm.DB) ReconciliationRepositoryItf {
+	return &ReconciliationRepository{
+		db: db,
+	}
+}
+
+// GetOrderIDsToReconcile lists every order still awaiting payment, whether
+// for a billing period, a plan change or a subscription from before billing
+// periods existed, plus the orders whose payment changed since changedSince.
+func (r *ReconciliationRepository) GetOrderIDsToReconcile(changedSince time.Time) ([]string, error) {
+	var orderIDs []string
+	err := r.db.Raw(`
+		SELECT order_id FROM billing_periods WHERE status = ?
+		UNION
+		SELECT order_id FROM subscription_changes WHERE status = ? AND order_id IS NOT NULL
+		UNION
+		SELECT order_id FROM subscriptions WHERE status = ? AND order_id IS NOT NULL
+		UNION
+		SELECT order_id FROM payments WHERE updated_at >= ?
+		ORDER BY order_id`,
+		entity.BillingPending, entity.ChangePending, entity.StatusPending, changedSince,
+	).Scan(&orderIDs).Error
+	return orderIDs, err
+}
+
+// CreateReport inserts the report together with its items.
+func (r *ReconciliationRepository) CreateReport(report *entity.ReconciliationReport) error {
+	return r.db.Create(report).Error
+}
+
+// GetReports lists reports newest first, without their items.
+func (r *ReconciliationRepository) GetReports() ([]entity.ReconciliationReport, error) {
+	var reports []entity.ReconciliationReport
+	err := r.db.Order("started_at desc").Find(&reports).Error
+	return reports, err
+}
+
+func (r *ReconciliationRepository) GetReportByID(id uuid.UUID) (*entity.ReconciliationReport, error) {
+	var report entity.ReconciliationReport
+	err := r.db.Preload("Items", func(db *gorm.DB) *gorm.DB {
+		return db.Order("outcome desc, order_id")
+	}).Where("id = ?", id).First(&report).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"time"
+
+	paymentRepository "github.com/Ablebil/sea-catering-be/internal/app/payment/repository"
+	subscriptionRepository "github.com/Ablebil/sea-catering-be/internal/app/subscription/repository"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/helper"
+	"github.com/google/uuid"
+)
+
+type PaymentUsecaseItf interface {
+	GetSubscriptionPayments(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.PaymentDetailResponse, *res.Err)
+	GetPayments(req dto.GetPaymentsRequest) ([]dto.PaymentDetailResponse, *res.Err)
+	GetSubscriptionInvoices(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.InvoiceResponse, *res.Err)
+	GetReconciliationReports() ([]dto.ReconciliationReportResponse, *res.Err)
+	GetReconciliationReport(id uuid.UUID) (*dto.ReconciliationReportResponse, *res.Err)
+}
+
+type PaymentUsecase struct {
+	PaymentRepository        paymentRepository.PaymentRepositoryItf
+	InvoiceRepository        paymentRepository.InvoiceRepositoryItf
+	ReconciliationRepository paymentRepository.ReconciliationRepositoryItf
+	SubscriptionRepository   subscriptionRepository.SubscriptionRepositoryItf
+	helper                   helper.HelperItf
+}
+
+func NewPaymentUsecase(paymentRepository paymentRepository.PaymentRepositoryItf, invoiceRepository paymentRepository.InvoiceRepositoryItf, reconciliationRepository paymentRepository.ReconciliationRepositoryItf, subscriptionRepository subscriptionRepository.SubscriptionRepositoryItf, helper helper.HelperItf) PaymentUsecaseItf {
+	return &PaymentUsecase{
+		PaymentRepository:        paymentRepository,
+		InvoiceRepository:        invoiceRepository,
+		ReconciliationRepository: reconciliationRepository,
+		SubscriptionRepository:   subscriptionRepository,
+		helper:                   helper,
+	}
+}
+
+func (uc *PaymentUsecase) GetSubscriptionPayments(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.PaymentDetailResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	payments, err := uc.PaymentRepository.GetPaymentsBySubscriptionID(sub.ID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetPayments)
+	}
+
+	return toPaymentResponses(payments), nil
+}
+
+func (uc *PaymentUsecase) GetPayments(req dto.GetPaymentsRequest) ([]dto.PaymentDetailResponse, *res.Err) {
+	var start, end *time.Time
+	if req.StartDate != "" && req.EndDate != "" {
+		startDate, endDate, err := uc.helper.ParseDateRange(req.StartDate, req.EndDate)
+		if err != nil {
+			return nil, err
+		}
+
+		start, end = &startDate, &endDate
+	}
+
+	payments, err := uc.PaymentRepository.GetPayments(start, end, req.Status, req.PaymentType)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetPayments)
+	}
+
+	return toPaymentResponses(payments), nil
+}
+
+func (uc *PaymentUsecase) GetSubscriptionInvoices(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.InvoiceResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	invoices, err := uc.InvoiceRepository.GetInvoicesBySubscriptionID(sub.ID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetInvoices)
+	}
+
+	result := make([]dto.InvoiceResponse, 0, len(invoices))
+	for _, invoice := range invoices {
+		result = append(result, dto.InvoiceResponse{
+			ID:             invoice.ID,
+			Number:         invoice.Number,
+			SubscriptionID: invoice.SubscriptionID,
+			OrderID:        invoice.OrderID,
+			Amount:         invoice.Amount,
+			PaymentType:    invoice.PaymentType,
+			PaidAt:         invoice.PaidAt,
+			DownloadURL:    *invoice.FileURL,
+			CreatedAt:      invoice.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (uc *PaymentUsecase) GetReconciliationReports() ([]dto.ReconciliationReportResponse, *res.Err) {
+	reports, err := uc.ReconciliationRepository.GetReports()
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetReconciliationReports)
+	}
+
+	result := make([]dto.ReconciliationReportResponse, 0, len(reports))
+	for i := range reports {
+		result = append(result, *toReconciliationReportResponse(&reports[i]))
+	}
+
+	return result, nil
+}
+
+func (uc *PaymentUsecase) GetReconciliationReport(id uuid.UUID) (*dto.ReconciliationReportResponse, *res.Err) {
+	report, err := uc.ReconciliationRepository.GetReportByID(id)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetReconciliationReports)
+	}
+
+	if report == nil {
+		return nil, res.ErrNotFound(res.ReconciliationReportNotFound)
+	}
+
+	return toReconciliationReportResponse(report), nil
+}
+
+func toReconciliationReportResponse(report *entity.ReconciliationReport) *dto.ReconciliationReportResponse {
+	var items []dto.ReconciliationItemResponse
+	for _, item := range report.Items {
+		items = append(items, dto.ReconciliationItemResponse{
+			OrderID:       item.OrderID,
+			LocalStatus:   item.LocalStatus,
+			GatewayStatus: item.GatewayStatus,
+			Outcome:       string(item.Outcome),
+			Note:          item.Note,
+		})
+	}
+
+	return &dto.ReconciliationReportResponse{
+		ID:         report.ID,
+		StartedAt:  report.StartedAt,
+		FinishedAt: report.FinishedAt,
+		Matched:    report.Matched,
+		Fixed:      report.Fixed,
+		Unresolved: report.Unresolved,
+		Items:      items,
+	}
+}
+
+func toPaymentResponses(payments []entity.Payment) []dto.PaymentDetailResponse {
+	result := make([]dto.PaymentDetailResponse, 0, len(payments))
+	for _, p := range payments {
+		result = append(result, dto.PaymentDetailResponse{
+			ID:              p.ID,
+			SubscriptionID:  p.SubscriptionID,
+			OrderID:         p.OrderID,
+			TransactionID:   p.TransactionID,
+			GrossAmount:     p.GrossAmount,
+			PaymentType:     p.PaymentType,
+			FraudStatus:     p.FraudStatus,
+			Status:          p.Status,
+			TransactionTime: p.TransactionTime,
+			CreatedAt:       p.CreatedAt,
+		})
+	}
+
+	return result
+}
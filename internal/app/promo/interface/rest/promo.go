@@ -0,0 +1,185 @@
+package rest
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/app/promo/usecase"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type PromoHandler struct {
+	Validator    *validator.Validate
+	PromoUsecase usecase.PromoUsecaseItf
+}
+
+func NewPromoHandler(routerGroup fiber.Router, validator *validator.Validate, promoUsecase usecase.PromoUsecaseItf, middleware middleware.MiddlewareItf) {
+	promoHandler := PromoHandler{
+		Validator:    validator,
+		PromoUsecase: promoUsecase,
+	}
+
+	adminRouterGroup := routerGroup.Group("/admin/promos", middleware.Authentication, middleware.Authorization)
+	adminRouterGroup.Post("/", promoHandler.CreatePromo)
+	adminRouterGroup.Get("/", promoHandler.GetAllPromos)
+	adminRouterGroup.Get("/:id", promoHandler.GetPromoByID)
+	adminRouterGroup.Put("/:id", promoHandler.UpdatePromo)
+	adminRouterGroup.Delete("/:id", promoHandler.DeletePromo)
+}
+
+// @Summary      Create Promo
+// @Description  Create a promo code for the first billing period of new subscriptions (admin only). Leave meal_plan_ids empty to allow every meal plan.
+// @Tags         Promo
+// @Accept       json
+// @Produce      json
+// @Param        payload body dto.PromoRequest true "Promo Request"
+// @Success      201  {object}  res.Res{payload=dto.PromoResponse} "Create promo successful"
+// @Failure      400  {object}  res.Err "Invalid request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Meal plan not found"
+// @Failure      409  {object}  res.Err "Promo code already exists"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/promos/ [post]
+func (h PromoHandler) CreatePromo(ctx *fiber.Ctx) error {
+	req := new(dto.PromoRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	promo, err := h.PromoUsecase.CreatePromo(*req)
+	if err != nil {
+		return err
+	}
+
+	return res.Created(ctx, promo, res.CreatePromoSuccess)
+}
+
+// @Summary      Get All Promos
+// @Description  List every promo code with its redemption count (admin only).
+// @Tags         Promo
+// @Produce      json
+// @Success      200  {object}  res.Res{payload=[]dto.PromoResponse} "Get all promos successful"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/promos/ [get]
+func (h PromoHandler) GetAllPromos(ctx *fiber.Ctx) error {
+	promos, err := h.PromoUsecase.GetAllPromos()
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, promos, res.GetAllPromosSuccess)
+}
+
+// @Summary      Get Promo By ID
+// @Description  Get a promo code by its ID (admin only).
+// @Tags         Promo
+// @Produce      json
+// @Param        id   path      string  true  "Promo ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=dto.PromoResponse} "Get promo by ID successful"
+// @Failure      400  {object}  res.Err "Invalid promo ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Promo not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/promos/{id} [get]
+func (h PromoHandler) GetPromoByID(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidPromoID)
+	}
+
+	promo, resErr := h.PromoUsecase.GetPromoByID(id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, promo, res.GetPromoByIDSuccess)
+}
+
+// @Summary      Update Promo
+// @Description  Replace the settings of a promo code (admin only). Redemptions already made are kept.
+// @Tags         Promo
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string            true  "Promo ID" Format(uuid)
+// @Param        payload body  dto.PromoRequest  true  "Promo Request"
+// @Success      200  {object}  res.Res{payload=dto.PromoResponse} "Update promo successful"
+// @Failure      400  {object}  res.Err "Invalid promo ID, request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Promo or meal plan not found"
+// @Failure      409  {object}  res.Err "Promo code already exists"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/promos/{id} [put]
+func (h PromoHandler) UpdatePromo(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidPromoID)
+	}
+
+	req := new(dto.PromoRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	promo, resErr := h.PromoUsecase.UpdatePromo(id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, promo, res.UpdatePromoSuccess)
+}
+
+// @Summary      Delete Promo
+// @Description  Delete a promo code that has never been redeemed (admin only). Redeemed promos can only be deactivated.
+// @Tags         Promo
+// @Produce      json
+// @Param        id   path      string  true  "Promo ID" Format(uuid)
+// @Success      200  {object}  res.Res "Delete promo successful"
+// @Failure      400  {object}  res.Err "Invalid promo ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Promo not found"
+// @Failure      409  {object}  res.Err "Promo has been redeemed"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/promos/{id} [delete]
+func (h PromoHandler) DeletePromo(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidPromoID)
+	}
+
+	if resErr := h.PromoUsecase.DeletePromo(id); resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, nil, res.DeletePromoSuccess)
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromoRepositoryItf interface {
+	WithTx(tx *gorm.DB) PromoRepositoryItf
+	CreatePromo(promo *entity.Promo) error
+	UpdatePromo(promo *entity.Promo) error
+	ReplacePromoMealPlans(promoID uuid.UUID, mealPlanIDs []uuid.UUID) error
+	DeletePromo(id uuid.UUID) error
+	GetAllPromos() ([]entity.Promo, error)
+	GetPromoByID(id uuid.UUID) (*entity.Promo, error)
+	GetPromoByCode(code string) (*entity.Promo, error)
+	GetPromoByCodeForUpdate(code string) (*entity.Promo, error)
+	GetPromoByIDForUpdate(id uuid.UUID) (*entity.Promo, error)
+}
+
+type PromoRepository struct {
+	db *gorm.DB
+}
+
+func NewPromoRepository(db *gorm.DB) PromoRepositoryItf {
+	return &PromoRepository{
+		db: db,
+	}
+}
+
+func (r *PromoRepository) WithTx(tx *gorm.DB) PromoRepositoryItf {
+	return &PromoRepository{
+		db: tx,
+	}
+}
+
+func (r *PromoRepository) CreatePromo(promo *entity.Promo) error {
+	return r.db.Create(promo).Error
+}
+
+// UpdatePromo saves the promo's own columns. Meal plans are changed with
+// ReplacePromoMealPlans.
+func (r *PromoRepository) UpdatePromo(promo *entity.Promo) error {
+	return r.db.Omit(clause.Associations).Save(promo).Error
+}
+
+func (r *PromoRepository) ReplacePromoMealPlans(promoID uuid.UUID, mealPlanIDs []uuid.UUID) error {
+	if err := r.db.Where("promo_id = ?", promoID).Delete(&entity.PromoMealPlan{}).Error; err != nil {
+		return err
+	}
+
+	if len(mealPlanIDs) == 0 {
+		return nil
+	}
+
+	mealPlans := make([]entity.PromoMealPlan, 0, len(mealPlanIDs))
+	for _, id := range mealPlanIDs {
+		mealPlans = append(mealPlans, entity.PromoMealPlan{PromoID: promoID, MealPlanID: id})
+	}
+
+	return r.db.Create(&mealPlans).Error
+}
+
+func (r *PromoRepository) DeletePromo(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&entity.Promo{}).Error
+}
+
+func (r *PromoRepository) GetAllPromos() ([]entity.Promo, error) {
+	var promos []entity.Promo
+	err := r.db.Preload("MealPlans").Order("created_at desc").Find(&promos).Error
+	return promos, err
+}
+
+func (r *PromoRepository) GetPromoByID(id uuid.UUID) (*entity.Promo, error) {
+	return r.first(r.db.Where("id = ?", id))
+}
+
+func (r *PromoRepository) GetPromoByCode(code string) (*entity.Promo, error) {
+	return r.first(r.db.Where("code = ?", code))
+}
+
+// GetPromoByCodeForUpdate locks the promo row until the surrounding
+// transaction ends, so concurrent checkouts count redemptions one at a
+// time. It must be called on a repository from WithTx.
+func (r *PromoRepository) GetPromoByCodeForUpdate(code string) (*entity.Promo, error) {
+	return r.first(r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("code = ?", code))
+}
+
+// GetPromoByIDForUpdate locks the promo row until the surrounding
+// transaction ends. It must be called on a repository from WithTx.
+func (r *PromoRepository) GetPromoByIDForUpdate(id uuid.UUID) (*entity.Promo, error) {
+	return r.first(r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id))
+}
+
+func (r *PromoRepository) first(query *gorm.DB) (*entity.Promo, error) {
+	var promo entity.Promo
+	err := query.Preload("MealPlans").First(&promo).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &promo, nil
+}
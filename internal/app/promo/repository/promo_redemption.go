@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PromoRedemptionRepositoryItf interface {
+	WithTx(tx *gorm.DB) PromoRedemptionRepositoryItf
+	CreatePromoRedemption(redemption *entity.PromoRedemption) error
+	UpdatePromoRedemption(redemption *entity.PromoRedemption) error
+	CountPromoRedemptions(promoID uuid.UUID) (int64, error)
+	CountUserPromoRedemptions(promoID uuid.UUID, userID uuid.UUID) (int64, error)
+	GetRedeemedPromoBySubscriptionIDForUpdate(subscriptionID uuid.UUID) (*entity.PromoRedemption, error)
+}
+
+type PromoRedemptionRepository struct {
+	db *gorm.DB
+}
+
+func NewPromoRedemptionRepository(db *gorm.DB) PromoRedemptionRepositoryItf {
+	return &PromoRedemptionRepository{
+		db: db,
+	}
+}
+
+func (r *PromoRedemptionRepository) WithTx(tx *gorm.DB) PromoRedemptionRepositoryItf {
+	return &PromoRedemptionRepository{
+		db: tx,
+	}
+}
+
+func (r *PromoRedemptionRepository) CreatePromoRedemption(redemption *entity.PromoRedemption) error {
+	return r.db.Create(redemption).Error
+}
+
+func (r *PromoRedemptionRepository) UpdatePromoRedemption(redemption *entity.PromoRedemption) error {
+	return r.db.Save(redemption).Error
+}
+
+// CountPromoRedemptions counts every redemption of a promo, released ones
+// included.
+func (r *PromoRedemptionRepository) CountPromoRedemptions(promoID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.PromoRedemption{}).Where("promo_id = ?", promoID).Count(&count).Error
+	return count, err
+}
+
+func (r *PromoRedemptionRepository) CountUserPromoRedemptions(promoID uuid.UUID, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.PromoRedemption{}).
+		Where("promo_id = ? AND user_id = ? AND status = ?", promoID, userID, entity.RedemptionRedeemed).
+		Count(&count).Error
+	return count, err
+}
+
+// GetRedeemedPromoBySubscriptionIDForUpdate locks the subscription's active
+// redemption until the surrounding transaction ends. It must be called on a
+// repository from WithTx.
+func (r *PromoRedemptionRepository) GetRedeemedPromoBySubscriptionIDForUpdate(subscriptionID uuid.UUID) (*entity.PromoRedemption, error) {
+	var redemption entity.PromoRedemption
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("subscription_id = ? AND status = ?", subscriptionID, entity.RedemptionRedeemed).
+		First(&redemption).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &redemption, nil
+}
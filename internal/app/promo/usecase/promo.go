@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	mealPlanRepository "github.com/Ablebil/sea-catering-be/internal/app/meal_plan/repository"
+	promoRepository "github.com/Ablebil/sea-catering-be/internal/app/promo/repository"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/helper"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PromoUsecaseItf interface {
+	CreatePromo(req dto.PromoRequest) (*dto.PromoResponse, *res.Err)
+	GetAllPromos() ([]dto.PromoResponse, *res.Err)
+	GetPromoByID(id uuid.UUID) (*dto.PromoResponse, *res.Err)
+	UpdatePromo(id uuid.UUID, req dto.PromoRequest) (*dto.PromoResponse, *res.Err)
+	DeletePromo(id uuid.UUID) *res.Err
+}
+
+type PromoUsecase struct {
+	PromoRepository           promoRepository.PromoRepositoryItf
+	PromoRedemptionRepository promoRepository.PromoRedemptionRepositoryItf
+	MealPlanRepository        mealPlanRepository.MealPlanRepositoryItf
+	db                        *gorm.DB
+	helper                    helper.HelperItf
+}
+
+func NewPromoUsecase(promoRepository promoRepository.PromoRepositoryItf, promoRedemptionRepository promoRepository.PromoRedemptionRepositoryItf, mealPlanRepository mealPlanRepository.MealPlanRepositoryItf, db *gorm.DB, helper helper.HelperItf) PromoUsecaseItf {
+	return &PromoUsecase{
+		PromoRepository:           promoRepository,
+		PromoRedemptionRepository: promoRedemptionRepository,
+		MealPlanRepository:        mealPlanRepository,
+		db:                        db,
+		helper:                    helper,
+	}
+}
+
+func (uc *PromoUsecase) CreatePromo(req dto.PromoRequest) (*dto.PromoResponse, *res.Err) {
+	code := strings.ToUpper(req.Code)
+
+	existing, err := uc.PromoRepository.GetPromoByCode(code)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetPromoByID)
+	}
+
+	if existing != nil {
+		return nil, res.ErrConflict(res.PromoCodeAlreadyExists)
+	}
+
+	promo := &entity.Promo{}
+	if resErr := uc.fillPromo(promo, req); resErr != nil {
+		return nil, resErr
+	}
+
+	for _, id := range req.MealPlanIDs {
+		promo.MealPlans = append(promo.MealPlans, entity.PromoMealPlan{MealPlanID: id})
+	}
+
+	if err := uc.PromoRepository.CreatePromo(promo); err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSavePromo)
+	}
+
+	return toPromoResponse(promo), nil
+}
+
+func (uc *PromoUsecase) GetAllPromos() ([]dto.PromoResponse, *res.Err) {
+	promos, err := uc.PromoRepository.GetAllPromos()
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetAllPromos)
+	}
+
+	result := make([]dto.PromoResponse, 0, len(promos))
+	for i := range promos {
+		result = append(result, *toPromoResponse(&promos[i]))
+	}
+
+	return result, nil
+}
+
+func (uc *PromoUsecase) GetPromoByID(id uuid.UUID) (*dto.PromoResponse, *res.Err) {
+	promo, err := uc.PromoRepository.GetPromoByID(id)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetPromoByID)
+	}
+
+	if promo == nil {
+		return nil, res.ErrNotFound(res.PromoNotFound)
+	}
+
+	return toPromoResponse(promo), nil
+}
+
+// UpdatePromo replaces a promo's settings. The row is locked so the change
+// cannot overwrite a redemption counted by a checkout running alongside it.
+func (uc *PromoUsecase) UpdatePromo(id uuid.UUID, req dto.PromoRequest) (*dto.PromoResponse, *res.Err) {
+	code := strings.ToUpper(req.Code)
+
+	existing, err := uc.PromoRepository.GetPromoByCode(code)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetPromoByID)
+	}
+
+	if existing != nil && existing.ID != id {
+		return nil, res.ErrConflict(res.PromoCodeAlreadyExists)
+	}
+
+	var promo *entity.Promo
+	var resErr *res.Err
+
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		promoRepo := uc.PromoRepository.WithTx(tx)
+
+		promo, err = promoRepo.GetPromoByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+
+		if promo == nil {
+			resErr = res.ErrNotFound(res.PromoNotFound)
+			return resErr
+		}
+
+		if resErr = uc.fillPromo(promo, req); resErr != nil {
+			return resErr
+		}
+
+		if err := promoRepo.UpdatePromo(promo); err != nil {
+			return err
+		}
+
+		if err := promoRepo.ReplacePromoMealPlans(promo.ID, req.MealPlanIDs); err != nil {
+			return err
+		}
+
+		promo.MealPlans = promo.MealPlans[:0]
+		for _, mealPlanID := range req.MealPlanIDs {
+			promo.MealPlans = append(promo.MealPlans, entity.PromoMealPlan{PromoID: promo.ID, MealPlanID: mealPlanID})
+		}
+
+		return nil
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSavePromo)
+	}
+
+	return toPromoResponse(promo), nil
+}
+
+// DeletePromo removes a promo that was never used. Redeemed promos stay for
+// the record and can only be deactivated.
+func (uc *PromoUsecase) DeletePromo(id uuid.UUID) *res.Err {
+	promo, err := uc.PromoRepository.GetPromoByID(id)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetPromoByID)
+	}
+
+	if promo == nil {
+		return res.ErrNotFound(res.PromoNotFound)
+	}
+
+	redemptions, err := uc.PromoRedemptionRepository.CountPromoRedemptions(id)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetPromoRedemptions)
+	}
+
+	if redemptions > 0 {
+		return res.ErrConflict(res.PromoAlreadyRedeemed)
+	}
+
+	if err := uc.PromoRepository.DeletePromo(id); err != nil {
+		return res.ErrInternalServerError(res.FailedDeletePromo)
+	}
+
+	return nil
+}
+
+// fillPromo copies a request onto a promo after checking what the validator
+// cannot: the percentage cap and that every meal plan exists.
+func (uc *PromoUsecase) fillPromo(promo *entity.Promo, req dto.PromoRequest) *res.Err {
+	discountType := entity.PromoDiscountType(req.DiscountType)
+	if discountType == entity.PromoPercentage && req.DiscountValue > 100 {
+		return res.ErrBadRequest(res.InvalidPromoPercentage)
+	}
+
+	validFrom, validUntil, resErr := uc.helper.ParseDateRange(req.ValidFrom, req.ValidUntil)
+	if resErr != nil {
+		return resErr
+	}
+
+	for _, id := range req.MealPlanIDs {
+		mealPlan, err := uc.MealPlanRepository.GetMealPlanByID(id)
+		if err != nil {
+			return res.ErrInternalServerError(res.FailedGetMealPlanByID)
+		}
+
+		if mealPlan == nil {
+			return res.ErrNotFound(res.MealPlanNotFound)
+		}
+	}
+
+	promo.Code = strings.ToUpper(req.Code)
+	promo.Description = req.Description
+	promo.DiscountType = discountType
+	promo.DiscountValue = req.DiscountValue
+	promo.MaxDiscount = req.MaxDiscount
+	promo.MinSpend = req.MinSpend
+	promo.ValidFrom = validFrom
+	promo.ValidUntil = validUntil
+	promo.MaxRedemptions = req.MaxRedemptions
+	promo.PerUserLimit = req.PerUserLimit
+	promo.IsActive = *req.IsActive
+
+	return nil
+}
+
+func toPromoResponse(promo *entity.Promo) *dto.PromoResponse {
+	mealPlanIDs := make([]uuid.UUID, 0, len(promo.MealPlans))
+	for _, mealPlan := range promo.MealPlans {
+		mealPlanIDs = append(mealPlanIDs, mealPlan.MealPlanID)
+	}
+
+	var createdAt time.Time
+	if promo.CreatedAt != nil {
+		createdAt = *promo.CreatedAt
+	}
+
+	return &dto.PromoResponse{
+		ID:              promo.ID,
+		Code:            promo.Code,
+		Description:     promo.Description,
+		DiscountType:    string(promo.DiscountType),
+		DiscountValue:   promo.DiscountValue,
+		MaxDiscount:     promo.MaxDiscount,
+		MinSpend:        promo.MinSpend,
+		ValidFrom:       promo.ValidFrom,
+		ValidUntil:      promo.ValidUntil,
+		MaxRedemptions:  promo.MaxRedemptions,
+		PerUserLimit:    promo.PerUserLimit,
+		RedemptionCount: promo.RedemptionCount,
+		MealPlanIDs:     mealPlanIDs,
+		IsActive:        promo.IsActive,
+		CreatedAt:       createdAt,
+	}
+}
@@ -0,0 +1,180 @@
+package rest
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/app/refund/usecase"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type RefundHandler struct {
+	Validator     *validator.Validate
+	RefundUsecase usecase.RefundUsecaseItf
+}
+
+func NewRefundHandler(routerGroup fiber.Router, validator *validator.Validate, refundUsecase usecase.RefundUsecaseItf, middleware middleware.MiddlewareItf) {
+	refundHandler := RefundHandler{
+		Validator:     validator,
+		RefundUsecase: refundUsecase,
+	}
+
+	routerGroup.Get("/subscriptions/:id/refunds", middleware.Authentication, refundHandler.GetSubscriptionRefunds)
+
+	adminRouterGroup := routerGroup.Group("/admin/refunds", middleware.Authentication, middleware.Authorization)
+	adminRouterGroup.Get("/", refundHandler.GetRefunds)
+	adminRouterGroup.Put("/:id/approve", refundHandler.ApproveRefund)
+	adminRouterGroup.Put("/:id/reject", refundHandler.RejectRefund)
+}
+
+// @Summary      Get Subscription Refunds
+// @Description  List the refunds opened when one of the authenticated user's subscriptions was cancelled.
+// @Tags         Refund
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=[]dto.RefundResponse} "Get subscription refunds successful"
+// @Failure      400  {object}  res.Err "Invalid subscription ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/refunds [get]
+func (h RefundHandler) GetSubscriptionRefunds(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	refunds, resErr := h.RefundUsecase.GetSubscriptionRefunds(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, refunds, res.GetSubscriptionRefundsSuccess)
+}
+
+// @Summary      Get Refunds
+// @Description  List refunds, optionally filtered by status (admin only).
+// @Tags         Refund
+// @Produce      json
+// @Param        status query string false "Refund status" Enums(requested, approved, rejected, refunded)
+// @Success      200  {object}  res.Res{payload=[]dto.RefundResponse} "Get refunds successful"
+// @Failure      400  {object}  res.Err "Invalid request params"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/refunds/ [get]
+func (h RefundHandler) GetRefunds(ctx *fiber.Ctx) error {
+	req := new(dto.GetRefundsRequest)
+	if err := ctx.QueryParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestParams)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	refunds, err := h.RefundUsecase.GetRefunds(*req)
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, refunds, res.GetRefundsSuccess)
+}
+
+// @Summary      Approve Refund
+// @Description  Approve a requested refund (admin only). By default it is credited to the customer's wallet and refunded straight away; with destination=gateway the payment gateway pays it out instead and the refund is marked refunded once the gateway confirms it.
+// @Tags         Refund
+// @Produce      json
+// @Param        id          path   string  true   "Refund ID" Format(uuid)
+// @Param        destination query  string  false  "Where the refund goes" Enums(wallet, gateway) default(wallet)
+// @Success      200  {object}  res.Res{payload=dto.RefundResponse} "Refund approved successful"
+// @Failure      400  {object}  res.Err "Invalid refund ID, request params or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Refund not found"
+// @Failure      409  {object}  res.Err "Refund has already been approved or rejected"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/refunds/{id}/approve [put]
+func (h RefundHandler) ApproveRefund(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidRefundID)
+	}
+
+	req := new(dto.ApproveRefundRequest)
+	if err := ctx.QueryParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestParams)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	refund, resErr := h.RefundUsecase.ApproveRefund(id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, refund, res.ApproveRefundSuccess)
+}
+
+// @Summary      Reject Refund
+// @Description  Reject a requested refund with a reason shown to the customer (admin only).
+// @Tags         Refund
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string                   true  "Refund ID" Format(uuid)
+// @Param        payload body  dto.RejectRefundRequest  true  "Reject Refund Request"
+// @Success      200  {object}  res.Res{payload=dto.RefundResponse} "Refund rejected successful"
+// @Failure      400  {object}  res.Err "Invalid refund ID, request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "Refund not found"
+// @Failure      409  {object}  res.Err "Refund has already been approved or rejected"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/refunds/{id}/reject [put]
+func (h RefundHandler) RejectRefund(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidRefundID)
+	}
+
+	req := new(dto.RejectRefundRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	refund, resErr := h.RefundUsecase.RejectRefund(id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, refund, res.RejectRefundSuccess)
+}
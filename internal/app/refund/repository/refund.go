@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefundRepositoryItf interface {
+	WithTx(tx *gorm.DB) RefundRepositoryItf
+	CreateRefund(refund *entity.Refund) error
+	UpdateRefund(refund *entity.Refund) error
+	GetRefunds(status string) ([]entity.Refund, error)
+	GetRefundsBySubscriptionID(subscriptionID uuid.UUID) ([]entity.Refund, error)
+	GetRefundByIDForUpdate(id uuid.UUID) (*entity.Refund, error)
+	GetRefundByOrderIDForUpdate(orderID string) (*entity.Refund, error)
+}
+
+type RefundRepository struct {
+	db *gorm.DB
+}
+
+func NewRefundRepository(db *gorm.DB) RefundRepositoryItf {
+	return &RefundRepository{
+		db: db,
+	}
+}
+
+func (r *RefundRepository) WithTx(tx *gorm.DB) RefundRepositoryItf {
+	return &RefundRepository{
+		db: tx,
+	}
+}
+
+func (r *RefundRepository) CreateRefund(refund *entity.Refund) error {
+	return r.db.Create(refund).Error
+}
+
+func (r *RefundRepository) UpdateRefund(refund *entity.Refund) error {
+	return r.db.Save(refund).Error
+}
+
+func (r *RefundRepository) GetRefunds(status string) ([]entity.Refund, error) {
+	var refunds []entity.Refund
+	query := r.db.Model(&entity.Refund{})
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Order("created_at desc").Find(&refunds).Error
+	return refunds, err
+}
+
+func (r *RefundRepository) GetRefundsBySubscriptionID(subscriptionID uuid.UUID) ([]entity.Refund, error) {
+	var refunds []entity.Refund
+	err := r.db.Where("subscription_id = ?", subscriptionID).Order("created_at desc").Find(&refunds).Error
+	return refunds, err
+}
+
+// GetRefundByIDForUpdate locks the refund until the surrounding transaction
+// ends. It must be called on a repository from WithTx.
+func (r *RefundRepository) GetRefundByIDForUpdate(id uuid.UUID) (*entity.Refund, error) {
+	return r.firstForUpdate("id = ?", id)
+}
+
+// GetRefundByOrderIDForUpdate locks the refund of an order until the
+// surrounding transaction ends. It must be called on a repository from WithTx.
+func (r *RefundRepository) GetRefundByOrderIDForUpdate(orderID string) (*entity.Refund, error) {
+	return r.firstForUpdate("order_id = ?", orderID)
+}
+
+func (r *RefundRepository) firstForUpdate(query string, args ...any) (*entity.Refund, error) {
+	var refund entity.Refund
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where(query, args...).First(&refund).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &refund, nil
+}
@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"log"
+	"time"
+
+	refundRepository "github.com/Ablebil/sea-catering-be/internal/app/refund/repository"
+	subscriptionRepository "github.com/Ablebil/sea-catering-be/internal/app/subscription/repository"
+	walletRepository "github.com/Ablebil/sea-catering-be/internal/app/wallet/repository"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/infra/payment"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefundUsecaseItf interface {
+	GetRefunds(req dto.GetRefundsRequest) ([]dto.RefundResponse, *res.Err)
+	GetSubscriptionRefunds(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.RefundResponse, *res.Err)
+	ApproveRefund(id uuid.UUID, req dto.ApproveRefundRequest) (*dto.RefundResponse, *res.Err)
+	RejectRefund(id uuid.UUID, req dto.RejectRefundRequest) (*dto.RefundResponse, *res.Err)
+}
+
+type RefundUsecase struct {
+	RefundRepository       refundRepository.RefundRepositoryItf
+	SubscriptionRepository subscriptionRepository.SubscriptionRepositoryItf
+	WalletRepository       walletRepository.WalletRepositoryItf
+	db                     *gorm.DB
+	paymentGateway         payment.PaymentGatewayItf
+}
+
+func NewRefundUsecase(refundRepository refundRepository.RefundRepositoryItf, subscriptionRepository subscriptionRepository.SubscriptionRepositoryItf, walletRepository walletRepository.WalletRepositoryItf, db *gorm.DB, paymentGateway payment.PaymentGatewayItf) RefundUsecaseItf {
+	return &RefundUsecase{
+		RefundRepository:       refundRepository,
+		SubscriptionRepository: subscriptionRepository,
+		WalletRepository:       walletRepository,
+		db:                     db,
+		paymentGateway:         paymentGateway,
+	}
+}
+
+func (uc *RefundUsecase) GetRefunds(req dto.GetRefundsRequest) ([]dto.RefundResponse, *res.Err) {
+	refunds, err := uc.RefundRepository.GetRefunds(req.Status)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetRefunds)
+	}
+
+	return toRefundResponses(refunds), nil
+}
+
+func (uc *RefundUsecase) GetSubscriptionRefunds(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.RefundResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	refunds, err := uc.RefundRepository.GetRefundsBySubscriptionID(sub.ID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetRefunds)
+	}
+
+	return toRefundResponses(refunds), nil
+}
+
+// ApproveRefund pays out a requested refund. By default it becomes store
+// credit in the customer's wallet, which is refunded straight away. Paid out
+// through the payment gateway instead, the refund stays locked during the
+// call so two admins cannot pay it out twice, and its ID is the refund key,
+// which the gateway uses to drop repeated requests. Most channels refund
+// asynchronously; those refunds are marked refunded when the refund
+// notification arrives.
+func (uc *RefundUsecase) ApproveRefund(id uuid.UUID, req dto.ApproveRefundRequest) (*dto.RefundResponse, *res.Err) {
+	destination := entity.RefundToWallet
+	if req.Destination != "" {
+		destination = entity.RefundDestination(req.Destination)
+	}
+
+	var refund *entity.Refund
+	var resErr *res.Err
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		refundRepo := uc.RefundRepository.WithTx(tx)
+
+		var err error
+		refund, err = refundRepo.GetRefundByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+
+		if resErr = checkReviewable(refund); resErr != nil {
+			return resErr
+		}
+
+		now := time.Now()
+		refund.Status = entity.RefundApproved
+		refund.Destination = &destination
+		refund.ReviewedAt = &now
+
+		if destination == entity.RefundToWallet {
+			resErr = uc.refundToWallet(tx, refund)
+		} else {
+			resErr = uc.refundThroughGateway(refund)
+		}
+
+		if resErr != nil {
+			return resErr
+		}
+
+		return refundRepo.UpdateRefund(refund)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSaveRefund)
+	}
+
+	return toRefundResponse(refund), nil
+}
+
+// refundToWallet credits the refund to whoever paid the order: the buyer of
+// a gift, otherwise the subscription's owner.
+func (uc *RefundUsecase) refundToWallet(tx *gorm.DB, refund *entity.Refund) *res.Err {
+	userID := refund.PayerID
+	if userID == nil {
+		sub, err := uc.SubscriptionRepository.WithTx(tx).GetSubscriptionByID(refund.SubscriptionID)
+		if err != nil {
+			return res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+		}
+
+		if sub == nil {
+			return res.ErrNotFound(res.SubscriptionNotFound)
+		}
+
+		userID = &sub.UserID
+	}
+
+	reference := refund.ID.String()
+	if err := uc.WalletRepository.WithTx(tx).Credit(&entity.WalletTransaction{
+		UserID:    *userID,
+		Amount:    refund.Amount,
+		Reason:    entity.WalletRefund,
+		Reference: &reference,
+	}); err != nil {
+		return res.ErrInternalServerError(res.FailedSaveRefund)
+	}
+
+	refund.Status = entity.RefundRefunded
+	refund.RefundedAt = refund.ReviewedAt
+	return nil
+}
+
+func (uc *RefundUsecase) refundThroughGateway(refund *entity.Refund) *res.Err {
+	refundKey := refund.ID.String()
+	refundResp, err := uc.paymentGateway.Refund(&dto.GatewayRefundRequest{
+		OrderID:   refund.OrderID,
+		RefundKey: refundKey,
+		Amount:    int64(pricing.FromFloat(refund.Amount)),
+		Reason:    "Subscription cancelled",
+	})
+	if err != nil {
+		log.Printf("Failed to refund order %s: %v", refund.OrderID, err)
+		return res.ErrInternalServerError(res.FailedRequestRefund)
+	}
+
+	refund.RefundKey = &refundKey
+	if refundResp.TransactionStatus == "refund" || refundResp.TransactionStatus == "partial_refund" {
+		refund.Status = entity.RefundRefunded
+		refund.RefundedAt = refund.ReviewedAt
+	}
+
+	return nil
+}
+
+func (uc *RefundUsecase) RejectRefund(id uuid.UUID, req dto.RejectRefundRequest) (*dto.RefundResponse, *res.Err) {
+	var refund *entity.Refund
+	var resErr *res.Err
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		refundRepo := uc.RefundRepository.WithTx(tx)
+
+		var err error
+		refund, err = refundRepo.GetRefundByIDForUpdate(id)
+		if err != nil {
+			return err
+		}
+
+		if resErr = checkReviewable(refund); resErr != nil {
+			return resErr
+		}
+
+		now := time.Now()
+		refund.Status = entity.RefundRejected
+		refund.RejectReason = &req.Reason
+		refund.ReviewedAt = &now
+
+		return refundRepo.UpdateRefund(refund)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSaveRefund)
+	}
+
+	return toRefundResponse(refund), nil
+}
+
+func checkReviewable(refund *entity.Refund) *res.Err {
+	if refund == nil {
+		return res.ErrNotFound(res.RefundNotFound)
+	}
+
+	if refund.Status != entity.RefundRequested {
+		return res.ErrConflict(res.RefundAlreadyReviewed)
+	}
+
+	return nil
+}
+
+func toRefundResponses(refunds []entity.Refund) []dto.RefundResponse {
+	result := make([]dto.RefundResponse, 0, len(refunds))
+	for i := range refunds {
+		result = append(result, *toRefundResponse(&refunds[i]))
+	}
+
+	return result
+}
+
+func toRefundResponse(refund *entity.Refund) *dto.RefundResponse {
+	return &dto.RefundResponse{
+		ID:              refund.ID,
+		SubscriptionID:  refund.SubscriptionID,
+		OrderID:         refund.OrderID,
+		PaidAmount:      refund.PaidAmount,
+		UndeliveredDays: refund.UndeliveredDays,
+		TotalDays:       refund.TotalDays,
+		Fee:             refund.Fee,
+		Amount:          refund.Amount,
+		Status:          string(refund.Status),
+		Destination:     (*string)(refund.Destination),
+		RejectReason:    refund.RejectReason,
+		ReviewedAt:      refund.ReviewedAt,
+		RefundedAt:      refund.RefundedAt,
+		CreatedAt:       refund.CreatedAt,
+	}
+}
@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"net/http"
+
 	"github.com/Ablebil/sea-catering-be/internal/app/subscription/usecase"
 	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
 	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
@@ -24,8 +26,18 @@ func NewSubscriptionHandler(routerGroup fiber.Router, validator *validator.Valid
 
 	routerGroup = routerGroup.Group("/subscriptions")
 	routerGroup.Post("/", limiter.Subscription(), middleware.Authentication, subscriptionHandler.CreateSubscription)
+	routerGroup.Post("/quote", subscriptionHandler.QuoteSubscription)
 	routerGroup.Get("/", middleware.Authentication, subscriptionHandler.GetUserSubscriptions)
+	routerGroup.Patch("/:id", middleware.Authentication, subscriptionHandler.UpdateSubscription)
+	routerGroup.Get("/:id/changes", middleware.Authentication, subscriptionHandler.GetSubscriptionChanges)
 	routerGroup.Put("/:id/pause", middleware.Authentication, subscriptionHandler.PauseSubscription)
+	routerGroup.Put("/:id/resume", middleware.Authentication, subscriptionHandler.ResumeSubscription)
+	routerGroup.Put("/:id/auto-renew", middleware.Authentication, subscriptionHandler.UpdateAutoRenew)
+	routerGroup.Put("/:id/address", middleware.Authentication, subscriptionHandler.ChangeDeliveryAddress)
+	routerGroup.Delete("/:id/address", middleware.Authentication, subscriptionHandler.CancelAddressChange)
+	routerGroup.Get("/:id/address-changes", middleware.Authentication, subscriptionHandler.GetAddressChanges)
+	routerGroup.Get("/:id/billing-periods", middleware.Authentication, subscriptionHandler.GetBillingPeriods)
+	routerGroup.Post("/:id/pay", limiter.Subscription(), middleware.Authentication, subscriptionHandler.PaySubscription)
 	routerGroup.Delete("/:id", middleware.Authentication, subscriptionHandler.CancelSubscription)
 
 	adminRouterGroup := routerGroup.Group("/admin", middleware.Authentication, middleware.Authorization)
@@ -34,19 +46,21 @@ func NewSubscriptionHandler(routerGroup fiber.Router, validator *validator.Valid
 	adminRouterGroup.Get("/stats/active-total", subscriptionHandler.GetTotalActiveSubscriptions)
 	adminRouterGroup.Get("/stats/reactivations", subscriptionHandler.GetReactivationStats)
 
-	routerGroup.Post("/webhook/midtrans", subscriptionHandler.HandleMidtransWebhook)
+	routerGroup.Post("/webhook/payment", subscriptionHandler.HandlePaymentWebhook)
+	routerGroup.Post("/webhook/midtrans", subscriptionHandler.HandlePaymentWebhook)
 }
 
 // @Summary      Create Subscription
-// @Description  Create a new meal plan subscription with payment.
+// @Description  Create a new meal plan subscription with payment. With use_wallet_credit, wallet credit pays as much of the first billing period as it covers and only the rest is charged. With gift, the subscription is bought for the recipient named in the request: it does not auto-renew, and once paid a gift code is emailed to recipient_email for them to claim it. Once delivery zones are set up, the address must fall in an active zone that delivers on every chosen day, and that zone's delivery fee is charged. With address_id, the name, phone number and address come from that address book entry instead.
 // @Tags         Subscription
 // @Accept       json
 // @Produce      json
 // @Param        payload body dto.CreateSubscriptionRequest true "Create Subscription Request"
 // @Success      201  {object}  res.Res{payload=dto.PaymentResponse} "Subscription created successfully"
-// @Failure      400  {object}  res.Err "Invalid request body or validation error"
+// @Failure      400  {object}  res.Err "Invalid request body, validation error or no city or postal code while delivery zones are active"
 // @Failure      401  {object}  res.Err "Missing or invalid access token"
-// @Failure      404  {object}  res.Err "Meal plan not found"
+// @Failure      404  {object}  res.Err "Meal plan or address not found"
+// @Failure      422  {object}  res.Err "Address not covered or delivery day not served by its zone"
 // @Failure      500  {object}  res.Err "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /subscriptions/ [post]
@@ -76,6 +90,41 @@ func (h SubscriptionHandler) CreateSubscription(ctx *fiber.Ctx) error {
 	return res.Created(ctx, paymentResp, res.CreateSubscriptionSuccess)
 }
 
+// @Summary      Quote Subscription
+// @Description  Preview the itemized price of a subscription before checkout, using the same pricing as subscription creation. With postal_code or coordinates, the address is checked against the delivery zones and priced with its zone's fee.
+// @Tags         Subscription
+// @Accept       json
+// @Produce      json
+// @Param        payload body dto.QuoteSubscriptionRequest true "Quote Subscription Request"
+// @Success      200  {object}  res.Res{payload=dto.QuoteResponse} "Quote subscription successful"
+// @Failure      400  {object}  res.Err "Invalid request body or validation error"
+// @Failure      404  {object}  res.Err "Meal plan not found"
+// @Failure      422  {object}  res.Err "Address not covered or delivery day not served by its zone"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Router       /subscriptions/quote [post]
+func (h SubscriptionHandler) QuoteSubscription(ctx *fiber.Ctx) error {
+	req := new(dto.QuoteSubscriptionRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	quote, err := h.SubscriptionUsecase.QuoteSubscription(*req)
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, quote, res.QuoteSubscriptionSuccess)
+}
+
 // @Summary      Get User Subscriptions
 // @Description  Retrieve all subscriptions for the authenticated user.
 // @Tags         Subscription
@@ -96,6 +145,81 @@ func (h SubscriptionHandler) GetUserSubscriptions(ctx *fiber.Ctx) error {
 	return res.OK(ctx, subs, res.GetAllSubscriptionsSuccess)
 }
 
+// @Summary      Update Subscription
+// @Description  Change the meal plan, meal types or delivery days of an active or paused subscription. The price difference for the remaining days is prorated: downgrades are credited to the subscription, upgrades spend existing credit first and return a payment link for the rest. A charged change takes effect once it is paid.
+// @Tags         Subscription
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string                         true  "Subscription ID" Format(uuid)
+// @Param        payload body  dto.UpdateSubscriptionRequest  true  "Update Subscription Request"
+// @Success      200  {object}  res.Res{payload=dto.UpdateSubscriptionResponse} "Subscription updated successfully"
+// @Failure      400  {object}  res.Err "Invalid subscription ID, request body or no changes requested"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription or meal plan not found"
+// @Failure      409  {object}  res.Err "Subscription cannot be changed or already has a change awaiting payment"
+// @Failure      422  {object}  res.Err "Delivery day not served by the subscription's zone"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id} [patch]
+func (h SubscriptionHandler) UpdateSubscription(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+	email := ctx.Locals("email").(string)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	req := new(dto.UpdateSubscriptionRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	updateResp, resErr := h.SubscriptionUsecase.UpdateSubscription(userID, email, id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, updateResp, res.UpdateSubscriptionSuccess)
+}
+
+// @Summary      Get Subscription Changes
+// @Description  List the plan changes made to a subscription, newest first.
+// @Tags         Subscription
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=[]dto.SubscriptionChangeResponse} "Get subscription changes successful"
+// @Failure      400  {object}  res.Err "Invalid subscription ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/changes [get]
+func (h SubscriptionHandler) GetSubscriptionChanges(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	changes, resErr := h.SubscriptionUsecase.GetSubscriptionChanges(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, changes, res.GetSubscriptionChangesSuccess)
+}
+
 // @Summary      Pause Subscription
 // @Description  Temporarily pause a subscription by specifying start and end dates. The subscription duration will be extended by the pause period.
 // @Tags         Subscription
@@ -108,6 +232,7 @@ func (h SubscriptionHandler) GetUserSubscriptions(ctx *fiber.Ctx) error {
 // @Failure      401  {object}  res.Err "Missing or invalid access token"
 // @Failure      403  {object}  res.Err "Not authorized to pause this subscription"
 // @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      409  {object}  res.Err "Subscription cannot be paused in its current status"
 // @Failure      500  {object}  res.Err "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /subscriptions/{id}/pause [put]
@@ -141,8 +266,241 @@ func (h SubscriptionHandler) PauseSubscription(ctx *fiber.Ctx) error {
 	return res.OK(ctx, pausedSub, res.PauseSubscriptionSuccess)
 }
 
+// @Summary      Resume Subscription
+// @Description  Resume a paused subscription before its pause window ends, or drop a pause that has not started yet. Unused pause days are taken off the extended end date.
+// @Tags         Subscription
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=dto.SubscriptionResponse} "Subscription resumed successfully"
+// @Failure      400  {object}  res.Err "Invalid subscription ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      409  {object}  res.Err "Subscription is not paused"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/resume [put]
+func (h SubscriptionHandler) ResumeSubscription(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	resumedSub, resErr := h.SubscriptionUsecase.ResumeSubscription(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, resumedSub, res.ResumeSubscriptionSuccess)
+}
+
+// @Summary      Update Auto-Renew
+// @Description  Turn automatic renewal on or off. Turning it off withdraws a renewal that has not been paid yet.
+// @Tags         Subscription
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string                      true  "Subscription ID" Format(uuid)
+// @Param        payload body  dto.UpdateAutoRenewRequest  true  "Update Auto-Renew Request"
+// @Success      200  {object}  res.Res{payload=dto.SubscriptionResponse} "Auto-renew updated successfully"
+// @Failure      400  {object}  res.Err "Invalid subscription ID or request body"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      409  {object}  res.Err "Subscription can no longer be renewed"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/auto-renew [put]
+func (h SubscriptionHandler) UpdateAutoRenew(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	req := new(dto.UpdateAutoRenewRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	updatedSub, resErr := h.SubscriptionUsecase.UpdateAutoRenew(userID, id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, updatedSub, res.UpdateAutoRenewSuccess)
+}
+
+// @Summary      Change Delivery Address
+// @Description  Move an active or paused subscription to an address from the address book, starting on effective_date. The date must fall after the next delivery cutoff and before the subscription ends, and the address must be covered by a delivery zone serving the subscription's delivery days. A change still waiting is replaced. The delivery fee stays as it is until the plan is next changed.
+// @Tags         Subscription
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string                            true  "Subscription ID" Format(uuid)
+// @Param        payload body  dto.ChangeDeliveryAddressRequest  true  "Change Delivery Address Request"
+// @Success      200  {object}  res.Res{payload=dto.SubscriptionAddressChangeResponse} "Address change scheduled successful"
+// @Failure      400  {object}  res.Err "Invalid subscription ID, request body or effective date"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription or address not found"
+// @Failure      409  {object}  res.Err "Subscription can only be changed while active or paused"
+// @Failure      422  {object}  res.Err "Address not covered or delivery day not served by its zone"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/address [put]
+func (h SubscriptionHandler) ChangeDeliveryAddress(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	req := new(dto.ChangeDeliveryAddressRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	change, resErr := h.SubscriptionUsecase.ChangeDeliveryAddress(userID, id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, change, res.ChangeDeliveryAddressSuccess)
+}
+
+// @Summary      Cancel Address Change
+// @Description  Withdraw a delivery address change that has not taken effect yet.
+// @Tags         Subscription
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID" Format(uuid)
+// @Success      200  {object}  res.Res "Address change cancelled successful"
+// @Failure      400  {object}  res.Err "Invalid subscription ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found or no address change scheduled"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/address [delete]
+func (h SubscriptionHandler) CancelAddressChange(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	if resErr := h.SubscriptionUsecase.CancelAddressChange(userID, id); resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, nil, res.CancelAddressChangeSuccess)
+}
+
+// @Summary      Get Address Changes
+// @Description  List the delivery address changes scheduled for a subscription, newest first.
+// @Tags         Subscription
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=[]dto.SubscriptionAddressChangeResponse} "Get address changes successful"
+// @Failure      400  {object}  res.Err "Invalid subscription ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/address-changes [get]
+func (h SubscriptionHandler) GetAddressChanges(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	changes, resErr := h.SubscriptionUsecase.GetAddressChanges(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, changes, res.GetAddressChangesSuccess)
+}
+
+// @Summary      Get Billing Periods
+// @Description  List every billing period of a subscription, oldest first, including unpaid renewals and their payment links.
+// @Tags         Subscription
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=[]dto.BillingPeriodResponse} "Get billing periods successful"
+// @Failure      400  {object}  res.Err "Invalid subscription ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/billing-periods [get]
+func (h SubscriptionHandler) GetBillingPeriods(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	periods, resErr := h.SubscriptionUsecase.GetBillingPeriods(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, periods, res.GetBillingPeriodsSuccess)
+}
+
+// @Summary      Pay Subscription
+// @Description  Issue a new payment link for a subscription still awaiting its first payment. The previous payment link is voided and the new one charges the same amount.
+// @Tags         Subscription
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=dto.PaymentResponse} "Payment link issued successfully"
+// @Failure      400  {object}  res.Err "Invalid subscription ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      409  {object}  res.Err "Subscription is not awaiting payment"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /subscriptions/{id}/pay [post]
+func (h SubscriptionHandler) PaySubscription(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+	email := ctx.Locals("email").(string)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidSubscriptionID)
+	}
+
+	paymentResp, resErr := h.SubscriptionUsecase.PaySubscription(userID, email, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, paymentResp, res.PaySubscriptionSuccess)
+}
+
 // @Summary      Cancel Subscription
-// @Description  Permanently cancel a subscription. This action cannot be undone.
+// @Description  Permanently cancel a subscription. This action cannot be undone. Payments covering days that will no longer be delivered get a pro-rata refund, less a fee, once an admin approves it.
 // @Tags         Subscription
 // @Produce      json
 // @Param        id   path      string  true  "Subscription ID" Format(uuid)
@@ -151,6 +509,7 @@ func (h SubscriptionHandler) PauseSubscription(ctx *fiber.Ctx) error {
 // @Failure      401  {object}  res.Err "Missing or invalid access token"
 // @Failure      403  {object}  res.Err "Not authorized to cancel this subscription"
 // @Failure      404  {object}  res.Err "Subscription not found"
+// @Failure      409  {object}  res.Err "Subscription cannot be cancelled in its current status"
 // @Failure      500  {object}  res.Err "Internal Server Error"
 // @Security     ApiKeyAuth
 // @Router       /subscriptions/{id} [delete]
@@ -297,24 +656,21 @@ func (h SubscriptionHandler) GetReactivationStats(ctx *fiber.Ctx) error {
 	return res.OK(ctx, fiber.Map{"count": count}, res.GetReactivationStatsSuccess)
 }
 
-// @Summary      Handle Midtrans Webhook
-// @Description  Handle payment notification from Midtrans payment gateway. This endpoint is called by Midtrans to notify payment status changes.
+// @Summary      Handle Payment Webhook
+// @Description  Handle a payment notification from the configured payment gateway (Midtrans, Xendit or the local fake). The gateway authenticates the delivery: Midtrans signs the body, Xendit sends its callback token in x-callback-token. /subscriptions/webhook/midtrans is kept as an alias for existing Midtrans dashboards.
 // @Tags         Subscription
 // @Accept       json
 // @Produce      json
-// @Param        payload body object{order_id=string,transaction_status=string,payment_type=string} true "Midtrans Notification"
+// @Param        payload body object true "Gateway notification"
 // @Success      200  {object}  res.Res "Webhook processed successfully"
-// @Failure      400  {object}  res.Err "Invalid notification data"
+// @Failure      400  {object}  res.Err "Invalid notification data or gross amount mismatch"
+// @Failure      403  {object}  res.Err "Invalid signature key"
 // @Failure      404  {object}  res.Err "Subscription not found"
 // @Failure      500  {object}  res.Err "Internal Server Error"
-// @Router       /subscriptions/webhook/midtrans [post]
-func (h SubscriptionHandler) HandleMidtransWebhook(ctx *fiber.Ctx) error {
-	var notification map[string]interface{}
-	if err := ctx.BodyParser(&notification); err != nil {
-		return res.ErrBadRequest(res.FailedParsingRequestBody)
-	}
-
-	if resErr := h.SubscriptionUsecase.HandlePaymentNotification(notification); resErr != nil {
+// @Router       /subscriptions/webhook/payment [post]
+func (h SubscriptionHandler) HandlePaymentWebhook(ctx *fiber.Ctx) error {
+	header := http.Header(ctx.GetReqHeaders())
+	if resErr := h.SubscriptionUsecase.HandlePaymentNotification(header, ctx.Body()); resErr != nil {
 		return resErr
 	}
 
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BillingPeriodRepositoryItf interface {
+	WithTx(tx *gorm.DB) BillingPeriodRepositoryItf
+	CreateBillingPeriod(period *entity.BillingPeriod) error
+	UpdateBillingPeriod(period *entity.BillingPeriod) error
+	GetBillingPeriodByOrderID(orderID string) (*entity.BillingPeriod, error)
+	GetBillingPeriodByOrderIDForUpdate(orderID string) (*entity.BillingPeriod, error)
+	GetBillingPeriodsBySubscriptionID(subscriptionID uuid.UUID) ([]entity.BillingPeriod, error)
+	GetLatestBillingPeriod(subscriptionID uuid.UUID) (*entity.BillingPeriod, error)
+	CancelPendingBillingPeriods(subscriptionID uuid.UUID) error
+}
+
+type BillingPeriodRepository struct {
+	db *gorm.DB
+}
+
+func NewBillingPeriodRepository(db *gorm.DB) BillingPeriodRepositoryItf {
+	return &BillingPeriodRepository{
+		db: db,
+	}
+}
+
+func (r *BillingPeriodRepository) WithTx(tx *gorm.DB) BillingPeriodRepositoryItf {
+	return &BillingPeriodRepository{
+		db: tx,
+	}
+}
+
+func (r *BillingPeriodRepository) CreateBillingPeriod(period *entity.BillingPeriod) error {
+	return r.db.Create(period).Error
+}
+
+func (r *BillingPeriodRepository) UpdateBillingPeriod(period *entity.BillingPeriod) error {
+	return r.db.Save(period).Error
+}
+
+func (r *BillingPeriodRepository) GetBillingPeriodByOrderID(orderID string) (*entity.BillingPeriod, error) {
+	var period entity.BillingPeriod
+	err := r.db.Where("order_id = ?", orderID).First(&period).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+func (r *BillingPeriodRepository) GetBillingPeriodByOrderIDForUpdate(orderID string) (*entity.BillingPeriod, error) {
+	var period entity.BillingPeriod
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", orderID).First(&period).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+func (r *BillingPeriodRepository) GetBillingPeriodsBySubscriptionID(subscriptionID uuid.UUID) ([]entity.BillingPeriod, error) {
+	var periods []entity.BillingPeriod
+	err := r.db.Where("subscription_id = ?", subscriptionID).Order("period_number asc").Find(&periods).Error
+	return periods, err
+}
+
+func (r *BillingPeriodRepository) GetLatestBillingPeriod(subscriptionID uuid.UUID) (*entity.BillingPeriod, error) {
+	var period entity.BillingPeriod
+	err := r.db.Where("subscription_id = ?", subscriptionID).Order("period_number desc").First(&period).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+func (r *BillingPeriodRepository) CancelPendingBillingPeriods(subscriptionID uuid.UUID) error {
+	return r.db.Model(&entity.BillingPeriod{}).
+		Where("subscription_id = ? AND status = ?", subscriptionID, entity.BillingPending).
+		Update("status", entity.BillingCancelled).Error
+}
@@ -7,16 +7,26 @@ import (
 	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type SubscriptionRepositoryItf interface {
+	WithTx(tx *gorm.DB) SubscriptionRepositoryItf
 	CreateSubscription(subscription *entity.Subscription) error
 	UpdateStatus(subscription *entity.Subscription, newStatus entity.SubscriptionStatus) error
+	UpdateSubscription(subscription *entity.Subscription) error
 	GetAllSubscriptionByUserID(userID uuid.UUID) ([]entity.Subscription, error)
 	GetSubscriptionByID(id uuid.UUID) (*entity.Subscription, error)
+	GetSubscriptionWithUserByID(id uuid.UUID) (*entity.Subscription, error)
 	GetSubscriptionByIDAndUserID(id uuid.UUID, userID uuid.UUID) (*entity.Subscription, error)
 	GetSubscriptionByOrderID(orderID string) (*entity.Subscription, error)
-	GetExpiredActiveSubscriptions() ([]entity.Subscription, error)
+	GetSubscriptionByIDForUpdate(id uuid.UUID) (*entity.Subscription, error)
+	GetExpiredActiveSubscriptions(before time.Time) ([]entity.Subscription, error)
+	GetSubscriptionsDueForRenewal(until time.Time, notBefore time.Time) ([]entity.Subscription, error)
+	GetSubscriptionsEndingOn(date time.Time) ([]entity.Subscription, error)
+	GetSubscriptionsDueToPause(today time.Time) ([]entity.Subscription, error)
+	GetSubscriptionsDueToResume(today time.Time) ([]entity.Subscription, error)
+	GetStalePendingSubscriptions(issuedBefore time.Time) ([]entity.Subscription, error)
 	CountNewInRange(start time.Time, end time.Time) (int64, error)
 	CalculateMRRInRange(start time.Time, end time.Time) (float64, error)
 	CountTotalActive() (int64, error)
@@ -33,6 +43,12 @@ func NewSubscriptionRepository(db *gorm.DB) SubscriptionRepositoryItf {
 	}
 }
 
+func (r *SubscriptionRepository) WithTx(tx *gorm.DB) SubscriptionRepositoryItf {
+	return &SubscriptionRepository{
+		db: tx,
+	}
+}
+
 func (r *SubscriptionRepository) CreateSubscription(subscription *entity.Subscription) error {
 	return r.db.Create(subscription).Error
 }
@@ -60,6 +76,10 @@ func (r *SubscriptionRepository) UpdateStatus(subscription *entity.Subscription,
 	})
 }
 
+func (r *SubscriptionRepository) UpdateSubscription(subscription *entity.Subscription) error {
+	return r.db.Save(subscription).Error
+}
+
 func (r *SubscriptionRepository) GetAllSubscriptionByUserID(userID uuid.UUID) ([]entity.Subscription, error) {
 	var subscriptions []entity.Subscription
 	err := r.db.Preload("MealPlan").Where("user_id = ?", userID).Order("created_at desc").Find(&subscriptions).Error
@@ -81,6 +101,21 @@ func (r *SubscriptionRepository) GetSubscriptionByID(id uuid.UUID) (*entity.Subs
 	return &subscription, nil
 }
 
+func (r *SubscriptionRepository) GetSubscriptionWithUserByID(id uuid.UUID) (*entity.Subscription, error) {
+	var subscription entity.Subscription
+	err := r.db.Preload("User").Preload("MealPlan").Where("id = ?", id).First(&subscription).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
 func (r *SubscriptionRepository) GetSubscriptionByIDAndUserID(id, userID uuid.UUID) (*entity.Subscription, error) {
 	var subscription entity.Subscription
 	err := r.db.Preload("MealPlan").Where("id = ? AND user_id = ?", id, userID).First(&subscription).Error
@@ -111,11 +146,69 @@ func (r *SubscriptionRepository) GetSubscriptionByOrderID(orderID string) (*enti
 	return &subscription, nil
 }
 
-func (r *SubscriptionRepository) GetExpiredActiveSubscriptions() ([]entity.Subscription, error) {
+// GetSubscriptionByIDForUpdate locks the subscription row until the
+// surrounding transaction ends. It must be called on a repository from WithTx.
+func (r *SubscriptionRepository) GetSubscriptionByIDForUpdate(id uuid.UUID) (*entity.Subscription, error) {
+	var subscription entity.Subscription
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&subscription).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (r *SubscriptionRepository) GetExpiredActiveSubscriptions(before time.Time) ([]entity.Subscription, error) {
 	var subscriptions []entity.Subscription
-	now := time.Now()
+	err := r.db.Where("status = ? AND end_date < ?", entity.StatusActive, before).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// GetSubscriptionsDueForRenewal returns auto-renewing subscriptions ending
+// between notBefore and until that have no open or paid renewal yet.
+func (r *SubscriptionRepository) GetSubscriptionsDueForRenewal(until time.Time, notBefore time.Time) ([]entity.Subscription, error) {
+	var subscriptions []entity.Subscription
+	err := r.db.Preload("User").Preload("MealPlan").
+		Where("status IN ? AND auto_renew = ? AND end_date <= ? AND end_date >= ?", []entity.SubscriptionStatus{entity.StatusActive, entity.StatusPaused}, true, until, notBefore).
+		Where("NOT EXISTS (SELECT 1 FROM billing_periods bp WHERE bp.subscription_id = subscriptions.id AND bp.start_date >= subscriptions.end_date AND bp.status IN ?)", []entity.BillingPeriodStatus{entity.BillingPending, entity.BillingPaid}).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
 
-	err := r.db.Where("status = ? AND end_date < ?", entity.StatusActive, now).Find(&subscriptions).Error
+func (r *SubscriptionRepository) GetSubscriptionsEndingOn(date time.Time) ([]entity.Subscription, error) {
+	var subscriptions []entity.Subscription
+	err := r.db.Preload("User").Preload("MealPlan").
+		Where("status IN ? AND auto_renew = ? AND end_date = ?", []entity.SubscriptionStatus{entity.StatusActive, entity.StatusPaused}, false, date).
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *SubscriptionRepository) GetSubscriptionsDueToPause(today time.Time) ([]entity.Subscription, error) {
+	var subscriptions []entity.Subscription
+	err := r.db.Where("status = ? AND pause_start_date <= ? AND pause_end_date >= ?", entity.StatusActive, today, today).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *SubscriptionRepository) GetSubscriptionsDueToResume(today time.Time) ([]entity.Subscription, error) {
+	var subscriptions []entity.Subscription
+	err := r.db.Where("status = ? AND pause_end_date < ?", entity.StatusPaused, today).Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// GetStalePendingSubscriptions returns pending subscriptions whose current
+// payment link was issued before issuedBefore. Subscriptions from before
+// billing periods existed count from when they were created.
+func (r *SubscriptionRepository) GetStalePendingSubscriptions(issuedBefore time.Time) ([]entity.Subscription, error) {
+	var subscriptions []entity.Subscription
+	err := r.db.
+		Where("status = ?", entity.StatusPending).
+		Where("COALESCE((SELECT bp.created_at FROM billing_periods bp WHERE bp.order_id = subscriptions.order_id), subscriptions.created_at) < ?", issuedBefore).
+		Find(&subscriptions).Error
 	return subscriptions, err
 }
 
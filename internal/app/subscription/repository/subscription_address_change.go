@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SubscriptionAddressChangeRepositoryItf interface {
+	WithTx(tx *gorm.DB) SubscriptionAddressChangeRepositoryItf
+	CreateSubscriptionAddressChange(change *entity.SubscriptionAddressChange) error
+	UpdateSubscriptionAddressChange(change *entity.SubscriptionAddressChange) error
+	GetSubscriptionAddressChangesBySubscriptionID(subscriptionID uuid.UUID) ([]entity.SubscriptionAddressChange, error)
+	GetPendingSubscriptionAddressChange(subscriptionID uuid.UUID) (*entity.SubscriptionAddressChange, error)
+	GetDueSubscriptionAddressChanges(today time.Time) ([]entity.SubscriptionAddressChange, error)
+}
+
+type SubscriptionAddressChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionAddressChangeRepository(db *gorm.DB) SubscriptionAddressChangeRepositoryItf {
+	return &SubscriptionAddressChangeRepository{
+		db: db,
+	}
+}
+
+func (r *SubscriptionAddressChangeRepository) WithTx(tx *gorm.DB) SubscriptionAddressChangeRepositoryItf {
+	return &SubscriptionAddressChangeRepository{
+		db: tx,
+	}
+}
+
+func (r *SubscriptionAddressChangeRepository) CreateSubscriptionAddressChange(change *entity.SubscriptionAddressChange) error {
+	return r.db.Create(change).Error
+}
+
+func (r *SubscriptionAddressChangeRepository) UpdateSubscriptionAddressChange(change *entity.SubscriptionAddressChange) error {
+	return r.db.Save(change).Error
+}
+
+func (r *SubscriptionAddressChangeRepository) GetSubscriptionAddressChangesBySubscriptionID(subscriptionID uuid.UUID) ([]entity.SubscriptionAddressChange, error) {
+	var changes []entity.SubscriptionAddressChange
+	err := r.db.Where("subscription_id = ?", subscriptionID).Order("created_at desc").Find(&changes).Error
+	return changes, err
+}
+
+func (r *SubscriptionAddressChangeRepository) GetPendingSubscriptionAddressChange(subscriptionID uuid.UUID) (*entity.SubscriptionAddressChange, error) {
+	var change entity.SubscriptionAddressChange
+	err := r.db.Where("subscription_id = ? AND status = ?", subscriptionID, entity.AddressChangePending).First(&change).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+func (r *SubscriptionAddressChangeRepository) GetDueSubscriptionAddressChanges(today time.Time) ([]entity.SubscriptionAddressChange, error) {
+	var changes []entity.SubscriptionAddressChange
+	err := r.db.Where("status = ? AND effective_date <= ?", entity.AddressChangePending, today).Find(&changes).Error
+	return changes, err
+}
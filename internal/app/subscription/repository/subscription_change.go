@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SubscriptionChangeRepositoryItf interface {
+	WithTx(tx *gorm.DB) SubscriptionChangeRepositoryItf
+	CreateSubscriptionChange(change *entity.SubscriptionChange) error
+	UpdateSubscriptionChange(change *entity.SubscriptionChange) error
+	GetSubscriptionChangeByOrderID(orderID string) (*entity.SubscriptionChange, error)
+	GetSubscriptionChangeByOrderIDForUpdate(orderID string) (*entity.SubscriptionChange, error)
+	GetSubscriptionChangesBySubscriptionID(subscriptionID uuid.UUID) ([]entity.SubscriptionChange, error)
+	GetPendingSubscriptionChange(subscriptionID uuid.UUID) (*entity.SubscriptionChange, error)
+	GetStalePendingSubscriptionChanges(createdBefore time.Time) ([]entity.SubscriptionChange, error)
+}
+
+type SubscriptionChangeRepository struct {
+	db *gorm.DB
+}
+
+func NewSubscriptionChangeRepository(db *gorm.DB) SubscriptionChangeRepositoryItf {
+	return &SubscriptionChangeRepository{
+		db: db,
+	}
+}
+
+func (r *SubscriptionChangeRepository) WithTx(tx *gorm.DB) SubscriptionChangeRepositoryItf {
+	return &SubscriptionChangeRepository{
+		db: tx,
+	}
+}
+
+func (r *SubscriptionChangeRepository) CreateSubscriptionChange(change *entity.SubscriptionChange) error {
+	return r.db.Create(change).Error
+}
+
+func (r *SubscriptionChangeRepository) UpdateSubscriptionChange(change *entity.SubscriptionChange) error {
+	return r.db.Save(change).Error
+}
+
+func (r *SubscriptionChangeRepository) GetSubscriptionChangeByOrderID(orderID string) (*entity.SubscriptionChange, error) {
+	var change entity.SubscriptionChange
+	err := r.db.Where("order_id = ?", orderID).First(&change).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+func (r *SubscriptionChangeRepository) GetSubscriptionChangeByOrderIDForUpdate(orderID string) (*entity.SubscriptionChange, error) {
+	var change entity.SubscriptionChange
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("order_id = ?", orderID).First(&change).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+func (r *SubscriptionChangeRepository) GetSubscriptionChangesBySubscriptionID(subscriptionID uuid.UUID) ([]entity.SubscriptionChange, error) {
+	var changes []entity.SubscriptionChange
+	err := r.db.Where("subscription_id = ?", subscriptionID).Order("created_at desc").Find(&changes).Error
+	return changes, err
+}
+
+func (r *SubscriptionChangeRepository) GetPendingSubscriptionChange(subscriptionID uuid.UUID) (*entity.SubscriptionChange, error) {
+	var change entity.SubscriptionChange
+	err := r.db.Where("subscription_id = ? AND status = ?", subscriptionID, entity.ChangePending).First(&change).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+// GetStalePendingSubscriptionChanges returns charged changes still waiting
+// for a payment whose link was issued before createdBefore.
+func (r *SubscriptionChangeRepository) GetStalePendingSubscriptionChanges(createdBefore time.Time) ([]entity.SubscriptionChange, error) {
+	var changes []entity.SubscriptionChange
+	err := r.db.
+		Where("status = ? AND order_id IS NOT NULL", entity.ChangePending).
+		Where("created_at < ?", createdBefore).
+		Find(&changes).Error
+	return changes, err
+}
@@ -0,0 +1,299 @@
+package usecase
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// savedAddress loads an entry of the customer's address book.
+func (uc *SubscriptionUsecase) savedAddress(userID uuid.UUID, addressID uuid.UUID) (*entity.UserAddress, *res.Err) {
+	address, err := uc.UserAddressRepository.GetUserAddressByIDAndUserID(addressID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetUserAddresses)
+	}
+
+	if address == nil {
+		return nil, res.ErrNotFound(res.UserAddressNotFound)
+	}
+
+	return address, nil
+}
+
+// useSavedAddress fills the delivery details of a new subscription from an
+// address book entry. Delivery notes given with the order win over the
+// entry's own.
+func useSavedAddress(req *dto.CreateSubscriptionRequest, address *entity.UserAddress) {
+	req.Name = address.RecipientName
+	req.PhoneNumber = address.PhoneNumber
+	req.DeliveryAddress = address.Address
+	req.City = address.City
+	req.PostalCode = address.PostalCode
+	req.Latitude = address.Latitude
+	req.Longitude = address.Longitude
+
+	if req.DeliveryNotes == nil {
+		req.DeliveryNotes = address.Notes
+	}
+}
+
+// ChangeDeliveryAddress schedules a running subscription to move to an
+// address from the customer's address book. The move can only start after
+// the next delivery cutoff, the new address must be covered by a zone that
+// serves the subscription's delivery days, and it replaces any move still
+// waiting. The delivery fee stays as it is until the plan is next changed.
+func (uc *SubscriptionUsecase) ChangeDeliveryAddress(userID uuid.UUID, subscriptionID uuid.UUID, req dto.ChangeDeliveryAddressRequest) (*dto.SubscriptionAddressChangeResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	if sub.Status != entity.StatusActive && sub.Status != entity.StatusPaused {
+		return nil, res.ErrConflict(res.SubscriptionNotModifiable)
+	}
+
+	effectiveDate, err := time.ParseInLocation("2006-01-02", req.EffectiveDate, time.Local)
+	if err != nil {
+		return nil, res.ErrBadRequest(res.InvalidEffectiveDate)
+	}
+
+	if sub.EndDate != nil && civilDate(effectiveDate).After(civilDate(*sub.EndDate)) {
+		return nil, res.ErrBadRequest(res.AddressChangeOutsidePeriod)
+	}
+
+	address, resErr := uc.savedAddress(userID, req.AddressID)
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	area, resErr := uc.resolveDeliveryArea(address.City, address.PostalCode, address.Latitude, address.Longitude, strings.Split(sub.DeliveryDays, ","))
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	change := &entity.SubscriptionAddressChange{
+		SubscriptionID:  sub.ID,
+		UserAddressID:   &address.ID,
+		Name:            address.RecipientName,
+		PhoneNumber:     address.PhoneNumber,
+		DeliveryAddress: address.Address,
+		City:            address.City,
+		PostalCode:      address.PostalCode,
+		DeliveryNotes:   address.Notes,
+		EffectiveDate:   effectiveDate,
+		Status:          entity.AddressChangePending,
+	}
+
+	if area.point != nil {
+		change.Latitude = &area.point.Latitude
+		change.Longitude = &area.point.Longitude
+	}
+
+	if area.zone != nil {
+		change.DeliveryZoneID = &area.zone.ID
+	}
+
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		locked, err := repos.subscriptions.GetSubscriptionByIDForUpdate(sub.ID)
+		if err != nil {
+			return err
+		}
+
+		if locked == nil {
+			resErr = res.ErrNotFound(res.SubscriptionNotFound)
+			return resErr
+		}
+
+		from, err := uc.nextDeliveryChangeDate(repos, locked)
+		if err != nil {
+			return err
+		}
+
+		if civilDate(effectiveDate).Before(civilDate(from)) {
+			resErr = res.ErrBadRequest(res.AddressChangeTooSoon)
+			return resErr
+		}
+
+		pending, err := repos.addressChanges.GetPendingSubscriptionAddressChange(locked.ID)
+		if err != nil {
+			return err
+		}
+
+		if pending != nil {
+			pending.Status = entity.AddressChangeCancelled
+			if err := repos.addressChanges.UpdateSubscriptionAddressChange(pending); err != nil {
+				return err
+			}
+		}
+
+		return repos.addressChanges.CreateSubscriptionAddressChange(change)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSaveAddressChange)
+	}
+
+	return toSubscriptionAddressChangeResponse(change), nil
+}
+
+// CancelAddressChange withdraws an address change that has not taken effect
+// yet.
+func (uc *SubscriptionUsecase) CancelAddressChange(userID uuid.UUID, subscriptionID uuid.UUID) *res.Err {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	var resErr *res.Err
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		if _, err := repos.subscriptions.GetSubscriptionByIDForUpdate(sub.ID); err != nil {
+			return err
+		}
+
+		pending, err := repos.addressChanges.GetPendingSubscriptionAddressChange(sub.ID)
+		if err != nil {
+			return err
+		}
+
+		if pending == nil {
+			resErr = res.ErrNotFound(res.NoAddressChangePending)
+			return resErr
+		}
+
+		pending.Status = entity.AddressChangeCancelled
+		return repos.addressChanges.UpdateSubscriptionAddressChange(pending)
+	})
+
+	if resErr != nil {
+		return resErr
+	}
+
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedSaveAddressChange)
+	}
+
+	return nil
+}
+
+func (uc *SubscriptionUsecase) GetAddressChanges(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.SubscriptionAddressChangeResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	changes, err := uc.SubscriptionAddressChangeRepository.GetSubscriptionAddressChangesBySubscriptionID(sub.ID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetAddressChanges)
+	}
+
+	result := make([]dto.SubscriptionAddressChangeResponse, 0, len(changes))
+	for i := range changes {
+		result = append(result, *toSubscriptionAddressChangeResponse(&changes[i]))
+	}
+
+	return result, nil
+}
+
+// ApplyDueAddressChanges moves subscriptions to the addresses scheduled to
+// take effect by today. Changes on subscriptions that have ended since are
+// dropped.
+func (uc *SubscriptionUsecase) ApplyDueAddressChanges() *res.Err {
+	changes, err := uc.SubscriptionAddressChangeRepository.GetDueSubscriptionAddressChanges(dateOnly(time.Now()))
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetAddressChanges)
+	}
+
+	for i := range changes {
+		if err := uc.applyAddressChange(changes[i].SubscriptionID, changes[i].ID); err != nil {
+			log.Printf("Failed to apply address change %s: %v", changes[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *SubscriptionUsecase) applyAddressChange(subscriptionID uuid.UUID, changeID uuid.UUID) error {
+	return uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		sub, err := repos.subscriptions.GetSubscriptionByIDForUpdate(subscriptionID)
+		if err != nil || sub == nil {
+			return err
+		}
+
+		// Re-read under the lock: the customer may have replaced or
+		// withdrawn the change in the meantime.
+		change, err := repos.addressChanges.GetPendingSubscriptionAddressChange(sub.ID)
+		if err != nil || change == nil || change.ID != changeID {
+			return err
+		}
+
+		if sub.Status != entity.StatusActive && sub.Status != entity.StatusPaused {
+			change.Status = entity.AddressChangeCancelled
+			return repos.addressChanges.UpdateSubscriptionAddressChange(change)
+		}
+
+		sub.Name = change.Name
+		sub.PhoneNumber = change.PhoneNumber
+		sub.DeliveryAddress = change.DeliveryAddress
+		sub.City = &change.City
+		sub.PostalCode = &change.PostalCode
+		sub.Latitude = change.Latitude
+		sub.Longitude = change.Longitude
+		sub.DeliveryNotes = change.DeliveryNotes
+		sub.DeliveryZoneID = change.DeliveryZoneID
+
+		if err := repos.subscriptions.UpdateSubscription(sub); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		change.Status = entity.AddressChangeApplied
+		change.AppliedAt = &now
+		return repos.addressChanges.UpdateSubscriptionAddressChange(change)
+	})
+}
+
+func toSubscriptionAddressChangeResponse(change *entity.SubscriptionAddressChange) *dto.SubscriptionAddressChangeResponse {
+	return &dto.SubscriptionAddressChangeResponse{
+		ID:              change.ID,
+		AddressID:       change.UserAddressID,
+		Name:            change.Name,
+		PhoneNumber:     change.PhoneNumber,
+		DeliveryAddress: change.DeliveryAddress,
+		City:            change.City,
+		PostalCode:      change.PostalCode,
+		DeliveryNotes:   change.DeliveryNotes,
+		DeliveryZoneID:  change.DeliveryZoneID,
+		EffectiveDate:   change.EffectiveDate,
+		Status:          string(change.Status),
+		AppliedAt:       change.AppliedAt,
+		CreatedAt:       derefTime(change.CreatedAt),
+	}
+}
@@ -0,0 +1,477 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UpdateSubscription changes the meal plan, meal types or delivery days of a
+// running subscription. The price difference for the days left is prorated
+// with the same formula used at checkout: downgrades are credited to the
+// subscription, upgrades spend that credit first and charge the rest through
+// the payment gateway. A charged change only takes effect once it is paid.
+func (uc *SubscriptionUsecase) UpdateSubscription(userID uuid.UUID, email string, subscriptionID uuid.UUID, req dto.UpdateSubscriptionRequest) (*dto.UpdateSubscriptionResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	if sub.Status != entity.StatusActive && sub.Status != entity.StatusPaused {
+		return nil, res.ErrConflict(res.SubscriptionNotModifiable)
+	}
+
+	pending, err := uc.SubscriptionChangeRepository.GetPendingSubscriptionChange(sub.ID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionChanges)
+	}
+
+	if pending != nil {
+		return nil, res.ErrConflict(res.SubscriptionChangePending)
+	}
+
+	mealPlanID := sub.MealPlanID
+	if req.MealPlanID != nil {
+		mealPlanID = *req.MealPlanID
+	}
+
+	mealTypes := strings.Split(sub.MealTypes, ",")
+	if len(req.MealTypes) > 0 {
+		mealTypes = req.MealTypes
+	}
+
+	deliveryDays := strings.Split(sub.DeliveryDays, ",")
+	if len(req.DeliveryDays) > 0 {
+		deliveryDays = req.DeliveryDays
+	}
+
+	newMealTypes := strings.Join(mealTypes, ",")
+	newDeliveryDays := strings.Join(deliveryDays, ",")
+	if mealPlanID == sub.MealPlanID && newMealTypes == sub.MealTypes && newDeliveryDays == sub.DeliveryDays {
+		return nil, res.ErrBadRequest(res.NoSubscriptionChanges)
+	}
+
+	mealPlan, err := uc.MealPlanRepository.GetMealPlanByID(mealPlanID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetMealPlanByID)
+	}
+
+	if mealPlan == nil {
+		return nil, res.ErrNotFound(res.MealPlanNotFound)
+	}
+
+	zone, err := uc.subscriptionZone(sub)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetDeliveryZoneByID)
+	}
+
+	if zone != nil {
+		if resErr := checkZoneDeliveryDays(zone, deliveryDays); resErr != nil {
+			return nil, resErr
+		}
+	}
+
+	quote := uc.quote(mealPlan, mealTypes, deliveryDays, sub.DeliveryAddress, zone)
+
+	var change *entity.SubscriptionChange
+	var chargeAmount pricing.Money
+	var itemDetails []dto.ChargeItem
+	var resErr *res.Err
+
+	// The subscription is read again under a lock, so a parallel change cannot
+	// spend the same credit and a webhook or renewal committing meanwhile is
+	// not overwritten. Credit is taken off the balance straight away so it
+	// cannot be spent twice while the charge is waiting; a failed charge gives
+	// it back.
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		locked, err := repos.subscriptions.GetSubscriptionByIDForUpdate(sub.ID)
+		if err != nil {
+			return err
+		}
+
+		if locked == nil {
+			resErr = res.ErrNotFound(res.SubscriptionNotFound)
+			return resErr
+		}
+
+		if locked.Status != entity.StatusActive && locked.Status != entity.StatusPaused {
+			resErr = res.ErrConflict(res.SubscriptionNotModifiable)
+			return resErr
+		}
+
+		pending, err := repos.changes.GetPendingSubscriptionChange(locked.ID)
+		if err != nil {
+			return err
+		}
+
+		if pending != nil {
+			resErr = res.ErrConflict(res.SubscriptionChangePending)
+			return resErr
+		}
+
+		// The quote was priced against what the subscription was; a change
+		// applied in the meantime makes it stale.
+		if locked.MealPlanID != sub.MealPlanID || locked.MealTypes != sub.MealTypes ||
+			locked.DeliveryDays != sub.DeliveryDays || locked.TotalPrice != sub.TotalPrice {
+			resErr = res.ErrConflict(res.SubscriptionModified)
+			return resErr
+		}
+
+		sub = locked
+		days := remainingDays(sub, dateOnly(time.Now()))
+		prorated := pricing.Prorate(quote.Total-pricing.FromFloat(sub.TotalPrice), days, int(billingPeriodLength.Hours()/24))
+
+		change = &entity.SubscriptionChange{
+			SubscriptionID:  sub.ID,
+			OldMealPlanID:   sub.MealPlanID,
+			NewMealPlanID:   mealPlanID,
+			OldMealTypes:    sub.MealTypes,
+			NewMealTypes:    newMealTypes,
+			OldDeliveryDays: sub.DeliveryDays,
+			NewDeliveryDays: newDeliveryDays,
+			OldTotalPrice:   sub.TotalPrice,
+			NewSubtotal:     quote.Subtotal.Float64(),
+			NewDeliveryFee:  quote.Fees.Float64(),
+			NewTaxAmount:    quote.Tax.Float64(),
+			NewTotalPrice:   quote.Total.Float64(),
+			RemainingDays:   days,
+			ProratedAmount:  prorated.Float64(),
+			Status:          entity.ChangePending,
+		}
+
+		if prorated > 0 {
+			creditApplied := pricing.Min(prorated, pricing.FromFloat(sub.CreditBalance))
+			chargeAmount = prorated - creditApplied
+			change.CreditApplied = creditApplied.Float64()
+			change.ChargeAmount = chargeAmount.Float64()
+		}
+
+		if chargeAmount > 0 {
+			orderID := "CHANGE-" + uuid.NewString()
+			change.OrderID = &orderID
+
+			itemDetails = []dto.ChargeItem{{
+				ID:    mealPlan.ID.String(),
+				Name:  fmt.Sprintf("Subscription change %s", mealPlan.Name),
+				Price: int64(chargeAmount),
+				Qty:   1,
+			}}
+			change.ItemDetails = encodeItemDetails(itemDetails)
+		}
+
+		if err := repos.changes.CreateSubscriptionChange(change); err != nil {
+			return err
+		}
+
+		sub.CreditBalance -= change.CreditApplied
+
+		if change.ChargeAmount > 0 {
+			return repos.subscriptions.UpdateSubscription(sub)
+		}
+
+		return uc.applySubscriptionChange(repos, sub, change)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSaveSubscriptionChange)
+	}
+
+	var paymentResponse *dto.PaymentResponse
+	if change.ChargeAmount > 0 {
+		paymentResponse, err = uc.paymentGateway.CreateCharge(&dto.ChargeRequest{
+			OrderID:        *change.OrderID,
+			Amount:         int64(chargeAmount),
+			SubscriptionID: sub.ID,
+			CustomerDetails: dto.ChargeCustomer{
+				Name:  sub.Name,
+				Email: email,
+				Phone: sub.PhoneNumber,
+			},
+			ItemDetails: itemDetails,
+		})
+		if err != nil {
+			if err := uc.db.Transaction(func(tx *gorm.DB) error {
+				repos := uc.withTx(tx)
+
+				locked, err := repos.subscriptions.GetSubscriptionByIDForUpdate(sub.ID)
+				if err != nil {
+					return err
+				}
+
+				if locked == nil {
+					return fmt.Errorf("subscription %s not found", sub.ID)
+				}
+
+				return cancelSubscriptionChange(repos, locked, change)
+			}); err != nil {
+				log.Printf("Failed to cancel subscription change %s: %v", change.ID, err)
+			}
+
+			return nil, res.ErrInternalServerError(res.FailedCreatePaymentTransaction)
+		}
+
+		change.PaymentURL = &paymentResponse.RedirectURL
+		if err := uc.SubscriptionChangeRepository.UpdateSubscriptionChange(change); err != nil {
+			return nil, res.ErrInternalServerError(res.FailedSaveSubscriptionChange)
+		}
+	}
+
+	subResp, resErr := uc.toSubscriptionResponse(sub)
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	return &dto.UpdateSubscriptionResponse{
+		Subscription: *subResp,
+		Change:       toSubscriptionChangeResponse(change),
+		Payment:      paymentResponse,
+	}, nil
+}
+
+func (uc *SubscriptionUsecase) GetSubscriptionChanges(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.SubscriptionChangeResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	changes, err := uc.SubscriptionChangeRepository.GetSubscriptionChangesBySubscriptionID(sub.ID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionChanges)
+	}
+
+	result := make([]dto.SubscriptionChangeResponse, 0, len(changes))
+	for i := range changes {
+		result = append(result, toSubscriptionChangeResponse(&changes[i]))
+	}
+
+	return result, nil
+}
+
+// applyChangePayment applies or cancels a charged change once the gateway
+// reports the outcome of its payment. A change paid after it was cancelled,
+// or after its subscription stopped running, is never applied, so a refund
+// of the whole payment is opened instead.
+func (uc *SubscriptionUsecase) applyChangePayment(repos *txRepositories, subscription *entity.Subscription, change *entity.SubscriptionChange, status *dto.TransactionStatus) *res.Err {
+	outcome, ok := billingPeriodStatus(status)
+	if !ok || outcome == entity.BillingPending {
+		return nil
+	}
+
+	running := subscription.Status == entity.StatusActive || subscription.Status == entity.StatusPaused
+	if outcome == entity.BillingPaid && (change.Status == entity.ChangeCancelled || (change.Status == entity.ChangePending && !running)) {
+		log.Printf("Refunding paid change %s for %s subscription %s", status.OrderID, subscription.Status, subscription.ID)
+		if change.Status == entity.ChangePending {
+			if err := cancelSubscriptionChange(repos, subscription, change); err != nil {
+				return res.ErrInternalServerError(res.FailedSaveSubscriptionChange)
+			}
+		}
+
+		if err := refundUnappliedChange(repos, subscription.ID, change); err != nil {
+			return res.ErrInternalServerError(res.FailedSaveRefund)
+		}
+
+		return nil
+	}
+
+	if change.Status != entity.ChangePending {
+		return nil
+	}
+
+	var err error
+	if outcome == entity.BillingPaid {
+		err = uc.applySubscriptionChange(repos, subscription, change)
+	} else {
+		err = cancelSubscriptionChange(repos, subscription, change)
+	}
+
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedSaveSubscriptionChange)
+	}
+
+	return nil
+}
+
+// applySubscriptionChange switches the subscription over to the new plan and
+// price from tomorrow's deliveries on. Unpaid renewals were priced on the old
+// plan, so they are withdrawn and the renewal job opens them again at the new
+// price.
+func (uc *SubscriptionUsecase) applySubscriptionChange(repos *txRepositories, subscription *entity.Subscription, change *entity.SubscriptionChange) error {
+	subscription.MealPlanID = change.NewMealPlanID
+	subscription.MealPlan = nil
+	subscription.MealTypes = change.NewMealTypes
+	subscription.DeliveryDays = change.NewDeliveryDays
+	subscription.Subtotal = change.NewSubtotal
+	subscription.DeliveryFee = change.NewDeliveryFee
+	subscription.TaxAmount = change.NewTaxAmount
+	subscription.TotalPrice = change.NewTotalPrice
+
+	if change.ProratedAmount < 0 {
+		subscription.CreditBalance -= change.ProratedAmount
+	}
+
+	now := time.Now()
+	change.Status = entity.ChangeApplied
+	change.AppliedAt = &now
+
+	if err := repos.subscriptions.UpdateSubscription(subscription); err != nil {
+		return err
+	}
+
+	if err := repos.changes.UpdateSubscriptionChange(change); err != nil {
+		return err
+	}
+
+	if err := cancelPendingBillingPeriods(repos, subscription); err != nil {
+		return err
+	}
+
+	from, err := uc.nextDeliveryChangeDate(repos, subscription)
+	if err != nil {
+		return err
+	}
+
+	return syncDeliveries(repos, subscription, from)
+}
+
+func cancelSubscriptionChange(repos *txRepositories, subscription *entity.Subscription, change *entity.SubscriptionChange) error {
+	change.Status = entity.ChangeCancelled
+	if err := repos.changes.UpdateSubscriptionChange(change); err != nil {
+		return err
+	}
+
+	if change.CreditApplied == 0 {
+		return nil
+	}
+
+	subscription.CreditBalance += change.CreditApplied
+	return repos.subscriptions.UpdateSubscription(subscription)
+}
+
+// CancelStaleSubscriptionChanges cancels charged changes whose payment link
+// expired without being paid, returning the credit they set aside. Until
+// then the subscription cannot be changed again, and an abandoned payment
+// page never sends a notification to release it.
+func (uc *SubscriptionUsecase) CancelStaleSubscriptionChanges() *res.Err {
+	createdBefore := time.Now().Add(-uc.conf.MidtransPaymentDuration - uc.conf.PendingPaymentMargin)
+
+	changes, err := uc.SubscriptionChangeRepository.GetStalePendingSubscriptionChanges(createdBefore)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetSubscriptionChanges)
+	}
+
+	for _, change := range changes {
+		if err := uc.cancelStaleSubscriptionChange(change.SubscriptionID, *change.OrderID); err != nil {
+			log.Printf("Failed to cancel pending subscription change %s: %v", *change.OrderID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *SubscriptionUsecase) cancelStaleSubscriptionChange(subscriptionID uuid.UUID, orderID string) error {
+	// Voiding the order first makes sure it cannot be paid once the change
+	// is cancelled.
+	if err := uc.paymentGateway.ExpireCharge(orderID); err != nil {
+		return err
+	}
+
+	return uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		sub, err := repos.subscriptions.GetSubscriptionByIDForUpdate(subscriptionID)
+		if err != nil {
+			return err
+		}
+
+		change, err := repos.changes.GetSubscriptionChangeByOrderIDForUpdate(orderID)
+		if err != nil {
+			return err
+		}
+
+		// The change was paid or cancelled meanwhile.
+		if sub == nil || change == nil || change.Status != entity.ChangePending {
+			return nil
+		}
+
+		return cancelSubscriptionChange(repos, sub, change)
+	})
+}
+
+// remainingDays counts the days of service left on a subscription. Pause
+// days still ahead were added onto the end date, so they are left out.
+func remainingDays(sub *entity.Subscription, today time.Time) int {
+	if sub.EndDate == nil {
+		return 0
+	}
+
+	days := daysBetween(today, *sub.EndDate)
+
+	if sub.PauseStartDate != nil && sub.PauseEndDate != nil {
+		from := *sub.PauseStartDate
+		if daysBetween(from, today) > 0 {
+			from = today
+		}
+
+		if paused := daysBetween(from, *sub.PauseEndDate); paused > 0 {
+			days -= paused
+		}
+	}
+
+	if days < 0 {
+		return 0
+	}
+
+	return days
+}
+
+// daysBetween counts calendar days from one date to another, ignoring the
+// time of day and location of either.
+func daysBetween(from time.Time, to time.Time) int {
+	f := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	t := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(t.Sub(f).Hours() / 24)
+}
+
+func toSubscriptionChangeResponse(change *entity.SubscriptionChange) dto.SubscriptionChangeResponse {
+	return dto.SubscriptionChangeResponse{
+		ID:              change.ID,
+		OldMealPlanID:   change.OldMealPlanID,
+		NewMealPlanID:   change.NewMealPlanID,
+		OldMealTypes:    strings.Split(change.OldMealTypes, ","),
+		NewMealTypes:    strings.Split(change.NewMealTypes, ","),
+		OldDeliveryDays: strings.Split(change.OldDeliveryDays, ","),
+		NewDeliveryDays: strings.Split(change.NewDeliveryDays, ","),
+		OldTotalPrice:   change.OldTotalPrice,
+		NewTotalPrice:   change.NewTotalPrice,
+		RemainingDays:   change.RemainingDays,
+		ProratedAmount:  change.ProratedAmount,
+		CreditApplied:   change.CreditApplied,
+		ChargeAmount:    change.ChargeAmount,
+		PaymentURL:      change.PaymentURL,
+		Status:          string(change.Status),
+		AppliedAt:       change.AppliedAt,
+		CreatedAt:       derefTime(change.CreatedAt),
+	}
+}
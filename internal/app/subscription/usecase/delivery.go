@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// nextDeliveryChangeDate is the first date a customer's own change can
+// touch. A day's meals are locked once the cutoff of the subscription's
+// delivery zone has passed.
+func (uc *SubscriptionUsecase) nextDeliveryChangeDate(repos *txRepositories, sub *entity.Subscription) (time.Time, error) {
+	cutoff, err := uc.deliveryCutoff(repos, sub.DeliveryZoneID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return dateOnly(time.Now().Add(cutoff)).AddDate(0, 0, 1), nil
+}
+
+// syncDeliveries regenerates the scheduled deliveries of a subscription from
+// the given date onward. Deliveries already made are kept as they are, and so
+// are skips on meals still owed. A skip on a meal that is no longer owed is
+// dropped and its credit taken back, since the customer is not paying for
+// that meal any more.
+func syncDeliveries(repos *txRepositories, sub *entity.Subscription, from time.Time) error {
+	planned := planDeliveries(sub, from)
+
+	owed := make(map[string]bool, len(planned))
+	for _, d := range planned {
+		owed[deliverySlot(d)] = true
+	}
+
+	skipped, err := repos.deliveries.GetSkippedDeliveriesFrom(sub.ID, from)
+	if err != nil {
+		return err
+	}
+
+	var stale []uuid.UUID
+	var reclaimed float64
+	for _, d := range skipped {
+		if owed[deliverySlot(d)] {
+			continue
+		}
+
+		stale = append(stale, d.ID)
+
+		walletCredit, err := reclaimSkipCredit(repos, sub.UserID, d)
+		if err != nil {
+			return err
+		}
+
+		if !walletCredit {
+			reclaimed += d.CreditAmount
+		}
+	}
+
+	if err := repos.deliveries.DeleteScheduledDeliveriesFrom(sub.ID, from); err != nil {
+		return err
+	}
+
+	if err := repos.deliveries.DeleteDeliveries(stale); err != nil {
+		return err
+	}
+
+	if reclaimed > 0 {
+		sub.CreditBalance = math.Max(sub.CreditBalance-reclaimed, 0)
+		if err := repos.subscriptions.UpdateSubscription(sub); err != nil {
+			return err
+		}
+	}
+
+	return repos.deliveries.CreateDeliveries(planned)
+}
+
+// reclaimSkipCredit takes the credit a skip added to the wallet back, as far
+// as the balance allows. It reports false for skips from before wallets
+// existed, whose credit went to the subscription.
+func reclaimSkipCredit(repos *txRepositories, userID uuid.UUID, d entity.Delivery) (bool, error) {
+	reference := d.ID.String()
+	credited, err := repos.wallets.GetTransactionByReference(userID, entity.WalletSkipCredit, reference)
+	if err != nil || credited == nil {
+		return false, err
+	}
+
+	wallet, err := repos.wallets.GetWalletByUserIDForUpdate(userID)
+	if err != nil {
+		return true, err
+	}
+
+	amount := math.Min(d.CreditAmount, wallet.Balance)
+	if amount <= 0 {
+		return true, nil
+	}
+
+	_, err = repos.wallets.Debit(&entity.WalletTransaction{
+		UserID:    userID,
+		Amount:    amount,
+		Reason:    entity.WalletSkipReversal,
+		Reference: &reference,
+	})
+	return true, err
+}
+
+func deliverySlot(d entity.Delivery) string {
+	return civilDate(d.DeliveryDate).Format("2006-01-02") + "/" + d.MealType
+}
+
+// planDeliveries lists every meal owed from the given date until the end of
+// the subscription: one per meal type on each delivery day, skipping the
+// pause window. Only active and paused subscriptions are owed anything.
+func planDeliveries(sub *entity.Subscription, from time.Time) []entity.Delivery {
+	if sub.Status != entity.StatusActive && sub.Status != entity.StatusPaused {
+		return nil
+	}
+
+	if sub.EndDate == nil {
+		return nil
+	}
+
+	days := make(map[time.Weekday]bool)
+	for _, day := range strings.Split(sub.DeliveryDays, ",") {
+		if weekday, ok := weekdays[strings.ToLower(strings.TrimSpace(day))]; ok {
+			days[weekday] = true
+		}
+	}
+
+	var mealTypes []string
+	for _, mealType := range strings.Split(sub.MealTypes, ",") {
+		if mealType = strings.ToLower(strings.TrimSpace(mealType)); mealType != "" {
+			mealTypes = append(mealTypes, mealType)
+		}
+	}
+
+	start := civilDate(sub.StartDate)
+	if f := civilDate(from); f.After(start) {
+		start = f
+	}
+
+	end := civilDate(*sub.EndDate)
+
+	var deliveries []entity.Delivery
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		if !days[date.Weekday()] || isPaused(sub, date) {
+			continue
+		}
+
+		for _, mealType := range mealTypes {
+			deliveries = append(deliveries, entity.Delivery{
+				SubscriptionID: sub.ID,
+				DeliveryDate:   date,
+				MealType:       mealType,
+				Status:         entity.DeliveryScheduled,
+			})
+		}
+	}
+
+	return deliveries
+}
+
+func isPaused(sub *entity.Subscription, date time.Time) bool {
+	if sub.PauseStartDate == nil || sub.PauseEndDate == nil {
+		return false
+	}
+
+	return !date.Before(civilDate(*sub.PauseStartDate)) && !date.After(civilDate(*sub.PauseEndDate))
+}
+
+// civilDate drops the time of day and location so dates read from date
+// columns compare cleanly with dates computed locally.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
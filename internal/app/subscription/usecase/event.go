@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"log"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/infra/event"
+	"github.com/google/uuid"
+)
+
+// publish tells the user's open event streams that something changed. It is
+// called once the change is committed, so a client refetching on the event
+// reads the new state. Events are a convenience on top of the API, so
+// failing to publish one never fails the change itself.
+func (uc *SubscriptionUsecase) publish(userID uuid.UUID, eventType event.Type, data interface{}) {
+	if err := uc.event.Publish(userID, eventType, data); err != nil {
+		log.Printf("Failed to publish %s event for user %s: %v", eventType, userID, err)
+	}
+}
+
+// publishStatusChange publishes a subscription's status when it moved away
+// from oldStatus.
+func (uc *SubscriptionUsecase) publishStatusChange(sub *entity.Subscription, oldStatus entity.SubscriptionStatus) {
+	if sub.Status == oldStatus {
+		return
+	}
+
+	uc.publish(sub.UserID, event.SubscriptionStatusChanged, dto.SubscriptionStatusEvent{
+		SubscriptionID: sub.ID,
+		OldStatus:      string(oldStatus),
+		Status:         string(sub.Status),
+	})
+}
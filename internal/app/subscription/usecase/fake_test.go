@@ -0,0 +1,407 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+	deliveryRepository "github.com/Ablebil/sea-catering-be/internal/app/delivery/repository"
+	deliveryZoneRepository "github.com/Ablebil/sea-catering-be/internal/app/delivery_zone/repository"
+	giftRepository "github.com/Ablebil/sea-catering-be/internal/app/gift/repository"
+	paymentRepository "github.com/Ablebil/sea-catering-be/internal/app/payment/repository"
+	promoRepository "github.com/Ablebil/sea-catering-be/internal/app/promo/repository"
+	refundRepository "github.com/Ablebil/sea-catering-be/internal/app/refund/repository"
+	subscriptionRepository "github.com/Ablebil/sea-catering-be/internal/app/subscription/repository"
+	walletRepository "github.com/Ablebil/sea-catering-be/internal/app/wallet/repository"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/infra/event"
+	"github.com/Ablebil/sea-catering-be/internal/infra/payment"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var errNoDatabase = errors.New("tests have no database")
+
+// fakeConnPool lets the usecase open transactions without a database. Every
+// repository in these tests keeps its rows in memory, so no query ever
+// reaches it.
+type fakeConnPool struct{}
+
+func (fakeConnPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errNoDatabase
+}
+
+func (fakeConnPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errNoDatabase
+}
+
+func (fakeConnPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (fakeConnPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func (fakeConnPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return &fakeTx{}, nil
+}
+
+type fakeTx struct {
+	fakeConnPool
+}
+
+func (*fakeTx) Commit() error   { return nil }
+func (*fakeTx) Rollback() error { return nil }
+
+// store holds the rows of the in-memory repositories. Repositories hand out
+// copies, as a database would, so a test sees only what was saved.
+type store struct {
+	subscriptions map[uuid.UUID]entity.Subscription
+	periods       map[string]entity.BillingPeriod
+	changes       map[string]entity.SubscriptionChange
+	payments      map[string]entity.Payment
+	notifications map[entity.PaymentNotification]bool
+	refunds       []entity.Refund
+}
+
+// newTestUsecase builds a usecase on in-memory repositories. Repository
+// methods the tests do not need panic on the nil interface they embed.
+func newTestUsecase(t *testing.T, gateway payment.PaymentGatewayItf) (*SubscriptionUsecase, *store) {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: fakeConnPool{}}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &store{
+		subscriptions: make(map[uuid.UUID]entity.Subscription),
+		periods:       make(map[string]entity.BillingPeriod),
+		changes:       make(map[string]entity.SubscriptionChange),
+		payments:      make(map[string]entity.Payment),
+		notifications: make(map[entity.PaymentNotification]bool),
+	}
+
+	uc := &SubscriptionUsecase{
+		SubscriptionRepository:              memSubscriptions{s: s},
+		BillingPeriodRepository:             memBillingPeriods{s: s},
+		SubscriptionChangeRepository:        memChanges{s: s},
+		PaymentRepository:                   memPayments{s: s},
+		RefundRepository:                    memRefunds{s: s},
+		GiftRepository:                      noGifts{},
+		InvoiceRepository:                   issuedInvoices{},
+		DeliveryRepository:                  memDeliveries{},
+		PromoRepository:                     nopPromos{},
+		PromoRedemptionRepository:           nopRedemptions{},
+		WalletRepository:                    emptyWallets{},
+		DeliveryZoneRepository:              nopZones{},
+		SubscriptionAddressChangeRepository: nopAddressChanges{},
+		db:                                  db,
+		conf:                                &conf.Config{MidtransPaymentDuration: time.Hour},
+		paymentGateway:                      gateway,
+		event:                               nopEvents{},
+	}
+
+	return uc, s
+}
+
+type memSubscriptions struct {
+	subscriptionRepository.SubscriptionRepositoryItf
+	s *store
+}
+
+func (r memSubscriptions) WithTx(tx *gorm.DB) subscriptionRepository.SubscriptionRepositoryItf {
+	return r
+}
+
+func (r memSubscriptions) GetSubscriptionByID(id uuid.UUID) (*entity.Subscription, error) {
+	sub, ok := r.s.subscriptions[id]
+	if !ok {
+		return nil, nil
+	}
+
+	return &sub, nil
+}
+
+func (r memSubscriptions) GetSubscriptionByIDForUpdate(id uuid.UUID) (*entity.Subscription, error) {
+	return r.GetSubscriptionByID(id)
+}
+
+func (r memSubscriptions) UpdateSubscription(sub *entity.Subscription) error {
+	r.s.subscriptions[sub.ID] = *sub
+	return nil
+}
+
+func (r memSubscriptions) UpdateStatus(sub *entity.Subscription, status entity.SubscriptionStatus) error {
+	sub.Status = status
+	return r.UpdateSubscription(sub)
+}
+
+type memBillingPeriods struct {
+	subscriptionRepository.BillingPeriodRepositoryItf
+	s *store
+}
+
+func (r memBillingPeriods) WithTx(tx *gorm.DB) subscriptionRepository.BillingPeriodRepositoryItf {
+	return r
+}
+
+func (r memBillingPeriods) GetBillingPeriodByOrderID(orderID string) (*entity.BillingPeriod, error) {
+	period, ok := r.s.periods[orderID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &period, nil
+}
+
+func (r memBillingPeriods) GetBillingPeriodByOrderIDForUpdate(orderID string) (*entity.BillingPeriod, error) {
+	return r.GetBillingPeriodByOrderID(orderID)
+}
+
+func (r memBillingPeriods) GetBillingPeriodsBySubscriptionID(subscriptionID uuid.UUID) ([]entity.BillingPeriod, error) {
+	var periods []entity.BillingPeriod
+	for _, period := range r.s.periods {
+		if period.SubscriptionID == subscriptionID {
+			periods = append(periods, period)
+		}
+	}
+
+	sort.Slice(periods, func(i, j int) bool {
+		return periods[i].PeriodNumber < periods[j].PeriodNumber
+	})
+
+	return periods, nil
+}
+
+func (r memBillingPeriods) UpdateBillingPeriod(period *entity.BillingPeriod) error {
+	r.s.periods[period.OrderID] = *period
+	return nil
+}
+
+func (r memBillingPeriods) CancelPendingBillingPeriods(subscriptionID uuid.UUID) error {
+	for orderID, period := range r.s.periods {
+		if period.SubscriptionID == subscriptionID && period.Status == entity.BillingPending {
+			period.Status = entity.BillingCancelled
+			r.s.periods[orderID] = period
+		}
+	}
+
+	return nil
+}
+
+type memChanges struct {
+	subscriptionRepository.SubscriptionChangeRepositoryItf
+	s *store
+}
+
+func (r memChanges) WithTx(tx *gorm.DB) subscriptionRepository.SubscriptionChangeRepositoryItf {
+	return r
+}
+
+func (r memChanges) GetSubscriptionChangeByOrderID(orderID string) (*entity.SubscriptionChange, error) {
+	change, ok := r.s.changes[orderID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &change, nil
+}
+
+func (r memChanges) GetSubscriptionChangeByOrderIDForUpdate(orderID string) (*entity.SubscriptionChange, error) {
+	return r.GetSubscriptionChangeByOrderID(orderID)
+}
+
+func (r memChanges) GetSubscriptionChangesBySubscriptionID(subscriptionID uuid.UUID) ([]entity.SubscriptionChange, error) {
+	var changes []entity.SubscriptionChange
+	for _, change := range r.s.changes {
+		if change.SubscriptionID == subscriptionID {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes, nil
+}
+
+func (r memChanges) GetPendingSubscriptionChange(subscriptionID uuid.UUID) (*entity.SubscriptionChange, error) {
+	for _, change := range r.s.changes {
+		if change.SubscriptionID == subscriptionID && change.Status == entity.ChangePending {
+			return &change, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r memChanges) UpdateSubscriptionChange(change *entity.SubscriptionChange) error {
+	r.s.changes[*change.OrderID] = *change
+	return nil
+}
+
+type memPayments struct {
+	paymentRepository.PaymentRepositoryItf
+	s *store
+}
+
+func (r memPayments) WithTx(tx *gorm.DB) paymentRepository.PaymentRepositoryItf {
+	return r
+}
+
+func (r memPayments) CreateNotification(notification *entity.PaymentNotification) (bool, error) {
+	if r.s.notifications[*notification] {
+		return false, nil
+	}
+
+	r.s.notifications[*notification] = true
+	return true, nil
+}
+
+func (r memPayments) GetPaymentByOrderID(orderID string) (*entity.Payment, error) {
+	payment, ok := r.s.payments[orderID]
+	if !ok {
+		return nil, nil
+	}
+
+	return &payment, nil
+}
+
+func (r memPayments) SavePayment(payment *entity.Payment) error {
+	r.s.payments[payment.OrderID] = *payment
+	return nil
+}
+
+type memRefunds struct {
+	refundRepository.RefundRepositoryItf
+	s *store
+}
+
+func (r memRefunds) WithTx(tx *gorm.DB) refundRepository.RefundRepositoryItf {
+	return r
+}
+
+func (r memRefunds) CreateRefund(refund *entity.Refund) error {
+	r.s.refunds = append(r.s.refunds, *refund)
+	return nil
+}
+
+func (r memRefunds) GetRefundByOrderIDForUpdate(orderID string) (*entity.Refund, error) {
+	for _, refund := range r.s.refunds {
+		if refund.OrderID == orderID {
+			return &refund, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// noGifts has no gift subscriptions.
+type noGifts struct {
+	giftRepository.GiftRepositoryItf
+}
+
+func (r noGifts) WithTx(tx *gorm.DB) giftRepository.GiftRepositoryItf {
+	return r
+}
+
+func (noGifts) GetGiftBySubscriptionID(subscriptionID uuid.UUID) (*entity.Gift, error) {
+	return nil, nil
+}
+
+func (noGifts) GetGiftBySubscriptionIDForUpdate(subscriptionID uuid.UUID) (*entity.Gift, error) {
+	return nil, nil
+}
+
+// issuedInvoices reports every order as invoiced, so paid notifications do
+// not render and upload PDFs.
+type issuedInvoices struct {
+	paymentRepository.InvoiceRepositoryItf
+}
+
+func (issuedInvoices) GetInvoiceByOrderID(orderID string) (*entity.Invoice, error) {
+	fileURL := "https://example.com/" + orderID + ".pdf"
+	return &entity.Invoice{OrderID: orderID, FileURL: &fileURL}, nil
+}
+
+// memDeliveries accepts the regenerated schedule without keeping it.
+type memDeliveries struct {
+	deliveryRepository.DeliveryRepositoryItf
+}
+
+func (r memDeliveries) WithTx(tx *gorm.DB) deliveryRepository.DeliveryRepositoryItf {
+	return r
+}
+
+func (memDeliveries) GetSkippedDeliveriesFrom(subscriptionID uuid.UUID, from time.Time) ([]entity.Delivery, error) {
+	return nil, nil
+}
+
+func (memDeliveries) DeleteScheduledDeliveriesFrom(subscriptionID uuid.UUID, from time.Time) error {
+	return nil
+}
+
+func (memDeliveries) DeleteDeliveries(ids []uuid.UUID) error {
+	return nil
+}
+
+func (memDeliveries) CreateDeliveries(deliveries []entity.Delivery) error {
+	return nil
+}
+
+// emptyWallets has no wallet credit spent or to give back.
+type emptyWallets struct {
+	walletRepository.WalletRepositoryItf
+}
+
+func (r emptyWallets) WithTx(tx *gorm.DB) walletRepository.WalletRepositoryItf {
+	return r
+}
+
+func (emptyWallets) GetTransactionByReference(userID uuid.UUID, reason entity.WalletReason, reference string) (*entity.WalletTransaction, error) {
+	return nil, nil
+}
+
+type nopPromos struct {
+	promoRepository.PromoRepositoryItf
+}
+
+func (r nopPromos) WithTx(tx *gorm.DB) promoRepository.PromoRepositoryItf {
+	return r
+}
+
+type nopRedemptions struct {
+	promoRepository.PromoRedemptionRepositoryItf
+}
+
+func (r nopRedemptions) WithTx(tx *gorm.DB) promoRepository.PromoRedemptionRepositoryItf {
+	return r
+}
+
+type nopZones struct {
+	deliveryZoneRepository.DeliveryZoneRepositoryItf
+}
+
+func (r nopZones) WithTx(tx *gorm.DB) deliveryZoneRepository.DeliveryZoneRepositoryItf {
+	return r
+}
+
+type nopAddressChanges struct {
+	subscriptionRepository.SubscriptionAddressChangeRepositoryItf
+}
+
+func (r nopAddressChanges) WithTx(tx *gorm.DB) subscriptionRepository.SubscriptionAddressChangeRepositoryItf {
+	return r
+}
+
+type nopEvents struct {
+	event.EventItf
+}
+
+func (nopEvents) Publish(userID uuid.UUID, eventType event.Type, data interface{}) error {
+	return nil
+}
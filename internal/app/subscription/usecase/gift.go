@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// giftCodeAlphabet leaves out 0, 1, I and O, which are easily misread when a
+// code is typed in from an email.
+const giftCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// issueGiftCode gives a gift subscription its code once its first period is
+// paid. Subscriptions that are not gifts, and gifts that already have a code,
+// are left alone.
+func issueGiftCode(repos *txRepositories, subscriptionID uuid.UUID) error {
+	gift, err := repos.gifts.GetGiftBySubscriptionIDForUpdate(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if gift == nil || gift.Status != entity.GiftPending {
+		return nil
+	}
+
+	code, err := generateGiftCode()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	gift.Code = &code
+	gift.Status = entity.GiftIssued
+	gift.IssuedAt = &now
+	return repos.gifts.UpdateGift(gift)
+}
+
+// generateGiftCode returns a code like GIFT-7KQ2-M9XD-4HPA, 60 random bits in
+// three groups of four.
+func generateGiftCode() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("GIFT")
+	for i, b := range buf {
+		if i%4 == 0 {
+			sb.WriteByte('-')
+		}
+		sb.WriteByte(giftCodeAlphabet[int(b)%len(giftCodeAlphabet)])
+	}
+
+	return sb.String(), nil
+}
+
+// notifyGiftRecipient sends the gift code in the background once its payment
+// is committed; failing to email it must not undo the payment.
+func (uc *SubscriptionUsecase) notifyGiftRecipient(subscriptionID uuid.UUID) {
+	if err := uc.sendGiftCode(subscriptionID); err != nil {
+		log.Printf("Failed to send gift code for subscription %s: %v", subscriptionID, err)
+	}
+}
+
+// sendGiftCode emails an issued gift code to its recipient. The gift is
+// marked notified before sending, so a second paid notification for the same
+// order does not send it twice; the buyer can always find the code in their
+// purchase history.
+func (uc *SubscriptionUsecase) sendGiftCode(subscriptionID uuid.UUID) error {
+	gift, err := uc.GiftRepository.GetGiftBySubscriptionID(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if gift == nil || gift.Status != entity.GiftIssued || gift.Code == nil || gift.NotifiedAt != nil {
+		return nil
+	}
+
+	sub, err := uc.SubscriptionRepository.GetSubscriptionWithUserByID(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if sub == nil || sub.User == nil || sub.MealPlan == nil {
+		return fmt.Errorf("subscription %s, its user or its meal plan not found", subscriptionID)
+	}
+
+	marked, err := uc.GiftRepository.MarkGiftNotified(gift.ID)
+	if err != nil || !marked {
+		return err
+	}
+
+	return uc.email.SendGiftEmail(gift.RecipientEmail, sub.Name, sub.User.Name, sub.MealPlan.Name, *gift.Code, gift.Message)
+}
+
+// giftPayer returns the buyer of a gift for money paid before the gift was
+// claimed, or nil when the subscription's owner paid it.
+func giftPayer(gift *entity.Gift, paidAt *time.Time) *uuid.UUID {
+	if gift == nil || paidAt == nil {
+		return nil
+	}
+
+	if gift.ClaimedAt != nil && !paidAt.Before(*gift.ClaimedAt) {
+		return nil
+	}
+
+	return &gift.PurchaserID
+}
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/invoice"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	gojson "github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+const invoiceBucket = "media"
+
+// encodeItemDetails keeps the line items an order is charged with, so its
+// invoice can list them once the order is paid.
+func encodeItemDetails(items []dto.ChargeItem) *string {
+	raw, err := gojson.Marshal(items)
+	if err != nil {
+		return nil
+	}
+
+	itemDetails := string(raw)
+	return &itemDetails
+}
+
+// decodeItemDetails reads back the line items kept by encodeItemDetails. It
+// returns nil for orders created before line items were kept.
+func decodeItemDetails(itemDetails *string) []dto.ChargeItem {
+	if itemDetails == nil {
+		return nil
+	}
+
+	var items []dto.ChargeItem
+	if err := gojson.Unmarshal([]byte(*itemDetails), &items); err != nil {
+		return nil
+	}
+
+	return items
+}
+
+// issueInvoice stores the PDF invoice for a paid order and emails the
+// customer a link to it. Every order gets at most one invoice, so repeating
+// this is harmless; an invoice whose PDF could not be stored is finished the
+// next time a paid notification for the order comes in, replays included.
+func (uc *SubscriptionUsecase) issueInvoice(orderID string) error {
+	inv, err := uc.InvoiceRepository.GetInvoiceByOrderID(orderID)
+	if err != nil {
+		return err
+	}
+
+	if inv != nil && inv.FileURL != nil {
+		return nil
+	}
+
+	payment, err := uc.PaymentRepository.GetPaymentByOrderID(orderID)
+	if err != nil {
+		return err
+	}
+
+	if payment == nil {
+		return fmt.Errorf("no payment recorded for order %s", orderID)
+	}
+
+	// A replay can arrive after the payment was refunded.
+	if paymentOutcome(payment) != entity.BillingPaid {
+		return nil
+	}
+
+	sub, err := uc.SubscriptionRepository.GetSubscriptionWithUserByID(payment.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	if sub == nil || sub.User == nil {
+		return fmt.Errorf("subscription %s or its user not found", payment.SubscriptionID)
+	}
+
+	if inv == nil {
+		paidAt := time.Now()
+		if payment.TransactionTime != nil {
+			paidAt = *payment.TransactionTime
+		}
+
+		inv = &entity.Invoice{
+			SubscriptionID: sub.ID,
+			UserID:         sub.UserID,
+			OrderID:        orderID,
+			Amount:         payment.GrossAmount,
+			PaymentType:    payment.PaymentType,
+			PaidAt:         paidAt,
+		}
+
+		created, err := uc.InvoiceRepository.CreateInvoice(inv)
+		if err != nil {
+			return err
+		}
+
+		// Another notification for the same order got there first.
+		if !created {
+			return nil
+		}
+	}
+
+	subResp, resErr := uc.toSubscriptionResponse(sub)
+	if resErr != nil {
+		return resErr
+	}
+
+	details, err := uc.invoiceDetails(sub, orderID)
+	if err != nil {
+		return err
+	}
+
+	details.Number = fmt.Sprintf("INV-%s-%06d", inv.PaidAt.Format("200601"), inv.Sequence)
+	details.IssuedAt = time.Now()
+	details.PaidAt = inv.PaidAt
+	details.PaymentMethod = inv.PaymentType
+	details.Email = sub.User.Email
+	details.Subscription = *subResp
+	details.Total = int64(pricing.FromFloat(inv.Amount))
+
+	// The random directory keeps invoice links from being guessed from the
+	// sequential invoice number.
+	fileName := fmt.Sprintf("invoices/%s/%s.pdf", uuid.NewString(), details.Number)
+	fileURL, err := uc.supabase.UploadFile(bytes.NewReader(invoice.Render(*details)), invoiceBucket, fileName, "application/pdf")
+	if err != nil {
+		return err
+	}
+
+	inv.Number = details.Number
+	inv.FileURL = &fileURL
+	if err := uc.InvoiceRepository.UpdateInvoice(inv); err != nil {
+		return err
+	}
+
+	return uc.email.SendInvoiceEmail(sub.User.Email, inv.Number, inv.Amount, fileURL)
+}
+
+// invoiceDetails finds what an order paid for and the stretch of the
+// subscription it covers.
+func (uc *SubscriptionUsecase) invoiceDetails(sub *entity.Subscription, orderID string) (*invoice.Invoice, error) {
+	period, err := uc.BillingPeriodRepository.GetBillingPeriodByOrderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if period != nil {
+		return &invoice.Invoice{
+			PeriodStart: period.StartDate,
+			PeriodEnd:   period.EndDate,
+			Items:       invoiceItems(period.ItemDetails, fmt.Sprintf("Subscription %s", sub.MealPlan.Name), period.Amount),
+		}, nil
+	}
+
+	change, err := uc.SubscriptionChangeRepository.GetSubscriptionChangeByOrderID(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &invoice.Invoice{
+		PeriodStart: sub.StartDate,
+		PeriodEnd:   derefTime(sub.EndDate),
+		Items:       invoiceItems(nil, fmt.Sprintf("Subscription %s", sub.MealPlan.Name), sub.TotalPrice),
+	}
+
+	if change != nil {
+		details.PeriodStart = derefTime(change.CreatedAt)
+		details.Items = invoiceItems(change.ItemDetails, fmt.Sprintf("Subscription change %s", sub.MealPlan.Name), change.ChargeAmount)
+	}
+
+	return details, nil
+}
+
+// invoiceItems lists the line items an order was charged with. Orders
+// created before line items were kept are shown as a single line.
+func invoiceItems(itemDetails *string, name string, amount float64) []invoice.Item {
+	details := decodeItemDetails(itemDetails)
+	if len(details) == 0 {
+		return []invoice.Item{{Name: name, Amount: int64(pricing.FromFloat(amount))}}
+	}
+
+	items := make([]invoice.Item, 0, len(details))
+	for _, detail := range details {
+		items = append(items, invoice.Item{
+			Name:   detail.Name,
+			Amount: detail.Price * int64(detail.Qty),
+		})
+	}
+
+	return items
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"log"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaySubscription issues a fresh payment link for a subscription still
+// awaiting its first payment. The previous order is voided at the gateway first,
+// so only the new link can be paid, and the new order charges the same items.
+func (uc *SubscriptionUsecase) PaySubscription(userID uuid.UUID, email string, subscriptionID uuid.UUID) (*dto.PaymentResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	if sub.Status != entity.StatusPending {
+		return nil, res.ErrConflict(res.SubscriptionNotPending)
+	}
+
+	orderID := "SUBS-" + uuid.NewString()
+	now := time.Now()
+	end := now.Add(billingPeriodLength)
+
+	var period *entity.BillingPeriod
+	var resErr *res.Err
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		locked, err := repos.subscriptions.GetSubscriptionByIDForUpdate(sub.ID)
+		if err != nil {
+			return err
+		}
+
+		if locked == nil || locked.Status != entity.StatusPending {
+			resErr = res.ErrConflict(res.SubscriptionNotPending)
+			return resErr
+		}
+
+		sub = locked
+		period = &entity.BillingPeriod{
+			SubscriptionID: sub.ID,
+			PeriodNumber:   1,
+			StartDate:      now,
+			EndDate:        end,
+			Amount:         sub.TotalPrice,
+			OrderID:        orderID,
+			Status:         entity.BillingPending,
+		}
+
+		// The lock is held while the old order is voided, so a notification
+		// for it waits until the subscription points at the new one.
+		if sub.OrderID != nil {
+			if err := uc.paymentGateway.ExpireCharge(*sub.OrderID); err != nil {
+				resErr = res.ErrInternalServerError(res.FailedVoidPaymentTransaction)
+				return resErr
+			}
+
+			previous, err := repos.billingPeriods.GetBillingPeriodByOrderIDForUpdate(*sub.OrderID)
+			if err != nil {
+				return err
+			}
+
+			if previous != nil {
+				period.Amount = previous.Amount
+				period.WalletCreditApplied = previous.WalletCreditApplied
+				period.ItemDetails = previous.ItemDetails
+
+				previous.Status = entity.BillingCancelled
+				if err := repos.billingPeriods.UpdateBillingPeriod(previous); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := repos.billingPeriods.CreateBillingPeriod(period); err != nil {
+			return err
+		}
+
+		sub.OrderID = &orderID
+		sub.StartDate = now
+		sub.EndDate = &end
+		return repos.subscriptions.UpdateSubscription(sub)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSaveSubscription)
+	}
+
+	amount := int64(pricing.FromFloat(period.Amount))
+	itemDetails := decodeItemDetails(period.ItemDetails)
+	if len(itemDetails) == 0 {
+		itemDetails = []dto.ChargeItem{{
+			ID:    sub.MealPlanID.String(),
+			Name:  "Subscription",
+			Price: amount,
+			Qty:   1,
+		}}
+	}
+
+	paymentResponse, err := uc.paymentGateway.CreateCharge(&dto.ChargeRequest{
+		OrderID:        orderID,
+		Amount:         amount,
+		SubscriptionID: sub.ID,
+		CustomerDetails: dto.ChargeCustomer{
+			Name:  sub.Name,
+			Email: email,
+			Phone: sub.PhoneNumber,
+		},
+		ItemDetails: itemDetails,
+	})
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedCreatePaymentTransaction)
+	}
+
+	return paymentResponse, nil
+}
+
+// CancelStalePendingSubscriptions cancels subscriptions whose payment link
+// expired without being paid. The margin past the payment duration
+// leaves room for a payment made just before expiry to be notified.
+func (uc *SubscriptionUsecase) CancelStalePendingSubscriptions() *res.Err {
+	issuedBefore := time.Now().Add(-uc.conf.MidtransPaymentDuration - uc.conf.PendingPaymentMargin)
+
+	subs, err := uc.SubscriptionRepository.GetStalePendingSubscriptions(issuedBefore)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetPendingSubscriptions)
+	}
+
+	for _, sub := range subs {
+		if err := uc.cancelStalePendingSubscription(sub.ID, sub.OrderID); err != nil {
+			log.Printf("Failed to cancel pending subscription %s: %v", sub.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *SubscriptionUsecase) cancelStalePendingSubscription(subscriptionID uuid.UUID, orderID *string) error {
+	// Voiding the order first makes sure it cannot be paid once the
+	// subscription is cancelled.
+	if orderID != nil {
+		if err := uc.paymentGateway.ExpireCharge(*orderID); err != nil {
+			return err
+		}
+	}
+
+	var cancelled *entity.Subscription
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		sub, err := repos.subscriptions.GetSubscriptionByIDForUpdate(subscriptionID)
+		if err != nil {
+			return err
+		}
+
+		// The subscription was paid or given a new payment link meanwhile.
+		if sub == nil || sub.Status != entity.StatusPending || !sameOrderID(sub.OrderID, orderID) {
+			return nil
+		}
+
+		if err := checkTransition(sub.Status, entity.StatusCancelled, ActorScheduler); err != nil {
+			return nil
+		}
+
+		if err := repos.subscriptions.UpdateStatus(sub, entity.StatusCancelled); err != nil {
+			return err
+		}
+
+		if err := releasePromoRedemption(repos, sub.ID); err != nil {
+			return err
+		}
+
+		if err := releaseWalletCredit(repos, sub.UserID, sub.ID.String()); err != nil {
+			return err
+		}
+
+		cancelled = sub
+		return cancelPendingBillingPeriods(repos, sub)
+	})
+	if err != nil {
+		return err
+	}
+
+	if cancelled != nil {
+		uc.publishStatusChange(cancelled, entity.StatusPending)
+	}
+
+	return nil
+}
+
+func sameOrderID(a *string, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
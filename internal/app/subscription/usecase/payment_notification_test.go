@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/infra/payment"
+	"github.com/Ablebil/sea-catering-be/internal/infra/payment/midtranstest"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	gojson "github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const testServerKey = "SB-Mid-server-test"
+
+func newMidtransTest(t *testing.T, verifyStatus bool) (*midtranstest.Server, payment.PaymentGatewayItf) {
+	t.Helper()
+
+	server := midtranstest.NewServer(testServerKey)
+	t.Cleanup(server.Close)
+
+	gateway := payment.NewMidtrans(&conf.Config{
+		MidtransServerKey:       testServerKey,
+		MidtransVerifyStatus:    verifyStatus,
+		MidtransAPIURL:          server.URL,
+		MidtransPaymentDuration: time.Hour,
+	})
+
+	return server, gateway
+}
+
+// charge opens an order on the fake server and returns its order ID.
+func charge(t *testing.T, gateway payment.PaymentGatewayItf, amount int64) string {
+	t.Helper()
+
+	orderID := "SUBS-" + uuid.NewString()
+	if _, err := gateway.CreateCharge(&dto.ChargeRequest{OrderID: orderID, Amount: amount, SubscriptionID: uuid.New()}); err != nil {
+		t.Fatalf("create charge: %v", err)
+	}
+
+	return orderID
+}
+
+// pendingSubscription stores a subscription waiting for its first period,
+// paid through orderID.
+func pendingSubscription(store *store, orderID string, amount float64) *entity.Subscription {
+	start := civilDate(time.Now())
+	end := start.Add(billingPeriodLength)
+
+	sub := &entity.Subscription{
+		ID:           uuid.New(),
+		UserID:       uuid.New(),
+		MealTypes:    "lunch",
+		DeliveryDays: "monday,wednesday,friday",
+		TotalPrice:   amount,
+		Status:       entity.StatusPending,
+		OrderID:      &orderID,
+		StartDate:    start,
+		EndDate:      &end,
+	}
+	store.subscriptions[sub.ID] = *sub
+
+	store.periods[orderID] = entity.BillingPeriod{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		PeriodNumber:   1,
+		StartDate:      start,
+		EndDate:        end,
+		Amount:         amount,
+		OrderID:        orderID,
+		Status:         entity.BillingPending,
+	}
+
+	return sub
+}
+
+func encode(t *testing.T, notification *dto.MidtransNotification) []byte {
+	t.Helper()
+
+	body, err := gojson.Marshal(notification)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return body
+}
+
+func TestHandlePaymentNotificationRejectsBadSignature(t *testing.T) {
+	server, gateway := newMidtransTest(t, false)
+	orderID := charge(t, gateway, 180600)
+	server.SetStatus(orderID, "settlement", "bank_transfer")
+
+	notification := server.Notify(orderID)
+	notification.SignatureKey = payment.Signature(orderID, notification.StatusCode, notification.GrossAmount, "wrong-key")
+
+	uc := &SubscriptionUsecase{paymentGateway: gateway}
+
+	err := uc.HandlePaymentNotification(http.Header{}, encode(t, notification))
+	if err == nil || err.Code != fiber.StatusForbidden || err.Message != res.InvalidSignatureKey {
+		t.Fatalf("got %v, want 403 %q", err, res.InvalidSignatureKey)
+	}
+}
+
+func TestHandlePaymentNotificationRejectsGrossAmountMismatch(t *testing.T) {
+	server, gateway := newMidtransTest(t, false)
+	orderID := charge(t, gateway, 180600)
+	server.SetStatus(orderID, "settlement", "bank_transfer")
+
+	uc, store := newTestUsecase(t, gateway)
+	sub := pendingSubscription(store, orderID, 180600)
+
+	period := store.periods[orderID]
+	period.Amount = 150000
+	store.periods[orderID] = period
+	store.subscriptions[sub.ID] = *sub
+
+	err := uc.HandlePaymentNotification(http.Header{}, encode(t, server.Notify(orderID)))
+	if err == nil || err.Code != fiber.StatusBadRequest || err.Message != res.GrossAmountMismatch {
+		t.Fatalf("got %v, want 400 %q", err, res.GrossAmountMismatch)
+	}
+}
+
+// A capture held for fraud review leaves the subscription pending. Accepting
+// it keeps the order, transaction and transaction status; only the fraud
+// status changes, and that must still activate the subscription.
+func TestChallengedCaptureActivatesOnAccept(t *testing.T) {
+	server, gateway := newMidtransTest(t, false)
+	orderID := charge(t, gateway, 180600)
+	server.SetStatus(orderID, "capture", "credit_card")
+	server.SetFraudStatus(orderID, "challenge")
+
+	uc, store := newTestUsecase(t, gateway)
+	sub := pendingSubscription(store, orderID, 180600)
+
+	if err := uc.HandlePaymentNotification(http.Header{}, encode(t, server.Notify(orderID))); err != nil {
+		t.Fatalf("challenged capture: %v", err)
+	}
+
+	if got := store.subscriptions[sub.ID].Status; got != entity.StatusPending {
+		t.Fatalf("challenged capture: subscription status %q, want %q", got, entity.StatusPending)
+	}
+
+	if got := store.periods[orderID].Status; got != entity.BillingPending {
+		t.Fatalf("challenged capture: billing period status %q, want %q", got, entity.BillingPending)
+	}
+
+	server.SetFraudStatus(orderID, "accept")
+
+	if err := uc.HandlePaymentNotification(http.Header{}, encode(t, server.Notify(orderID))); err != nil {
+		t.Fatalf("accepted capture: %v", err)
+	}
+
+	if got := store.subscriptions[sub.ID].Status; got != entity.StatusActive {
+		t.Errorf("accepted capture: subscription status %q, want %q", got, entity.StatusActive)
+	}
+
+	if got := store.periods[orderID].Status; got != entity.BillingPaid {
+		t.Errorf("accepted capture: billing period status %q, want %q", got, entity.BillingPaid)
+	}
+}
+
+func TestVerifyStatusOverridesNotification(t *testing.T) {
+	tests := []struct {
+		name         string
+		verifyStatus bool
+		want         string
+	}{
+		{name: "notification trusted", verifyStatus: false, want: "settlement"},
+		{name: "status api wins", verifyStatus: true, want: "expire"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, gateway := newMidtransTest(t, tt.verifyStatus)
+			orderID := charge(t, gateway, 180600)
+
+			// The notification claims settlement, but by the time it is
+			// checked the Status API reports the order expired.
+			server.SetStatus(orderID, "settlement", "bank_transfer")
+			body := encode(t, server.Notify(orderID))
+			server.SetStatus(orderID, "expire", "bank_transfer")
+
+			status, err := gateway.ParseWebhook(http.Header{}, body)
+			if err != nil {
+				t.Fatalf("parse webhook: %v", err)
+			}
+
+			if status.TransactionStatus != tt.want {
+				t.Fatalf("transaction status %q, want %q", status.TransactionStatus, tt.want)
+			}
+
+			if status.Raw != string(body) {
+				t.Errorf("raw notification not kept")
+			}
+		})
+	}
+}
+
+// A plan change paid after its subscription was cancelled is never applied,
+// so the whole payment is refunded.
+func TestChangePaidAfterCancelIsRefunded(t *testing.T) {
+	server, gateway := newMidtransTest(t, false)
+	orderID := charge(t, gateway, 45000)
+	server.SetStatus(orderID, "settlement", "bank_transfer")
+
+	uc, store := newTestUsecase(t, gateway)
+	sub := pendingSubscription(store, "SUBS-"+uuid.NewString(), 180600)
+	sub.Status = entity.StatusCancelled
+	sub.CreditBalance = 5000
+	store.subscriptions[sub.ID] = *sub
+
+	store.changes[orderID] = entity.SubscriptionChange{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		OrderID:        &orderID,
+		ChargeAmount:   45000,
+		CreditApplied:  10000,
+		RemainingDays:  14,
+		Status:         entity.ChangePending,
+	}
+
+	if err := uc.HandlePaymentNotification(http.Header{}, encode(t, server.Notify(orderID))); err != nil {
+		t.Fatalf("settlement: %v", err)
+	}
+
+	if got := store.changes[orderID].Status; got != entity.ChangeCancelled {
+		t.Errorf("change status %q, want %q", got, entity.ChangeCancelled)
+	}
+
+	if got := store.subscriptions[sub.ID].CreditBalance; got != 15000 {
+		t.Errorf("credit balance %v, want 15000", got)
+	}
+
+	if len(store.refunds) != 1 {
+		t.Fatalf("got %d refunds, want 1", len(store.refunds))
+	}
+
+	if refund := store.refunds[0]; refund.OrderID != orderID || refund.Amount != 45000 || refund.Fee != 0 {
+		t.Errorf("refund of %s for %v less %v, want %s for 45000 less 0", refund.OrderID, refund.Amount, refund.Fee, orderID)
+	}
+}
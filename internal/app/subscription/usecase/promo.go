@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+)
+
+// redeemPromo locks the promo behind code and checks that the user may spend
+// it on this meal plan for an order of the given subtotal. The lock is held
+// until the checkout transaction ends, so redemption limits hold when several
+// checkouts use the same code at once.
+func redeemPromo(repos *txRepositories, code string, userID uuid.UUID, mealPlanID uuid.UUID, subtotal pricing.Money) (*entity.Promo, *res.Err) {
+	promo, err := repos.promos.GetPromoByCodeForUpdate(strings.ToUpper(code))
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetPromoByID)
+	}
+
+	today := civilDate(time.Now())
+	if promo == nil || !promo.IsActive || today.Before(civilDate(promo.ValidFrom)) || today.After(civilDate(promo.ValidUntil)) {
+		return nil, res.ErrBadRequest(res.PromoNotValid)
+	}
+
+	if promo.MaxRedemptions != nil && promo.RedemptionCount >= *promo.MaxRedemptions {
+		return nil, res.ErrConflict(res.PromoFullyRedeemed)
+	}
+
+	if promo.PerUserLimit != nil {
+		used, err := repos.redemptions.CountUserPromoRedemptions(promo.ID, userID)
+		if err != nil {
+			return nil, res.ErrInternalServerError(res.FailedGetPromoRedemptions)
+		}
+
+		if used >= int64(*promo.PerUserLimit) {
+			return nil, res.ErrConflict(res.PromoUserLimitReached)
+		}
+	}
+
+	if len(promo.MealPlans) > 0 {
+		eligible := false
+		for _, mealPlan := range promo.MealPlans {
+			if mealPlan.MealPlanID == mealPlanID {
+				eligible = true
+				break
+			}
+		}
+
+		if !eligible {
+			return nil, res.ErrBadRequest(res.PromoMealPlanExcluded)
+		}
+	}
+
+	if subtotal < pricing.FromFloat(promo.MinSpend) {
+		return nil, res.ErrBadRequest(res.PromoMinSpendNotMet)
+	}
+
+	return promo, nil
+}
+
+func promoDiscount(promo *entity.Promo) pricing.Discount {
+	discount := pricing.Discount{
+		ID:   "promo-" + promo.Code,
+		Name: "Promo " + promo.Code,
+	}
+
+	switch promo.DiscountType {
+	case entity.PromoPercentage:
+		discount.BasisPoints = int64(math.Round(promo.DiscountValue * 100))
+		if promo.MaxDiscount != nil {
+			discount.Max = pricing.FromFloat(*promo.MaxDiscount)
+		}
+	case entity.PromoFixed:
+		discount.Amount = pricing.FromFloat(promo.DiscountValue)
+	}
+
+	return discount
+}
+
+// releasePromoRedemption gives back the promo spent on a subscription that
+// was cancelled before its first payment, so the code can be used again.
+func releasePromoRedemption(repos *txRepositories, subscriptionID uuid.UUID) error {
+	redemption, err := repos.redemptions.GetRedeemedPromoBySubscriptionIDForUpdate(subscriptionID)
+	if err != nil || redemption == nil {
+		return err
+	}
+
+	redemption.Status = entity.RedemptionReleased
+	if err := repos.redemptions.UpdatePromoRedemption(redemption); err != nil {
+		return err
+	}
+
+	promo, err := repos.promos.GetPromoByIDForUpdate(redemption.PromoID)
+	if err != nil || promo == nil || promo.RedemptionCount == 0 {
+		return err
+	}
+
+	promo.RedemptionCount--
+	return repos.promos.UpdatePromo(promo)
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+)
+
+// QuoteSubscription prices a subscription without creating it, using the
+// same pricing as checkout.
+func (uc *SubscriptionUsecase) QuoteSubscription(req dto.QuoteSubscriptionRequest) (*dto.QuoteResponse, *res.Err) {
+	mealPlan, err := uc.MealPlanRepository.GetMealPlanByID(req.MealPlanID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetMealPlanByID)
+	}
+
+	if mealPlan == nil {
+		return nil, res.ErrNotFound(res.MealPlanNotFound)
+	}
+
+	// Without a postal code or location there is no area to check yet, so
+	// the quote falls back to the configured zone fees.
+	var zone *entity.DeliveryZone
+	if req.PostalCode != "" || req.Latitude != nil {
+		area, resErr := uc.resolveDeliveryArea(req.City, req.PostalCode, req.Latitude, req.Longitude, req.DeliveryDays)
+		if resErr != nil {
+			return nil, resErr
+		}
+
+		zone = area.zone
+	}
+
+	quote := uc.quote(mealPlan, req.MealTypes, req.DeliveryDays, req.DeliveryAddress, zone)
+	return toQuoteResponse(mealPlan, quote), nil
+}
+
+func (uc *SubscriptionUsecase) quote(mealPlan *entity.MealPlan, mealTypes []string, deliveryDays []string, deliveryAddress string, zone *entity.DeliveryZone, discounts ...pricing.Rule) *pricing.Quote {
+	return uc.pricing.Quote(pricing.Order{
+		MealPlanPrice:   pricing.FromFloat(mealPlan.Price),
+		MealTypes:       mealTypes,
+		DeliveryDays:    deliveryDays,
+		DeliveryAddress: deliveryAddress,
+		DeliveryZone:    pricingZone(zone),
+	}, discounts...)
+}
+
+// quoteItemDetails turns a quote into charge line items. They add up to
+// the quote total, which gateways require of the gross amount.
+func quoteItemDetails(quote *pricing.Quote) []dto.ChargeItem {
+	items := make([]dto.ChargeItem, 0, len(quote.Items))
+	for _, item := range quote.Items {
+		items = append(items, dto.ChargeItem{
+			ID:    item.ID,
+			Name:  item.Name,
+			Price: int64(item.Amount),
+			Qty:   1,
+		})
+	}
+
+	return items
+}
+
+func toQuoteResponse(mealPlan *entity.MealPlan, quote *pricing.Quote) *dto.QuoteResponse {
+	items := make([]dto.QuoteItemResponse, 0, len(quote.Items))
+	for _, item := range quote.Items {
+		items = append(items, dto.QuoteItemResponse{
+			ID:     item.ID,
+			Kind:   string(item.Kind),
+			Name:   item.Name,
+			Amount: int64(item.Amount),
+		})
+	}
+
+	return &dto.QuoteResponse{
+		MealPlan: dto.MealPlanResponse{
+			ID:          mealPlan.ID,
+			Name:        mealPlan.Name,
+			Description: mealPlan.Description,
+			Price:       mealPlan.Price,
+			PhotoURL:    mealPlan.PhotoURL,
+		},
+		BasePrice:   int64(quote.BasePrice),
+		PerMealType: int64(quote.PerMealType),
+		PerDay:      int64(quote.PerDay),
+		Items:       items,
+		Subtotal:    int64(quote.Subtotal),
+		Discount:    int64(quote.Discount),
+		Fees:        int64(quote.Fees),
+		Tax:         int64(quote.Tax),
+		Total:       int64(quote.Total),
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/infra/payment"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+)
+
+// ReconcilePayments compares every order still awaiting payment, and every
+// payment that changed within the lookback window, with what the gateway
+// reports. Orders the webhook missed are fixed through the same path a
+// webhook would take; anything that cannot be fixed safely is left for
+// finance in the report.
+func (uc *SubscriptionUsecase) ReconcilePayments() *res.Err {
+	report := &entity.ReconciliationReport{StartedAt: time.Now()}
+
+	orderIDs, err := uc.ReconciliationRepository.GetOrderIDsToReconcile(report.StartedAt.Add(-uc.conf.ReconciliationLookback))
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetReconciliationOrders)
+	}
+
+	for _, orderID := range orderIDs {
+		item, resErr := uc.reconcileOrder(orderID)
+		if resErr != nil {
+			return resErr
+		}
+
+		switch item.Outcome {
+		case entity.ReconciliationMatched:
+			report.Matched++
+			continue
+		case entity.ReconciliationFixed:
+			report.Fixed++
+		case entity.ReconciliationUnresolved:
+			report.Unresolved++
+		}
+
+		// Matched orders are only counted; the report lists what needed
+		// attention.
+		report.Items = append(report.Items, *item)
+	}
+
+	report.FinishedAt = time.Now()
+	if err := uc.ReconciliationRepository.CreateReport(report); err != nil {
+		return res.ErrInternalServerError(res.FailedSaveReconciliationReport)
+	}
+
+	if uc.conf.FinanceEmail != "" {
+		details := make([]string, 0, len(report.Items))
+		for _, item := range report.Items {
+			details = append(details, describeReconciliationItem(&item))
+		}
+
+		if err := uc.email.SendReconciliationReportEmail(uc.conf.FinanceEmail, report.StartedAt, report.Matched, report.Fixed, report.Unresolved, details); err != nil {
+			log.Printf("Failed to send reconciliation report %s: %v", report.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *SubscriptionUsecase) reconcileOrder(orderID string) (*entity.ReconciliationItem, *res.Err) {
+	existing, err := uc.PaymentRepository.GetPaymentByOrderID(orderID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetPayments)
+	}
+
+	item := &entity.ReconciliationItem{
+		OrderID:     orderID,
+		LocalStatus: "pending",
+	}
+
+	current := &dto.TransactionStatus{TransactionStatus: item.LocalStatus}
+	if existing != nil {
+		item.LocalStatus = existing.Status
+		current = recordedStatus(existing)
+	}
+
+	status, err := uc.paymentGateway.GetStatus(orderID)
+	switch {
+	case errors.Is(err, payment.ErrOrderNotFound) && item.LocalStatus == "pending":
+		// Nobody has tried to pay this order yet.
+		item.Outcome = entity.ReconciliationMatched
+		return item, nil
+	case errors.Is(err, payment.ErrOrderNotFound):
+		return unresolved(item, "Payment gateway has no record of this order"), nil
+	case err != nil:
+		return unresolved(item, "Failed to get status from payment gateway: "+err.Error()), nil
+	}
+
+	item.GatewayStatus = &status.TransactionStatus
+
+	if status.TransactionStatus == item.LocalStatus && !acceptsFraudReview(current, status) {
+		item.Outcome = entity.ReconciliationMatched
+		return item, nil
+	}
+
+	if !canApplyPaymentStatus(current, status) {
+		return unresolved(item, "Payment gateway reports a status the payment cannot move to"), nil
+	}
+
+	if resErr := uc.applyTransactionStatus(status); resErr != nil {
+		return unresolved(item, resErr.Message), nil
+	}
+
+	item.Outcome = entity.ReconciliationFixed
+	return item, nil
+}
+
+func unresolved(item *entity.ReconciliationItem, note string) *entity.ReconciliationItem {
+	item.Outcome = entity.ReconciliationUnresolved
+	item.Note = &note
+	return item
+}
+
+func describeReconciliationItem(item *entity.ReconciliationItem) string {
+	gatewayStatus := "unknown"
+	if item.GatewayStatus != nil {
+		gatewayStatus = *item.GatewayStatus
+	}
+
+	line := fmt.Sprintf("%s %s: local %s, gateway %s", item.Outcome, item.OrderID, item.LocalStatus, gatewayStatus)
+	if item.Note != nil {
+		line += " (" + *item.Note + ")"
+	}
+
+	return line
+}
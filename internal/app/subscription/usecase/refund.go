@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"log"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+)
+
+// requestRefunds opens a refund for every payment on a cancelled
+// subscription that still covers days from `from` on: billing periods and
+// paid plan changes alike. Each is refunded pro rata for the days that will
+// not be delivered, less the refund fee, and waits for an admin to approve
+// it. Payments with nothing left to refund after the fee are skipped. Money
+// paid by the buyer of a gift is refunded to them, not to its recipient.
+func (uc *SubscriptionUsecase) requestRefunds(repos *txRepositories, sub *entity.Subscription, from time.Time) error {
+	gift, err := repos.gifts.GetGiftBySubscriptionID(sub.ID)
+	if err != nil {
+		return err
+	}
+
+	periods, err := repos.billingPeriods.GetBillingPeriodsBySubscriptionID(sub.ID)
+	if err != nil {
+		return err
+	}
+
+	// A pause pushes the rest of the service back without moving the dates
+	// of the billing periods, so each period is served from where the one
+	// before it ran out.
+	var served time.Time
+	for _, period := range periods {
+		if period.Status != entity.BillingPaid {
+			continue
+		}
+
+		total := daysBetween(period.StartDate, period.EndDate)
+		start := laterDate(served, period.StartDate)
+		served = serviceEnd(sub, start, total)
+		undelivered := total - servedDays(sub, start, from)
+		payerID := giftPayer(gift, period.PaidAt)
+
+		userID := sub.UserID
+		if payerID != nil {
+			userID = *payerID
+		}
+
+		if err := returnWalletCredit(repos, userID, &period, undelivered, total); err != nil {
+			return err
+		}
+
+		if err := uc.requestRefund(repos, sub.ID, payerID, period.OrderID, undelivered, total); err != nil {
+			return err
+		}
+	}
+
+	changes, err := repos.changes.GetSubscriptionChangesBySubscriptionID(sub.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		if change.Status != entity.ChangeApplied || change.OrderID == nil || change.CreatedAt == nil {
+			continue
+		}
+
+		// A change is charged for the days left in the period it was made in.
+		start := dateOnly(*change.CreatedAt)
+		undelivered := change.RemainingDays - servedDays(sub, start, from)
+		if err := uc.requestRefund(repos, sub.ID, giftPayer(gift, change.CreatedAt), *change.OrderID, undelivered, change.RemainingDays); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (uc *SubscriptionUsecase) requestRefund(repos *txRepositories, subscriptionID uuid.UUID, payerID *uuid.UUID, orderID string, undelivered int, total int) error {
+	if total <= 0 || undelivered <= 0 {
+		return nil
+	}
+
+	undelivered = min(undelivered, total)
+
+	// Only money the gateway actually took can be refunded; periods covered by
+	// a promo or credit have no payment behind them, and wallet credit is
+	// returned by returnWalletCredit.
+	payment, err := repos.payments.GetPaymentByOrderID(orderID)
+	if err != nil {
+		return err
+	}
+
+	if payment == nil || (payment.Status != "settlement" && payment.Status != "capture") {
+		return nil
+	}
+
+	fee := pricing.FromFloat(uc.conf.RefundFee)
+	amount := pricing.Prorate(pricing.FromFloat(payment.GrossAmount), undelivered, total) - fee
+	if amount <= 0 {
+		return nil
+	}
+
+	return repos.refunds.CreateRefund(&entity.Refund{
+		SubscriptionID:  subscriptionID,
+		PayerID:         payerID,
+		OrderID:         orderID,
+		PaidAmount:      payment.GrossAmount,
+		UndeliveredDays: undelivered,
+		TotalDays:       total,
+		Fee:             fee.Float64(),
+		Amount:          amount.Float64(),
+		Status:          entity.RefundRequested,
+	})
+}
+
+// refundUnappliedChange opens a refund of the whole payment for a change
+// that was paid but never applied. Nothing was delivered on it, so no
+// refund fee is kept.
+func refundUnappliedChange(repos *txRepositories, subscriptionID uuid.UUID, change *entity.SubscriptionChange) error {
+	existing, err := repos.refunds.GetRefundByOrderIDForUpdate(*change.OrderID)
+	if err != nil || existing != nil {
+		return err
+	}
+
+	payment, err := repos.payments.GetPaymentByOrderID(*change.OrderID)
+	if err != nil || payment == nil {
+		return err
+	}
+
+	gift, err := repos.gifts.GetGiftBySubscriptionID(subscriptionID)
+	if err != nil {
+		return err
+	}
+
+	return repos.refunds.CreateRefund(&entity.Refund{
+		SubscriptionID:  subscriptionID,
+		PayerID:         giftPayer(gift, change.CreatedAt),
+		OrderID:         *change.OrderID,
+		PaidAmount:      payment.GrossAmount,
+		UndeliveredDays: change.RemainingDays,
+		TotalDays:       change.RemainingDays,
+		Amount:          payment.GrossAmount,
+		Status:          entity.RefundRequested,
+	})
+}
+
+// returnWalletCredit puts the wallet credit a period was paid with back
+// into the wallet, pro rata for the days that will not be delivered. Credit
+// goes back as credit without review, and without the refund fee.
+func returnWalletCredit(repos *txRepositories, userID uuid.UUID, period *entity.BillingPeriod, undelivered int, total int) error {
+	if period.WalletCreditApplied <= 0 || total <= 0 || undelivered <= 0 {
+		return nil
+	}
+
+	amount := pricing.Prorate(pricing.FromFloat(period.WalletCreditApplied), min(undelivered, total), total)
+	if amount <= 0 {
+		return nil
+	}
+
+	return repos.wallets.Credit(&entity.WalletTransaction{
+		UserID:    userID,
+		Amount:    amount.Float64(),
+		Reason:    entity.WalletRefund,
+		Reference: &period.OrderID,
+	})
+}
+
+// applyRefundNotification marks the refund of an order as paid out once
+// the gateway reports it. Refunds made from the gateway's dashboard have no
+// refund here and are only logged.
+func applyRefundNotification(repos *txRepositories, status *dto.TransactionStatus) error {
+	refund, err := repos.refunds.GetRefundByOrderIDForUpdate(status.OrderID)
+	if err != nil {
+		return err
+	}
+
+	if refund == nil {
+		log.Printf("Received %s for order %s without a refund request", status.TransactionStatus, status.OrderID)
+		return nil
+	}
+
+	if refund.Status == entity.RefundRefunded {
+		return nil
+	}
+
+	now := time.Now()
+	refund.Status = entity.RefundRefunded
+	refund.RefundedAt = &now
+	return repos.refunds.UpdateRefund(refund)
+}
+
+// servedDays counts the days of service from start until from, leaving out
+// the days the subscription was paused.
+func servedDays(sub *entity.Subscription, start time.Time, from time.Time) int {
+	return max(daysBetween(start, from)-pausedDays(sub, start, from), 0)
+}
+
+// pausedDays counts the days from one date to another that fall in the
+// subscription's pause.
+func pausedDays(sub *entity.Subscription, from time.Time, to time.Time) int {
+	if sub.PauseStartDate == nil || sub.PauseEndDate == nil {
+		return 0
+	}
+
+	start := laterDate(from, *sub.PauseStartDate)
+	end := *sub.PauseEndDate
+	if daysBetween(to, end) > 0 {
+		end = to
+	}
+
+	return max(daysBetween(start, end), 0)
+}
+
+// serviceEnd is the date total days of service starting at start run out,
+// moved back by a pause starting before then.
+func serviceEnd(sub *entity.Subscription, start time.Time, total int) time.Time {
+	end := start.AddDate(0, 0, total)
+	if sub.PauseStartDate == nil || sub.PauseEndDate == nil || daysBetween(*sub.PauseStartDate, end) <= 0 {
+		return end
+	}
+
+	return end.AddDate(0, 0, pausedDays(sub, start, *sub.PauseEndDate))
+}
+
+func laterDate(a time.Time, b time.Time) time.Time {
+	if daysBetween(a, b) > 0 {
+		return b
+	}
+
+	return a
+}
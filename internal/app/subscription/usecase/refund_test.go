@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+)
+
+// A pause moves the end of the service back but not the billing period's
+// dates, so the days paused must not count as delivered.
+func TestCancelAfterPauseRefundsPausedDays(t *testing.T) {
+	uc, store := newTestUsecase(t, nil)
+
+	today := civilDate(time.Now())
+	start := today.AddDate(0, 0, -20)
+	pauseStart := today.AddDate(0, 0, -10)
+	pauseEnd := today.AddDate(0, 0, -5)
+	end := start.AddDate(0, 0, 35)
+
+	orderID := "SUBS-" + uuid.NewString()
+	sub := &entity.Subscription{
+		ID:             uuid.New(),
+		UserID:         uuid.New(),
+		Status:         entity.StatusCancelled,
+		OrderID:        &orderID,
+		StartDate:      start,
+		EndDate:        &end,
+		PauseStartDate: &pauseStart,
+		PauseEndDate:   &pauseEnd,
+	}
+	store.subscriptions[sub.ID] = *sub
+
+	store.periods[orderID] = entity.BillingPeriod{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		PeriodNumber:   1,
+		StartDate:      start,
+		EndDate:        start.AddDate(0, 0, 30),
+		Amount:         300000,
+		OrderID:        orderID,
+		Status:         entity.BillingPaid,
+	}
+
+	store.payments[orderID] = entity.Payment{
+		SubscriptionID: sub.ID,
+		OrderID:        orderID,
+		Status:         "settlement",
+		GrossAmount:    300000,
+	}
+
+	// 20 days went by since the start, 5 of them paused.
+	if err := uc.requestRefunds(uc.withTx(uc.db), sub, today); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(store.refunds) != 1 {
+		t.Fatalf("got %d refunds, want 1", len(store.refunds))
+	}
+
+	refund := store.refunds[0]
+	if refund.UndeliveredDays != 15 || refund.TotalDays != 30 || refund.Amount != 150000 {
+		t.Errorf("refund for %d of %d days, %v; want 15 of 30 days, 150000", refund.UndeliveredDays, refund.TotalDays, refund.Amount)
+	}
+}
@@ -0,0 +1,365 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// billingPeriodLength is how long one paid billing period lasts.
+const billingPeriodLength = 30 * 24 * time.Hour
+
+// ProcessRenewals opens the next billing period for every auto-renewing
+// subscription ending within the renewal lead time, creates its payment
+// link and emails the payment link. Subscriptions already in their
+// grace period are retried until they are finished.
+func (uc *SubscriptionUsecase) ProcessRenewals() *res.Err {
+	today := dateOnly(time.Now())
+
+	subs, err := uc.SubscriptionRepository.GetSubscriptionsDueForRenewal(today.Add(uc.conf.RenewalLeadTime), today.Add(-uc.conf.RenewalGracePeriod))
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetRenewalSubscriptions)
+	}
+
+	for i := range subs {
+		if err := uc.renewSubscription(&subs[i]); err != nil {
+			log.Printf("Failed to renew subscription %s: %v", subs[i].ID, err)
+		}
+	}
+
+	return nil
+}
+
+// SendExpiryReminders warns users whose subscription ends within the renewal
+// lead time and will not renew on its own.
+func (uc *SubscriptionUsecase) SendExpiryReminders() *res.Err {
+	endDate := dateOnly(time.Now()).Add(uc.conf.RenewalLeadTime)
+
+	subs, err := uc.SubscriptionRepository.GetSubscriptionsEndingOn(endDate)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetRenewalSubscriptions)
+	}
+
+	for _, sub := range subs {
+		if sub.User == nil || sub.MealPlan == nil {
+			continue
+		}
+
+		if err := uc.email.SendExpiryReminderEmail(sub.User.Email, sub.MealPlan.Name, endDate); err != nil {
+			log.Printf("Failed to send expiry reminder for subscription %s: %v", sub.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (uc *SubscriptionUsecase) renewSubscription(sub *entity.Subscription) error {
+	if sub.User == nil || sub.MealPlan == nil || sub.EndDate == nil {
+		return fmt.Errorf("subscription is missing its user, meal plan or end date")
+	}
+
+	dueEndDate := *sub.EndDate
+
+	var period *entity.BillingPeriod
+	var amount pricing.Money
+	var itemDetails []dto.ChargeItem
+	var oldStatus entity.SubscriptionStatus
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		// The subscription was read without a lock, so whether it is still
+		// due is checked again: it may have been cancelled, changed or
+		// renewed since.
+		locked, err := repos.subscriptions.GetSubscriptionByIDForUpdate(sub.ID)
+		if err != nil {
+			return err
+		}
+
+		if locked == nil || !renewalDue(locked, dueEndDate) {
+			return nil
+		}
+
+		latest, err := repos.billingPeriods.GetLatestBillingPeriod(sub.ID)
+		if err != nil {
+			return err
+		}
+
+		if latest != nil && daysBetween(*locked.EndDate, latest.StartDate) >= 0 &&
+			(latest.Status == entity.BillingPending || latest.Status == entity.BillingPaid) {
+			return nil
+		}
+
+		locked.User = sub.User
+		locked.MealPlan = sub.MealPlan
+		if locked.MealPlanID != sub.MealPlan.ID {
+			if locked.MealPlan, err = uc.MealPlanRepository.GetMealPlanByID(locked.MealPlanID); err != nil {
+				return err
+			}
+
+			if locked.MealPlan == nil {
+				return fmt.Errorf("meal plan %s not found", locked.MealPlanID)
+			}
+		}
+
+		*sub = *locked
+		oldStatus = sub.Status
+
+		// Subscriptions created before billing periods existed have no record of
+		// their first period, so their first renewal is the second period.
+		periodNumber := 2
+		if latest != nil {
+			periodNumber = latest.PeriodNumber + 1
+		}
+
+		// Credit left over from downgrades is spent on the renewal. It is only
+		// taken off the balance once the renewal is paid.
+		total := pricing.FromFloat(sub.TotalPrice)
+		creditApplied := pricing.Min(pricing.FromFloat(sub.CreditBalance), total)
+
+		start := dateOnly(*sub.EndDate)
+		period = &entity.BillingPeriod{
+			SubscriptionID: sub.ID,
+			PeriodNumber:   periodNumber,
+			StartDate:      start,
+			EndDate:        start.Add(billingPeriodLength),
+			CreditApplied:  creditApplied.Float64(),
+			OrderID:        "RENEW-" + uuid.NewString(),
+			Status:         entity.BillingPending,
+		}
+
+		// Skip credit and refunds land in the wallet, which pays what the
+		// subscription's own credit leaves. Unlike that credit it is taken
+		// straight away, and given back should the renewal go unpaid.
+		walletCredit, err := spendWalletCredit(repos, sub.UserID, period.OrderID, total-creditApplied)
+		if err != nil {
+			return err
+		}
+
+		amount = total - creditApplied - walletCredit
+		period.Amount = amount.Float64()
+		period.WalletCreditApplied = walletCredit.Float64()
+
+		itemDetails = renewalItemDetails(sub, period)
+		period.ItemDetails = encodeItemDetails(itemDetails)
+
+		if amount > 0 {
+			return repos.billingPeriods.CreateBillingPeriod(period)
+		}
+
+		now := time.Now()
+		period.Status = entity.BillingPaid
+		period.PaidAt = &now
+
+		if err := repos.billingPeriods.CreateBillingPeriod(period); err != nil {
+			return err
+		}
+
+		if resErr := uc.applyRenewalPayment(repos, sub, period); resErr != nil {
+			return resErr
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if period == nil {
+		return nil
+	}
+
+	start := period.StartDate
+
+	if amount <= 0 {
+		uc.publishStatusChange(sub, oldStatus)
+		return nil
+	}
+
+	// The payment link stays valid until the grace period runs out.
+	expiry := time.Until(start.Add(uc.conf.RenewalGracePeriod))
+	if expiry < uc.conf.MidtransPaymentDuration {
+		expiry = uc.conf.MidtransPaymentDuration
+	}
+
+	paymentResponse, err := uc.paymentGateway.CreateCharge(&dto.ChargeRequest{
+		OrderID:        period.OrderID,
+		Amount:         int64(amount),
+		SubscriptionID: sub.ID,
+		ExpiryDuration: expiry,
+		CustomerDetails: dto.ChargeCustomer{
+			Name:  sub.Name,
+			Email: sub.User.Email,
+			Phone: sub.PhoneNumber,
+		},
+		ItemDetails: itemDetails,
+	})
+	if err != nil {
+		// A failed period does not block the next run from trying again.
+		if failErr := uc.db.Transaction(func(tx *gorm.DB) error {
+			repos := uc.withTx(tx)
+
+			period.Status = entity.BillingFailed
+			if err := repos.billingPeriods.UpdateBillingPeriod(period); err != nil {
+				return err
+			}
+
+			return releaseWalletCredit(repos, sub.UserID, period.OrderID)
+		}); failErr != nil {
+			log.Printf("Failed to mark billing period %s as failed: %v", period.ID, failErr)
+		}
+
+		return err
+	}
+
+	period.PaymentURL = &paymentResponse.RedirectURL
+	if err := uc.BillingPeriodRepository.UpdateBillingPeriod(period); err != nil {
+		return err
+	}
+
+	return uc.email.SendRenewalEmail(sub.User.Email, sub.MealPlan.Name, period.Amount, paymentResponse.RedirectURL, start)
+}
+
+// renewalDue reports whether a locked subscription is still the one the
+// renewal job picked up: running, renewing on its own and ending when it
+// did then.
+func renewalDue(sub *entity.Subscription, endDate time.Time) bool {
+	if sub.Status != entity.StatusActive && sub.Status != entity.StatusPaused {
+		return false
+	}
+
+	return sub.AutoRenew && sub.EndDate != nil && daysBetween(*sub.EndDate, endDate) == 0
+}
+
+// cancelPendingBillingPeriods withdraws the billing periods still waiting for
+// payment and gives back the wallet credit spent on them.
+func cancelPendingBillingPeriods(repos *txRepositories, sub *entity.Subscription) error {
+	periods, err := repos.billingPeriods.GetBillingPeriodsBySubscriptionID(sub.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, period := range periods {
+		if period.Status != entity.BillingPending || period.WalletCreditApplied <= 0 {
+			continue
+		}
+
+		if err := releaseWalletCredit(repos, sub.UserID, period.OrderID); err != nil {
+			return err
+		}
+	}
+
+	return repos.billingPeriods.CancelPendingBillingPeriods(sub.ID)
+}
+
+// renewalItemDetails itemizes a renewal from the price breakdown stored on
+// the subscription, with spent credit as a negative line. Subscriptions
+// priced before the breakdown was stored are charged as a single line.
+func renewalItemDetails(sub *entity.Subscription, period *entity.BillingPeriod) []dto.ChargeItem {
+	subtotal := sub.Subtotal
+	if subtotal == 0 {
+		subtotal = sub.TotalPrice
+	}
+
+	items := []dto.ChargeItem{{
+		ID:    sub.MealPlan.ID.String(),
+		Name:  fmt.Sprintf("Subscription %s", sub.MealPlan.Name),
+		Price: int64(pricing.FromFloat(subtotal)),
+		Qty:   1,
+	}}
+
+	if sub.Subtotal > 0 && sub.DeliveryFee > 0 {
+		items = append(items, dto.ChargeItem{
+			ID:    "delivery-fee",
+			Name:  "Delivery fee",
+			Price: int64(pricing.FromFloat(sub.DeliveryFee)),
+			Qty:   1,
+		})
+	}
+
+	if sub.Subtotal > 0 && sub.TaxAmount > 0 {
+		items = append(items, dto.ChargeItem{
+			ID:    "tax",
+			Name:  "PPN",
+			Price: int64(pricing.FromFloat(sub.TaxAmount)),
+			Qty:   1,
+		})
+	}
+
+	if period.CreditApplied > 0 {
+		items = append(items, dto.ChargeItem{
+			ID:    "credit",
+			Name:  "Subscription credit",
+			Price: -int64(pricing.FromFloat(period.CreditApplied)),
+			Qty:   1,
+		})
+	}
+
+	if period.WalletCreditApplied > 0 {
+		items = append(items, walletCreditItem(pricing.FromFloat(period.WalletCreditApplied)))
+	}
+
+	return items
+}
+
+// applyRenewalPayment extends a subscription once a renewal period is paid.
+// A renewal paid after the subscription already finished starts over from
+// today instead of back-filling the days that were not delivered.
+func (uc *SubscriptionUsecase) applyRenewalPayment(repos *txRepositories, subscription *entity.Subscription, period *entity.BillingPeriod) *res.Err {
+	if period.Status != entity.BillingPaid {
+		return nil
+	}
+
+	switch subscription.Status {
+	case entity.StatusActive, entity.StatusPaused:
+		if subscription.EndDate == nil || period.EndDate.After(*subscription.EndDate) {
+			endDate := period.EndDate
+			subscription.EndDate = &endDate
+		}
+
+		subscription.CreditBalance = math.Max(subscription.CreditBalance-period.CreditApplied, 0)
+
+		if err := repos.subscriptions.UpdateSubscription(subscription); err != nil {
+			return res.ErrInternalServerError(res.FailedUpdateSubscription)
+		}
+
+		if err := syncDeliveries(repos, subscription, dateOnly(time.Now())); err != nil {
+			return res.ErrInternalServerError(res.FailedSyncDeliveries)
+		}
+	case entity.StatusFinished:
+		if err := checkTransition(subscription.Status, entity.StatusActive, ActorWebhook); err != nil {
+			return err
+		}
+
+		today := dateOnly(time.Now())
+		period.StartDate = today
+		period.EndDate = today.Add(billingPeriodLength)
+
+		if err := repos.billingPeriods.UpdateBillingPeriod(period); err != nil {
+			return res.ErrInternalServerError(res.FailedSaveBillingPeriod)
+		}
+
+		endDate := period.EndDate
+		subscription.EndDate = &endDate
+		subscription.CreditBalance = math.Max(subscription.CreditBalance-period.CreditApplied, 0)
+
+		if err := repos.subscriptions.UpdateStatus(subscription, entity.StatusActive); err != nil {
+			return res.ErrInternalServerError(res.FailedUpdateSubscription)
+		}
+
+		if err := syncDeliveries(repos, subscription, today); err != nil {
+			return res.ErrInternalServerError(res.FailedSyncDeliveries)
+		}
+	default:
+		log.Printf("Ignoring paid renewal %s for %s subscription %s", period.OrderID, subscription.Status, subscription.ID)
+	}
+
+	return nil
+}
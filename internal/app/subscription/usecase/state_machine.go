@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+)
+
+// Actor is whoever asks for a subscription status change.
+type Actor string
+
+const (
+	ActorUser      Actor = "user"
+	ActorAdmin     Actor = "admin"
+	ActorWebhook   Actor = "webhook"
+	ActorScheduler Actor = "scheduler"
+	// ActorSystem is the service itself, as when a checkout needs no payment.
+	ActorSystem Actor = "system"
+)
+
+type statusTransition struct {
+	from entity.SubscriptionStatus
+	to   entity.SubscriptionStatus
+}
+
+// statusTransitions lists every legal status change and the actors allowed
+// to trigger it. Anything missing from this table is an illegal move.
+var statusTransitions = map[statusTransition][]Actor{
+	{entity.StatusPending, entity.StatusActive}:    {ActorWebhook, ActorAdmin, ActorSystem},
+	{entity.StatusPending, entity.StatusCancelled}: {ActorUser, ActorAdmin, ActorWebhook, ActorScheduler},
+
+	{entity.StatusActive, entity.StatusPaused}:    {ActorUser, ActorAdmin, ActorScheduler},
+	{entity.StatusActive, entity.StatusCancelled}: {ActorUser, ActorAdmin, ActorWebhook},
+	{entity.StatusActive, entity.StatusFinished}:  {ActorScheduler, ActorAdmin},
+
+	{entity.StatusPaused, entity.StatusActive}:    {ActorUser, ActorAdmin, ActorScheduler},
+	{entity.StatusPaused, entity.StatusCancelled}: {ActorUser, ActorAdmin},
+
+	{entity.StatusCancelled, entity.StatusActive}: {ActorAdmin},
+	{entity.StatusFinished, entity.StatusActive}:  {ActorAdmin, ActorWebhook},
+}
+
+// checkTransition returns nil when actor may move a subscription from one
+// status to another, ErrConflict when the move itself is illegal and
+// ErrForbidden when it is legal but not for this actor.
+func checkTransition(from entity.SubscriptionStatus, to entity.SubscriptionStatus, actor Actor) *res.Err {
+	actors, ok := statusTransitions[statusTransition{from: from, to: to}]
+	if !ok {
+		return res.ErrConflict(res.InvalidStatusTransition)
+	}
+
+	for _, a := range actors {
+		if a == actor {
+			return nil
+		}
+	}
+
+	return res.ErrForbidden(res.StatusTransitionNotAllowed)
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestCheckTransition(t *testing.T) {
+	statuses := []entity.SubscriptionStatus{
+		entity.StatusPending,
+		entity.StatusActive,
+		entity.StatusPaused,
+		entity.StatusCancelled,
+		entity.StatusFinished,
+	}
+
+	actors := []Actor{ActorUser, ActorAdmin, ActorWebhook, ActorScheduler, ActorSystem}
+
+	// allowed is the expected matrix, written out independently of
+	// statusTransitions so a change to the table has to be made here too:
+	// from -> to -> actors that may make the move. Any pair missing here,
+	// including every self transition, must be rejected as a conflict.
+	allowed := map[entity.SubscriptionStatus]map[entity.SubscriptionStatus][]Actor{
+		entity.StatusPending: {
+			entity.StatusActive:    {ActorWebhook, ActorAdmin, ActorSystem},
+			entity.StatusCancelled: {ActorUser, ActorAdmin, ActorWebhook, ActorScheduler},
+		},
+		entity.StatusActive: {
+			entity.StatusPaused:    {ActorUser, ActorAdmin, ActorScheduler},
+			entity.StatusCancelled: {ActorUser, ActorAdmin, ActorWebhook},
+			entity.StatusFinished:  {ActorScheduler, ActorAdmin},
+		},
+		entity.StatusPaused: {
+			entity.StatusActive:    {ActorUser, ActorAdmin, ActorScheduler},
+			entity.StatusCancelled: {ActorUser, ActorAdmin},
+		},
+		entity.StatusCancelled: {
+			entity.StatusActive: {ActorAdmin},
+		},
+		entity.StatusFinished: {
+			entity.StatusActive: {ActorAdmin, ActorWebhook},
+		},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			for _, actor := range actors {
+				legal, ok := allowed[from][to]
+
+				want := fiber.StatusConflict
+				if ok {
+					want = fiber.StatusForbidden
+					for _, a := range legal {
+						if a == actor {
+							want = 0
+						}
+					}
+				}
+
+				t.Run(string(from)+"->"+string(to)+"/"+string(actor), func(t *testing.T) {
+					err := checkTransition(from, to, actor)
+
+					switch {
+					case want == 0 && err != nil:
+						t.Fatalf("got %d %q, want nil", err.Code, err.Message)
+					case want != 0 && err == nil:
+						t.Fatalf("got nil, want %d", want)
+					case want != 0 && err.Code != want:
+						t.Fatalf("got %d %q, want %d", err.Code, err.Message, want)
+					}
+				})
+			}
+		}
+	}
+}
@@ -1,46 +1,178 @@
 package usecase
 
 import (
-	"fmt"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	conf "github.com/Ablebil/sea-catering-be/config"
+	deliveryRepository "github.com/Ablebil/sea-catering-be/internal/app/delivery/repository"
+	deliveryZoneRepository "github.com/Ablebil/sea-catering-be/internal/app/delivery_zone/repository"
+	giftRepository "github.com/Ablebil/sea-catering-be/internal/app/gift/repository"
 	mealPlanRepository "github.com/Ablebil/sea-catering-be/internal/app/meal_plan/repository"
+	paymentRepository "github.com/Ablebil/sea-catering-be/internal/app/payment/repository"
+	promoRepository "github.com/Ablebil/sea-catering-be/internal/app/promo/repository"
+	refundRepository "github.com/Ablebil/sea-catering-be/internal/app/refund/repository"
 	subscriptionRepository "github.com/Ablebil/sea-catering-be/internal/app/subscription/repository"
+	userRepository "github.com/Ablebil/sea-catering-be/internal/app/user/repository"
+	walletRepository "github.com/Ablebil/sea-catering-be/internal/app/wallet/repository"
 	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
 	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
-	"github.com/Ablebil/sea-catering-be/internal/infra/midtrans"
+	"github.com/Ablebil/sea-catering-be/internal/infra/email"
+	"github.com/Ablebil/sea-catering-be/internal/infra/event"
+	"github.com/Ablebil/sea-catering-be/internal/infra/geocoder"
+	"github.com/Ablebil/sea-catering-be/internal/infra/payment"
 	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/infra/supabase"
 	"github.com/Ablebil/sea-catering-be/internal/pkg/helper"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type SubscriptionUsecaseItf interface {
 	CreateSubscription(userID uuid.UUID, email string, req dto.CreateSubscriptionRequest) (*dto.PaymentResponse, *res.Err)
+	QuoteSubscription(req dto.QuoteSubscriptionRequest) (*dto.QuoteResponse, *res.Err)
 	GetUserSubscriptions(userID uuid.UUID) ([]dto.SubscriptionResponse, *res.Err)
 	PauseSubscription(userID uuid.UUID, subscriptionID uuid.UUID, req dto.PauseSubscriptionRequest) (*dto.SubscriptionResponse, *res.Err)
+	ResumeSubscription(userID uuid.UUID, subscriptionID uuid.UUID) (*dto.SubscriptionResponse, *res.Err)
 	CancelSubscription(userID uuid.UUID, subscriptionID uuid.UUID) (*dto.SubscriptionResponse, *res.Err)
+	UpdateSubscription(userID uuid.UUID, email string, subscriptionID uuid.UUID, req dto.UpdateSubscriptionRequest) (*dto.UpdateSubscriptionResponse, *res.Err)
+	GetSubscriptionChanges(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.SubscriptionChangeResponse, *res.Err)
+	ChangeDeliveryAddress(userID uuid.UUID, subscriptionID uuid.UUID, req dto.ChangeDeliveryAddressRequest) (*dto.SubscriptionAddressChangeResponse, *res.Err)
+	CancelAddressChange(userID uuid.UUID, subscriptionID uuid.UUID) *res.Err
+	GetAddressChanges(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.SubscriptionAddressChangeResponse, *res.Err)
+	UpdateAutoRenew(userID uuid.UUID, subscriptionID uuid.UUID, req dto.UpdateAutoRenewRequest) (*dto.SubscriptionResponse, *res.Err)
+	GetBillingPeriods(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.BillingPeriodResponse, *res.Err)
 	GetNewSusbcriptionsCount(req dto.GetSubscriptionStatisticRequest) (int64, *res.Err)
 	GetMRR(req dto.GetSubscriptionStatisticRequest) (float64, *res.Err)
 	GetTotalActiveSubscriptions() (int64, *res.Err)
 	GetReactivationStats(req dto.GetSubscriptionStatisticRequest) (int64, *res.Err)
-	HandlePaymentNotification(notification map[string]interface{}) *res.Err
+	PaySubscription(userID uuid.UUID, email string, subscriptionID uuid.UUID) (*dto.PaymentResponse, *res.Err)
+	HandlePaymentNotification(header http.Header, body []byte) *res.Err
+	CancelStalePendingSubscriptions() *res.Err
+	CancelStaleSubscriptionChanges() *res.Err
+	ReconcilePayments() *res.Err
 	UpdateExpiredSubscriptions() *res.Err
+	UpdatePausedSubscriptions() *res.Err
+	ApplyDueAddressChanges() *res.Err
+	ProcessRenewals() *res.Err
+	SendExpiryReminders() *res.Err
 }
 
 type SubscriptionUsecase struct {
-	SubscriptionRepository subscriptionRepository.SubscriptionRepositoryItf
-	MealPlanRepository     mealPlanRepository.MealPlanRepositoryItf
-	midtrans               midtrans.MidtransItf
-	helper                 helper.HelperItf
+	SubscriptionRepository              subscriptionRepository.SubscriptionRepositoryItf
+	MealPlanRepository                  mealPlanRepository.MealPlanRepositoryItf
+	PaymentRepository                   paymentRepository.PaymentRepositoryItf
+	BillingPeriodRepository             subscriptionRepository.BillingPeriodRepositoryItf
+	SubscriptionChangeRepository        subscriptionRepository.SubscriptionChangeRepositoryItf
+	DeliveryRepository                  deliveryRepository.DeliveryRepositoryItf
+	PromoRepository                     promoRepository.PromoRepositoryItf
+	PromoRedemptionRepository           promoRepository.PromoRedemptionRepositoryItf
+	InvoiceRepository                   paymentRepository.InvoiceRepositoryItf
+	RefundRepository                    refundRepository.RefundRepositoryItf
+	ReconciliationRepository            paymentRepository.ReconciliationRepositoryItf
+	WalletRepository                    walletRepository.WalletRepositoryItf
+	GiftRepository                      giftRepository.GiftRepositoryItf
+	DeliveryZoneRepository              deliveryZoneRepository.DeliveryZoneRepositoryItf
+	UserAddressRepository               userRepository.UserAddressRepositoryItf
+	SubscriptionAddressChangeRepository subscriptionRepository.SubscriptionAddressChangeRepositoryItf
+	db                                  *gorm.DB
+	conf                                *conf.Config
+	paymentGateway                      payment.PaymentGatewayItf
+	geocoder                            geocoder.GeocoderItf
+	email                               email.EmailItf
+	supabase                            supabase.SupabaseItf
+	event                               event.EventItf
+	helper                              helper.HelperItf
+	pricing                             pricing.PricingItf
 }
 
-func NewSubscriptionUsecase(subscriptionRepository subscriptionRepository.SubscriptionRepositoryItf, mealPlanRepository mealPlanRepository.MealPlanRepositoryItf, midtrans midtrans.MidtransItf, helper helper.HelperItf) SubscriptionUsecaseItf {
+// SubscriptionRepositories groups the repositories the subscription usecase
+// reads and writes, including those of the promo, wallet, gift, refund, zone
+// and address domains it touches inside its transactions.
+type SubscriptionRepositories struct {
+	Subscription              subscriptionRepository.SubscriptionRepositoryItf
+	MealPlan                  mealPlanRepository.MealPlanRepositoryItf
+	Payment                   paymentRepository.PaymentRepositoryItf
+	BillingPeriod             subscriptionRepository.BillingPeriodRepositoryItf
+	SubscriptionChange        subscriptionRepository.SubscriptionChangeRepositoryItf
+	Delivery                  deliveryRepository.DeliveryRepositoryItf
+	Promo                     promoRepository.PromoRepositoryItf
+	PromoRedemption           promoRepository.PromoRedemptionRepositoryItf
+	Invoice                   paymentRepository.InvoiceRepositoryItf
+	Refund                    refundRepository.RefundRepositoryItf
+	Reconciliation            paymentRepository.ReconciliationRepositoryItf
+	Wallet                    walletRepository.WalletRepositoryItf
+	Gift                      giftRepository.GiftRepositoryItf
+	DeliveryZone              deliveryZoneRepository.DeliveryZoneRepositoryItf
+	UserAddress               userRepository.UserAddressRepositoryItf
+	SubscriptionAddressChange subscriptionRepository.SubscriptionAddressChangeRepositoryItf
+}
+
+func NewSubscriptionUsecase(repositories SubscriptionRepositories, db *gorm.DB, conf *conf.Config, paymentGateway payment.PaymentGatewayItf, geocoder geocoder.GeocoderItf, email email.EmailItf, supabase supabase.SupabaseItf, event event.EventItf, helper helper.HelperItf, pricing pricing.PricingItf) SubscriptionUsecaseItf {
 	return &SubscriptionUsecase{
-		SubscriptionRepository: subscriptionRepository,
-		MealPlanRepository:     mealPlanRepository,
-		midtrans:               midtrans,
-		helper:                 helper,
+		SubscriptionRepository:              repositories.Subscription,
+		MealPlanRepository:                  repositories.MealPlan,
+		PaymentRepository:                   repositories.Payment,
+		BillingPeriodRepository:             repositories.BillingPeriod,
+		SubscriptionChangeRepository:        repositories.SubscriptionChange,
+		DeliveryRepository:                  repositories.Delivery,
+		PromoRepository:                     repositories.Promo,
+		PromoRedemptionRepository:           repositories.PromoRedemption,
+		InvoiceRepository:                   repositories.Invoice,
+		RefundRepository:                    repositories.Refund,
+		ReconciliationRepository:            repositories.Reconciliation,
+		WalletRepository:                    repositories.Wallet,
+		GiftRepository:                      repositories.Gift,
+		DeliveryZoneRepository:              repositories.DeliveryZone,
+		UserAddressRepository:               repositories.UserAddress,
+		SubscriptionAddressChangeRepository: repositories.SubscriptionAddressChange,
+		db:                                  db,
+		conf:                                conf,
+		paymentGateway:                      paymentGateway,
+		geocoder:                            geocoder,
+		email:                               email,
+		supabase:                            supabase,
+		event:                               event,
+		helper:                              helper,
+		pricing:                             pricing,
+	}
+}
+
+// txRepositories holds the repositories bound to a single transaction.
+type txRepositories struct {
+	subscriptions  subscriptionRepository.SubscriptionRepositoryItf
+	billingPeriods subscriptionRepository.BillingPeriodRepositoryItf
+	changes        subscriptionRepository.SubscriptionChangeRepositoryItf
+	payments       paymentRepository.PaymentRepositoryItf
+	deliveries     deliveryRepository.DeliveryRepositoryItf
+	promos         promoRepository.PromoRepositoryItf
+	redemptions    promoRepository.PromoRedemptionRepositoryItf
+	refunds        refundRepository.RefundRepositoryItf
+	wallets        walletRepository.WalletRepositoryItf
+	gifts          giftRepository.GiftRepositoryItf
+	zones          deliveryZoneRepository.DeliveryZoneRepositoryItf
+	addressChanges subscriptionRepository.SubscriptionAddressChangeRepositoryItf
+}
+
+func (uc *SubscriptionUsecase) withTx(tx *gorm.DB) *txRepositories {
+	return &txRepositories{
+		subscriptions:  uc.SubscriptionRepository.WithTx(tx),
+		billingPeriods: uc.BillingPeriodRepository.WithTx(tx),
+		changes:        uc.SubscriptionChangeRepository.WithTx(tx),
+		payments:       uc.PaymentRepository.WithTx(tx),
+		deliveries:     uc.DeliveryRepository.WithTx(tx),
+		promos:         uc.PromoRepository.WithTx(tx),
+		redemptions:    uc.PromoRedemptionRepository.WithTx(tx),
+		refunds:        uc.RefundRepository.WithTx(tx),
+		wallets:        uc.WalletRepository.WithTx(tx),
+		gifts:          uc.GiftRepository.WithTx(tx),
+		zones:          uc.DeliveryZoneRepository.WithTx(tx),
+		addressChanges: uc.SubscriptionAddressChangeRepository.WithTx(tx),
 	}
 }
 
@@ -54,11 +186,35 @@ func (uc *SubscriptionUsecase) CreateSubscription(userID uuid.UUID, email string
 		return nil, res.ErrNotFound(res.MealPlanNotFound)
 	}
 
-	totalPrice := mealPlan.Price * float64(len(req.MealTypes)) * float64(len(req.DeliveryDays)) * 4.3
+	if req.AddressID != nil {
+		address, resErr := uc.savedAddress(userID, *req.AddressID)
+		if resErr != nil {
+			return nil, resErr
+		}
+
+		useSavedAddress(&req, address)
+	}
+
+	area, resErr := uc.resolveDeliveryArea(req.City, req.PostalCode, req.Latitude, req.Longitude, req.DeliveryDays)
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	// The subscription renews at the undiscounted price; a promo only
+	// discounts the first billing period.
+	recurring := uc.quote(mealPlan, req.MealTypes, req.DeliveryDays, req.DeliveryAddress, area.zone)
+	firstPeriod := recurring
+
+	// A gift is a one-off: the recipient can turn on auto-renew once they
+	// have claimed it.
+	autoRenew := req.AutoRenew
+	if req.Gift != nil {
+		autoRenew = false
+	}
 
 	orderID := "SUBS-" + uuid.NewString()
 	now := time.Now()
-	end := now.Add(30 * 24 * time.Hour)
+	end := now.Add(billingPeriodLength)
 
 	newSubscription := &entity.Subscription{
 		UserID:          userID,
@@ -66,39 +222,172 @@ func (uc *SubscriptionUsecase) CreateSubscription(userID uuid.UUID, email string
 		Name:            req.Name,
 		PhoneNumber:     req.PhoneNumber,
 		DeliveryAddress: req.DeliveryAddress,
+		City:            optionalString(req.City),
+		PostalCode:      optionalString(req.PostalCode),
 		DeliveryNotes:   req.DeliveryNotes,
 		Status:          entity.StatusPending,
 		MealTypes:       strings.Join(req.MealTypes, ","),
 		DeliveryDays:    strings.Join(req.DeliveryDays, ","),
 		Allergies:       req.Allergies,
-		TotalPrice:      totalPrice,
+		Subtotal:        recurring.Subtotal.Float64(),
+		DeliveryFee:     recurring.Fees.Float64(),
+		TaxAmount:       recurring.Tax.Float64(),
+		TotalPrice:      recurring.Total.Float64(),
 		OrderID:         &orderID,
+		AutoRenew:       autoRenew,
 		StartDate:       now,
 		EndDate:         &end,
 	}
 
-	if err := uc.SubscriptionRepository.CreateSubscription(newSubscription); err != nil {
+	if area.point != nil {
+		newSubscription.Latitude = &area.point.Latitude
+		newSubscription.Longitude = &area.point.Longitude
+	}
+
+	if area.zone != nil {
+		newSubscription.DeliveryZoneID = &area.zone.ID
+	}
+
+	// What the gateway charges for the first period once the promo and
+	// wallet credit are taken off.
+	var amountDue pricing.Money
+	var itemDetails []dto.ChargeItem
+
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		var promo *entity.Promo
+		if req.PromoCode != nil {
+			promo, resErr = redeemPromo(repos, *req.PromoCode, userID, mealPlan.ID, recurring.Subtotal)
+			if resErr != nil {
+				return resErr
+			}
+
+			firstPeriod = uc.quote(mealPlan, req.MealTypes, req.DeliveryDays, req.DeliveryAddress, area.zone, promoDiscount(promo))
+		}
+
+		if err := repos.subscriptions.CreateSubscription(newSubscription); err != nil {
+			return err
+		}
+
+		if req.Gift != nil {
+			if err := repos.gifts.CreateGift(&entity.Gift{
+				SubscriptionID: newSubscription.ID,
+				PurchaserID:    userID,
+				RecipientEmail: req.Gift.RecipientEmail,
+				Message:        req.Gift.Message,
+				Status:         entity.GiftPending,
+			}); err != nil {
+				return err
+			}
+		}
+
+		amountDue = firstPeriod.Total
+		itemDetails = quoteItemDetails(firstPeriod)
+
+		var walletCredit pricing.Money
+		if req.UseWalletCredit && amountDue > 0 {
+			var err error
+			walletCredit, err = spendWalletCredit(repos, userID, newSubscription.ID.String(), amountDue)
+			if err != nil {
+				return err
+			}
+
+			if walletCredit > 0 {
+				amountDue -= walletCredit
+				itemDetails = append(itemDetails, walletCreditItem(walletCredit))
+			}
+		}
+
+		period := &entity.BillingPeriod{
+			SubscriptionID:      newSubscription.ID,
+			PeriodNumber:        1,
+			StartDate:           now,
+			EndDate:             end,
+			Amount:              amountDue.Float64(),
+			WalletCreditApplied: walletCredit.Float64(),
+			OrderID:             orderID,
+			ItemDetails:         encodeItemDetails(itemDetails),
+			Status:              entity.BillingPending,
+		}
+
+		// Nothing is left to charge when a promo or wallet credit covers the
+		// whole first period, so the subscription starts straight away.
+		if amountDue <= 0 {
+			period.Status = entity.BillingPaid
+			period.PaidAt = &now
+		}
+
+		if err := repos.billingPeriods.CreateBillingPeriod(period); err != nil {
+			return err
+		}
+
+		if promo != nil {
+			if err := repos.redemptions.CreatePromoRedemption(&entity.PromoRedemption{
+				PromoID:        promo.ID,
+				UserID:         userID,
+				SubscriptionID: newSubscription.ID,
+				DiscountAmount: firstPeriod.Discount.Float64(),
+			}); err != nil {
+				return err
+			}
+
+			promo.RedemptionCount++
+			if err := repos.promos.UpdatePromo(promo); err != nil {
+				return err
+			}
+		}
+
+		if period.Status != entity.BillingPaid {
+			return nil
+		}
+
+		if resErr = checkTransition(newSubscription.Status, entity.StatusActive, ActorSystem); resErr != nil {
+			return resErr
+		}
+
+		if err := repos.subscriptions.UpdateStatus(newSubscription, entity.StatusActive); err != nil {
+			return err
+		}
+
+		if err := issueGiftCode(repos, newSubscription.ID); err != nil {
+			return err
+		}
+
+		return syncDeliveries(repos, newSubscription, dateOnly(now))
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
 		return nil, res.ErrInternalServerError(res.FailedSaveSubscription)
 	}
 
-	midtransReq := &dto.MidtransRequest{
+	if amountDue <= 0 {
+		uc.publishStatusChange(newSubscription, entity.StatusPending)
+
+		if req.Gift != nil {
+			go uc.notifyGiftRecipient(newSubscription.ID)
+		}
+
+		return &dto.PaymentResponse{}, nil
+	}
+
+	chargeReq := &dto.ChargeRequest{
 		OrderID:        orderID,
-		Amount:         int64(totalPrice),
+		Amount:         int64(amountDue),
 		SubscriptionID: newSubscription.ID,
-		CustomerDetails: dto.MidtransCustomerDetails{
+		CustomerDetails: dto.ChargeCustomer{
 			Name:  req.Name,
 			Email: email,
 			Phone: req.PhoneNumber,
 		},
-		ItemDetails: []dto.MidtransItemDetail{{
-			ID:    mealPlan.ID.String(),
-			Name:  fmt.Sprintf("Subscription %s", mealPlan.Name),
-			Price: int64(totalPrice),
-			Qty:   1,
-		}},
+		ItemDetails: itemDetails,
 	}
 
-	paymentResponse, err := uc.midtrans.CreateTransaction(midtransReq)
+	paymentResponse, err := uc.paymentGateway.CreateCharge(chargeReq)
 	if err != nil {
 		return nil, res.ErrInternalServerError(res.FailedCreatePaymentTransaction)
 	}
@@ -113,35 +402,13 @@ func (uc *SubscriptionUsecase) GetUserSubscriptions(userID uuid.UUID) ([]dto.Sub
 	}
 
 	result := make([]dto.SubscriptionResponse, 0, len(subs))
-	for _, s := range subs {
-		mealPlan, err := uc.MealPlanRepository.GetMealPlanByID(s.MealPlanID)
-		if err != nil {
-			return nil, res.ErrInternalServerError(res.FailedGetMealPlanByID)
-		}
-
-		mealPlanResp := dto.MealPlanResponse{
-			ID:          mealPlan.ID,
-			Name:        mealPlan.Name,
-			Description: mealPlan.Description,
-			Price:       mealPlan.Price,
-			PhotoURL:    mealPlan.PhotoURL,
+	for i := range subs {
+		subResp, resErr := uc.toSubscriptionResponse(&subs[i])
+		if resErr != nil {
+			return nil, resErr
 		}
 
-		result = append(result, dto.SubscriptionResponse{
-			ID:              s.ID,
-			Name:            s.Name,
-			PhoneNumber:     s.PhoneNumber,
-			DeliveryAddress: s.DeliveryAddress,
-			DeliveryNotes:   s.DeliveryNotes,
-			MealPlan:        mealPlanResp,
-			MealTypes:       strings.Split(s.MealTypes, ","),
-			DeliveryDays:    strings.Split(s.DeliveryDays, ","),
-			Allergies:       s.Allergies,
-			TotalPrice:      s.TotalPrice,
-			Status:          string(s.Status),
-			StartDate:       s.StartDate,
-			EndDate:         s.EndDate,
-		})
+		result = append(result, *subResp)
 	}
 
 	return result, nil
@@ -162,49 +429,158 @@ func (uc *SubscriptionUsecase) PauseSubscription(userID uuid.UUID, subscriptionI
 		return nil, parseErr
 	}
 
-	sub.PauseStartDate = &startDate
-	sub.PauseEndDate = &endDate
+	today := civilDate(time.Now())
+	oldStatus := sub.Status
+
+	var resErr *res.Err
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		// Checked and changed on the locked row, so a payment or renewal
+		// committing meanwhile is not overwritten.
+		sub, resErr = lockSubscription(repos, sub.ID)
+		if resErr != nil {
+			return resErr
+		}
+
+		oldStatus = sub.Status
+		if resErr = checkTransition(sub.Status, entity.StatusPaused, ActorUser); resErr != nil {
+			return resErr
+		}
+
+		if sub.PauseEndDate != nil && !dateOnly(*sub.PauseEndDate).Before(today) {
+			resErr = res.ErrConflict(res.PauseAlreadyScheduled)
+			return resErr
+		}
+
+		if startDate.Before(civilDate(sub.StartDate)) || sub.EndDate != nil && civilDate(endDate).After(civilDate(*sub.EndDate)) {
+			resErr = res.ErrBadRequest(res.PauseOutsidePeriod)
+			return resErr
+		}
+
+		sub.PauseStartDate = &startDate
+		sub.PauseEndDate = &endDate
+
+		pauseDuration := endDate.Sub(startDate)
+		if sub.EndDate != nil {
+			newEndDate := sub.EndDate.Add(pauseDuration)
+			sub.EndDate = &newEndDate
+		}
+
+		// A pause starting later stays scheduled on an active subscription;
+		// the scheduler flips it to paused once the start date is reached.
+		if startDate.After(today) {
+			if err := repos.subscriptions.UpdateSubscription(sub); err != nil {
+				return err
+			}
+		} else if err := repos.subscriptions.UpdateStatus(sub, entity.StatusPaused); err != nil {
+			return err
+		}
+
+		from, err := uc.nextDeliveryChangeDate(repos, sub)
+		if err != nil {
+			return err
+		}
+
+		return syncDeliveries(repos, sub, from)
+	})
 
-	pauseDuration := endDate.Sub(startDate)
-	if sub.EndDate != nil {
-		newEndDate := sub.EndDate.Add(pauseDuration)
-		sub.EndDate = &newEndDate
+	if resErr != nil {
+		return nil, resErr
 	}
 
-	if err := uc.SubscriptionRepository.UpdateStatus(sub, entity.StatusPaused); err != nil {
+	if err != nil {
 		return nil, res.ErrInternalServerError(res.FailedPauseSubscription)
 	}
 
-	mealPlan, err := uc.MealPlanRepository.GetMealPlanByID(sub.MealPlanID)
+	uc.publishStatusChange(sub, oldStatus)
+	return uc.toSubscriptionResponse(sub)
+}
+
+func (uc *SubscriptionUsecase) ResumeSubscription(userID uuid.UUID, subscriptionID uuid.UUID) (*dto.SubscriptionResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
 	if err != nil {
-		return nil, res.ErrInternalServerError(res.FailedGetMealPlanByID)
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
 	}
 
-	mealPlanResp := dto.MealPlanResponse{
-		ID:          mealPlan.ID,
-		Name:        mealPlan.Name,
-		Description: mealPlan.Description,
-		Price:       mealPlan.Price,
-		PhotoURL:    mealPlan.PhotoURL,
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
 	}
 
-	return &dto.SubscriptionResponse{
-		ID:              sub.ID,
-		Name:            sub.Name,
-		PhoneNumber:     sub.PhoneNumber,
-		DeliveryAddress: sub.DeliveryAddress,
-		DeliveryNotes:   sub.DeliveryNotes,
-		MealPlan:        mealPlanResp,
-		MealTypes:       strings.Split(sub.MealTypes, ","),
-		DeliveryDays:    strings.Split(sub.DeliveryDays, ","),
-		Allergies:       sub.Allergies,
-		TotalPrice:      sub.TotalPrice,
-		Status:          string(sub.Status),
-		PauseStartDate:  sub.PauseStartDate,
-		PauseEndDate:    sub.PauseEndDate,
-		StartDate:       sub.StartDate,
-		EndDate:         sub.EndDate,
-	}, nil
+	today := civilDate(time.Now())
+	oldStatus := sub.Status
+
+	var resErr *res.Err
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		sub, resErr = lockSubscription(repos, sub.ID)
+		if resErr != nil {
+			return resErr
+		}
+
+		oldStatus = sub.Status
+		hasScheduledPause := sub.Status == entity.StatusActive && sub.PauseStartDate != nil && dateOnly(*sub.PauseStartDate).After(today)
+
+		if !hasScheduledPause {
+			if resErr = checkTransition(sub.Status, entity.StatusActive, ActorUser); resErr != nil {
+				return resErr
+			}
+		}
+
+		if sub.PauseStartDate == nil || sub.PauseEndDate == nil {
+			resErr = res.ErrConflict(res.SubscriptionNotPaused)
+			return resErr
+		}
+
+		// Give back the pause days that will no longer be used. For a pause
+		// that has not started yet that is the whole window.
+		pauseStart := dateOnly(*sub.PauseStartDate)
+		pauseEnd := dateOnly(*sub.PauseEndDate)
+		resumeFrom := today
+		if pauseStart.After(resumeFrom) {
+			resumeFrom = pauseStart
+		}
+
+		if unused := pauseEnd.Sub(resumeFrom); unused > 0 && sub.EndDate != nil {
+			newEndDate := sub.EndDate.Add(-unused)
+			sub.EndDate = &newEndDate
+		}
+
+		if hasScheduledPause {
+			sub.PauseStartDate = nil
+			sub.PauseEndDate = nil
+
+			if err := repos.subscriptions.UpdateSubscription(sub); err != nil {
+				return err
+			}
+		} else {
+			yesterday := today.AddDate(0, 0, -1)
+			sub.PauseEndDate = &yesterday
+
+			if err := repos.subscriptions.UpdateStatus(sub, entity.StatusActive); err != nil {
+				return err
+			}
+		}
+
+		from, err := uc.nextDeliveryChangeDate(repos, sub)
+		if err != nil {
+			return err
+		}
+
+		return syncDeliveries(repos, sub, from)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedResumeSubscription)
+	}
+
+	uc.publishStatusChange(sub, oldStatus)
+	return uc.toSubscriptionResponse(sub)
 }
 
 func (uc *SubscriptionUsecase) CancelSubscription(userID uuid.UUID, subscriptionID uuid.UUID) (*dto.SubscriptionResponse, *res.Err) {
@@ -217,38 +593,195 @@ func (uc *SubscriptionUsecase) CancelSubscription(userID uuid.UUID, subscription
 		return nil, res.ErrNotFound(res.SubscriptionNotFound)
 	}
 
-	if err := uc.SubscriptionRepository.UpdateStatus(sub, entity.StatusCancelled); err != nil {
+	oldStatus := sub.Status
+
+	var pendingChange *entity.SubscriptionChange
+	var resErr *res.Err
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		sub, resErr = lockSubscription(repos, sub.ID)
+		if resErr != nil {
+			return resErr
+		}
+
+		oldStatus = sub.Status
+		if resErr = checkTransition(sub.Status, entity.StatusCancelled, ActorUser); resErr != nil {
+			return resErr
+		}
+
+		// A change still waiting for payment will never be applied, so
+		// the credit it set aside is returned.
+		change, err := repos.changes.GetPendingSubscriptionChange(sub.ID)
+		if err != nil {
+			return err
+		}
+
+		if change != nil {
+			if err := cancelSubscriptionChange(repos, sub, change); err != nil {
+				return err
+			}
+		}
+
+		pendingChange = change
+
+		neverPaid := sub.Status == entity.StatusPending
+
+		from, err := uc.nextDeliveryChangeDate(repos, sub)
+		if err != nil {
+			return err
+		}
+
+		if err := repos.subscriptions.UpdateStatus(sub, entity.StatusCancelled); err != nil {
+			return err
+		}
+
+		if neverPaid {
+			if err := releasePromoRedemption(repos, sub.ID); err != nil {
+				return err
+			}
+
+			if err := releaseWalletCredit(repos, sub.UserID, sub.ID.String()); err != nil {
+				return err
+			}
+		} else if err := uc.requestRefunds(repos, sub, from); err != nil {
+			return err
+		}
+
+		if err := cancelPendingBillingPeriods(repos, sub); err != nil {
+			return err
+		}
+
+		return syncDeliveries(repos, sub, from)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
 		return nil, res.ErrInternalServerError(res.FailedCancelSubscription)
 	}
 
-	mealPlan, err := uc.MealPlanRepository.GetMealPlanByID(sub.MealPlanID)
+	// Should the change's order still get paid, the payment notification
+	// opens a refund for it.
+	if pendingChange != nil && pendingChange.OrderID != nil {
+		if err := uc.paymentGateway.ExpireCharge(*pendingChange.OrderID); err != nil {
+			log.Printf("Failed to expire order %s of cancelled subscription %s: %v", *pendingChange.OrderID, sub.ID, err)
+		}
+	}
+
+	uc.publishStatusChange(sub, oldStatus)
+	return uc.toSubscriptionResponse(sub)
+}
+
+func (uc *SubscriptionUsecase) UpdateAutoRenew(userID uuid.UUID, subscriptionID uuid.UUID, req dto.UpdateAutoRenewRequest) (*dto.SubscriptionResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
 	if err != nil {
-		return nil, res.ErrInternalServerError(res.FailedGetMealPlanByID)
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
 	}
 
-	mealPlanResp := dto.MealPlanResponse{
-		ID:          mealPlan.ID,
-		Name:        mealPlan.Name,
-		Description: mealPlan.Description,
-		Price:       mealPlan.Price,
-		PhotoURL:    mealPlan.PhotoURL,
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
 	}
 
-	return &dto.SubscriptionResponse{
-		ID:              sub.ID,
-		Name:            sub.Name,
-		PhoneNumber:     sub.PhoneNumber,
-		DeliveryAddress: sub.DeliveryAddress,
-		DeliveryNotes:   sub.DeliveryNotes,
-		MealPlan:        mealPlanResp,
-		MealTypes:       strings.Split(sub.MealTypes, ","),
-		DeliveryDays:    strings.Split(sub.DeliveryDays, ","),
-		Allergies:       sub.Allergies,
-		TotalPrice:      sub.TotalPrice,
-		Status:          string(sub.Status),
-		StartDate:       sub.StartDate,
-		EndDate:         sub.EndDate,
-	}, nil
+	var resErr *res.Err
+	err = uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		sub, resErr = lockSubscription(repos, sub.ID)
+		if resErr != nil {
+			return resErr
+		}
+
+		if sub.Status == entity.StatusCancelled || sub.Status == entity.StatusFinished {
+			resErr = res.ErrConflict(res.SubscriptionNotRenewable)
+			return resErr
+		}
+
+		sub.AutoRenew = *req.AutoRenew
+		if err := repos.subscriptions.UpdateSubscription(sub); err != nil {
+			return err
+		}
+
+		// Turning auto-renew off withdraws a renewal that is still waiting
+		// for payment. A pending subscription's only open period is its first
+		// payment, which is left alone.
+		if sub.AutoRenew || sub.Status == entity.StatusPending {
+			return nil
+		}
+
+		return cancelPendingBillingPeriods(repos, sub)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedUpdateSubscription)
+	}
+
+	return uc.toSubscriptionResponse(sub)
+}
+
+// optionalString stores an empty string as NULL.
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// lockSubscription reads a subscription again under a row lock, so changes
+// made to it inside the transaction start from what is committed rather than
+// from a copy read before the transaction.
+func lockSubscription(repos *txRepositories, subscriptionID uuid.UUID) (*entity.Subscription, *res.Err) {
+	sub, err := repos.subscriptions.GetSubscriptionByIDForUpdate(subscriptionID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	return sub, nil
+}
+
+func (uc *SubscriptionUsecase) GetBillingPeriods(userID uuid.UUID, subscriptionID uuid.UUID) ([]dto.BillingPeriodResponse, *res.Err) {
+	sub, err := uc.SubscriptionRepository.GetSubscriptionByIDAndUserID(subscriptionID, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if sub == nil {
+		return nil, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	periods, err := uc.BillingPeriodRepository.GetBillingPeriodsBySubscriptionID(sub.ID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetBillingPeriods)
+	}
+
+	result := make([]dto.BillingPeriodResponse, 0, len(periods))
+	for _, period := range periods {
+		result = append(result, dto.BillingPeriodResponse{
+			ID:           period.ID,
+			PeriodNumber: period.PeriodNumber,
+			StartDate:    period.StartDate,
+			EndDate:      period.EndDate,
+			Amount:       period.Amount,
+			WalletCredit: period.WalletCreditApplied,
+			OrderID:      period.OrderID,
+			PaymentURL:   period.PaymentURL,
+			Status:       string(period.Status),
+			PaidAt:       period.PaidAt,
+		})
+	}
+
+	return result, nil
 }
 
 func (uc *SubscriptionUsecase) GetNewSusbcriptionsCount(req dto.GetSubscriptionStatisticRequest) (int64, *res.Err) {
@@ -302,56 +835,628 @@ func (uc *SubscriptionUsecase) GetReactivationStats(req dto.GetSubscriptionStati
 	return count, nil
 }
 
-func (uc *SubscriptionUsecase) HandlePaymentNotification(notification map[string]interface{}) *res.Err {
-	orderID, ok := notification["order_id"].(string)
-	if !ok {
-		return res.ErrBadRequest(res.InvalidOrderID)
+func (uc *SubscriptionUsecase) HandlePaymentNotification(header http.Header, body []byte) *res.Err {
+	status, err := uc.paymentGateway.ParseWebhook(header, body)
+	switch {
+	case errors.Is(err, payment.ErrInvalidSignature):
+		return res.ErrForbidden(res.InvalidSignatureKey)
+	case errors.Is(err, payment.ErrInvalidNotification):
+		return res.ErrBadRequest(res.InvalidPaymentNotification)
+	case errors.Is(err, payment.ErrIgnoredNotification):
+		log.Printf("Ignoring payment notification: %v", err)
+		return nil
+	case err != nil:
+		return res.ErrInternalServerError(res.FailedGetTransactionStatus)
 	}
 
-	transactionStatus, ok := notification["transaction_status"].(string)
-	if !ok {
-		return res.ErrBadRequest(res.InvalidTransactionStatus)
+	return uc.applyTransactionStatus(status)
+}
+
+// applyTransactionStatus records an order status reported by the gateway,
+// whether it came in a webhook or was fetched by reconciliation.
+func (uc *SubscriptionUsecase) applyTransactionStatus(status *dto.TransactionStatus) *res.Err {
+	subscription, expectedAmount, resErr := uc.findPaymentOrder(status.OrderID)
+	if resErr != nil {
+		return resErr
 	}
 
-	subscription, err := uc.SubscriptionRepository.GetSubscriptionByOrderID(orderID)
+	if !matchGrossAmount(status.GrossAmount, expectedAmount) {
+		return res.ErrBadRequest(res.GrossAmountMismatch)
+	}
+
+	var changed bool
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		changed, resErr = uc.applyPaymentStatus(tx, subscription.ID, status)
+		if resErr != nil {
+			return resErr
+		}
+
+		return nil
+	})
+
+	if resErr != nil {
+		return resErr
+	}
+
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedUpdateSubscription)
+	}
+
+	periodStatus, _ := billingPeriodStatus(status)
+
+	// The invoice is issued in the background once the payment is committed:
+	// storing the PDF and sending the email are too slow for the webhook, and
+	// failing them must not undo the payment. Replays of a paid notification
+	// try again, so an invoice that failed is finished by the gateway's
+	// retries.
+	if periodStatus == entity.BillingPaid {
+		go func(orderID string) {
+			if err := uc.issueInvoice(orderID); err != nil {
+				log.Printf("Failed to issue invoice for order %s: %v", orderID, err)
+			}
+		}(status.OrderID)
+	}
+
+	// A replayed, late or merely confirming notification changed nothing the
+	// user has not already been told about.
+	if !changed {
+		return nil
+	}
+
+	paymentEvent := dto.PaymentEvent{
+		SubscriptionID: subscription.ID,
+		OrderID:        status.OrderID,
+		Status:         status.TransactionStatus,
+	}
+
+	switch periodStatus {
+	case entity.BillingPaid:
+		go uc.notifyGiftRecipient(subscription.ID)
+
+		uc.publish(subscription.UserID, event.PaymentSettled, paymentEvent)
+	case entity.BillingFailed:
+		uc.publish(subscription.UserID, event.PaymentFailed, paymentEvent)
+	}
+
+	// subscription was read before the payment was applied, so reading it
+	// again shows whether the payment moved it.
+	current, err := uc.SubscriptionRepository.GetSubscriptionByID(subscription.ID)
+	if err != nil {
+		log.Printf("Failed to read subscription %s after payment: %v", subscription.ID, err)
+	} else if current != nil {
+		uc.publishStatusChange(current, subscription.Status)
+	}
+
+	return nil
+}
+
+// findPaymentOrder resolves what an order paid for: a billing period, a
+// subscription change or, for subscriptions created before billing periods
+// existed, the subscription itself. It also returns the amount that order
+// was expected to charge.
+func (uc *SubscriptionUsecase) findPaymentOrder(orderID string) (*entity.Subscription, float64, *res.Err) {
+	period, err := uc.BillingPeriodRepository.GetBillingPeriodByOrderID(orderID)
+	if err != nil {
+		return nil, 0, res.ErrInternalServerError(res.FailedGetBillingPeriods)
+	}
+
+	var change *entity.SubscriptionChange
+	if period == nil {
+		change, err = uc.SubscriptionChangeRepository.GetSubscriptionChangeByOrderID(orderID)
+		if err != nil {
+			return nil, 0, res.ErrInternalServerError(res.FailedGetSubscriptionChanges)
+		}
+	}
+
+	var subscription *entity.Subscription
+	switch {
+	case period != nil:
+		subscription, err = uc.SubscriptionRepository.GetSubscriptionByID(period.SubscriptionID)
+	case change != nil:
+		subscription, err = uc.SubscriptionRepository.GetSubscriptionByID(change.SubscriptionID)
+	default:
+		subscription, err = uc.SubscriptionRepository.GetSubscriptionByOrderID(orderID)
+	}
+
+	if err != nil {
+		return nil, 0, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+	}
+
+	if subscription == nil {
+		return nil, 0, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	switch {
+	case period != nil:
+		return subscription, period.Amount, nil
+	case change != nil:
+		return subscription, change.ChargeAmount, nil
+	default:
+		return subscription, subscription.TotalPrice, nil
+	}
+}
+
+// applyPaymentStatus records the notification and moves the subscription to
+// the matching status. It runs inside a transaction holding a row lock on the
+// subscription, so parallel deliveries for the same order are serialised.
+// Replays are dropped and late messages never regress a terminal payment.
+// It reports whether the notification changed the payment's outcome, so the
+// caller only invoices, notifies and publishes for news.
+func (uc *SubscriptionUsecase) applyPaymentStatus(tx *gorm.DB, subscriptionID uuid.UUID, status *dto.TransactionStatus) (bool, *res.Err) {
+	repos := uc.withTx(tx)
+
+	subscription, err := repos.subscriptions.GetSubscriptionByIDForUpdate(subscriptionID)
 	if err != nil {
-		return res.ErrInternalServerError(res.FailedGetSubscriptionByID)
+		return false, res.ErrInternalServerError(res.FailedGetSubscriptionByID)
 	}
 
 	if subscription == nil {
-		return res.ErrNotFound(res.SubscriptionNotFound)
+		return false, res.ErrNotFound(res.SubscriptionNotFound)
+	}
+
+	period, err := repos.billingPeriods.GetBillingPeriodByOrderIDForUpdate(status.OrderID)
+	if err != nil {
+		return false, res.ErrInternalServerError(res.FailedGetBillingPeriods)
+	}
+
+	isNew, err := repos.payments.CreateNotification(&entity.PaymentNotification{
+		OrderID:           status.OrderID,
+		TransactionID:     status.TransactionID,
+		TransactionStatus: status.TransactionStatus,
+		FraudStatus:       status.FraudStatus,
+	})
+	if err != nil {
+		return false, res.ErrInternalServerError(res.FailedSavePaymentNotification)
 	}
 
-	var newStatus entity.SubscriptionStatus
-	switch transactionStatus {
-	case "capture", "settlement":
-		newStatus = entity.StatusActive
-	case "cancel", "expire", "failure":
-		newStatus = entity.StatusCancelled
+	if !isNew {
+		return false, nil
+	}
+
+	payment, err := repos.payments.GetPaymentByOrderID(status.OrderID)
+	if err != nil {
+		return false, res.ErrInternalServerError(res.FailedGetPayments)
+	}
+
+	if payment != nil && !canApplyPaymentStatus(recordedStatus(payment), status) {
+		return false, nil
+	}
+
+	// "settlement" following "capture" moves the payment along without
+	// changing its outcome: it was already paid.
+	changed := payment == nil || paymentOutcome(payment) != outcome(status)
+
+	if err := repos.payments.SavePayment(newPayment(subscription.ID, status)); err != nil {
+		return false, res.ErrInternalServerError(res.FailedSavePayment)
+	}
+
+	// A refund leaves the billing period and subscription as they are; the
+	// subscription was already cancelled when the refund was requested.
+	if status.TransactionStatus == "refund" || status.TransactionStatus == "partial_refund" {
+		if err := applyRefundNotification(repos, status); err != nil {
+			return false, res.ErrInternalServerError(res.FailedSaveRefund)
+		}
+
+		return changed, nil
+	}
+
+	if period != nil {
+		if periodStatus, ok := billingPeriodStatus(status); ok && period.Status != periodStatus {
+			period.Status = periodStatus
+			if periodStatus == entity.BillingPaid {
+				now := time.Now()
+				period.PaidAt = &now
+			}
+
+			if err := repos.billingPeriods.UpdateBillingPeriod(period); err != nil {
+				return false, res.ErrInternalServerError(res.FailedSaveBillingPeriod)
+			}
+
+			// Wallet credit spent on an unpaid renewal goes back to the
+			// wallet. The first period's is given back when the
+			// subscription is cancelled.
+			if periodStatus == entity.BillingFailed {
+				if err := releaseWalletCredit(repos, subscription.UserID, period.OrderID); err != nil {
+					return false, res.ErrInternalServerError(res.FailedReleaseWalletCredit)
+				}
+			}
+		}
+
+		if subscription.OrderID == nil || *subscription.OrderID != period.OrderID {
+			if period.PeriodNumber != 1 {
+				return changed, uc.applyRenewalPayment(repos, subscription, period)
+			}
+
+			// The first order was voided when its payment link was
+			// re-issued. Should it still get paid, the subscription runs
+			// on it and the replacement order is withdrawn.
+			if period.Status != entity.BillingPaid {
+				return changed, nil
+			}
+
+			log.Printf("Order %s was paid after being replaced, activating subscription %s on it", period.OrderID, subscription.ID)
+			if err := cancelPendingBillingPeriods(repos, subscription); err != nil {
+				return false, res.ErrInternalServerError(res.FailedSaveBillingPeriod)
+			}
+
+			subscription.OrderID = &period.OrderID
+			subscription.StartDate = period.StartDate
+			subscription.EndDate = &period.EndDate
+			if err := repos.subscriptions.UpdateSubscription(subscription); err != nil {
+				return false, res.ErrInternalServerError(res.FailedUpdateSubscription)
+			}
+		}
+	}
+
+	change, err := repos.changes.GetSubscriptionChangeByOrderIDForUpdate(status.OrderID)
+	if err != nil {
+		return false, res.ErrInternalServerError(res.FailedGetSubscriptionChanges)
+	}
+
+	if change != nil {
+		return changed, uc.applyChangePayment(repos, subscription, change, status)
+	}
+
+	newStatus, ok := subscriptionStatus(status)
+	if !ok {
+		return changed, nil
+	}
+
+	if subscription.Status == newStatus {
+		return changed, nil
+	}
+
+	// Retrying would not make an illegal move legal, so it is logged and
+	// acknowledged instead of failing the webhook.
+	if err := checkTransition(subscription.Status, newStatus, ActorWebhook); err != nil {
+		log.Printf("Ignoring payment notification for order %s: %s -> %s: %v", status.OrderID, subscription.Status, newStatus, err)
+		return changed, nil
+	}
+
+	neverPaid := subscription.Status == entity.StatusPending
+
+	if err := repos.subscriptions.UpdateStatus(subscription, newStatus); err != nil {
+		return false, res.ErrInternalServerError(res.FailedUpdateSubscription)
+	}
+
+	if neverPaid && newStatus == entity.StatusActive {
+		if err := issueGiftCode(repos, subscription.ID); err != nil {
+			return false, res.ErrInternalServerError(res.FailedSaveGift)
+		}
+	}
+
+	if neverPaid && newStatus == entity.StatusCancelled {
+		if err := releasePromoRedemption(repos, subscription.ID); err != nil {
+			return false, res.ErrInternalServerError(res.FailedReleasePromo)
+		}
+
+		if err := releaseWalletCredit(repos, subscription.UserID, subscription.ID.String()); err != nil {
+			return false, res.ErrInternalServerError(res.FailedReleaseWalletCredit)
+		}
+	}
+
+	if err := syncDeliveries(repos, subscription, dateOnly(time.Now())); err != nil {
+		return false, res.ErrInternalServerError(res.FailedSyncDeliveries)
+	}
+
+	return changed, nil
+}
+
+// subscriptionStatus maps a gateway transaction status onto the status of the
+// subscription it pays for. A capture held for fraud review leaves it pending.
+// The second result is false for statuses that do not move the subscription.
+func subscriptionStatus(status *dto.TransactionStatus) (entity.SubscriptionStatus, bool) {
+	switch status.TransactionStatus {
+	case "capture":
+		if status.FraudStatus == "challenge" {
+			return entity.StatusPending, true
+		}
+
+		return entity.StatusActive, true
+	case "settlement":
+		return entity.StatusActive, true
+	case "cancel", "expire", "failure", "deny":
+		return entity.StatusCancelled, true
 	case "pending":
-		newStatus = entity.StatusPending
+		return entity.StatusPending, true
 	default:
-		return nil
+		return "", false
 	}
+}
 
-	if err := uc.SubscriptionRepository.UpdateStatus(subscription, newStatus); err != nil {
-		return res.ErrInternalServerError(res.FailedUpdateSubscription)
+// billingPeriodStatus maps a gateway transaction status onto the billing
+// period it pays for. The second result is false while the outcome is open.
+func billingPeriodStatus(status *dto.TransactionStatus) (entity.BillingPeriodStatus, bool) {
+	switch status.TransactionStatus {
+	case "capture":
+		if status.FraudStatus == "challenge" {
+			return entity.BillingPending, true
+		}
+
+		return entity.BillingPaid, true
+	case "settlement":
+		return entity.BillingPaid, true
+	case "cancel", "expire", "failure", "deny":
+		return entity.BillingFailed, true
+	case "pending":
+		return entity.BillingPending, true
+	default:
+		return "", false
 	}
+}
 
-	return nil
+// outcome is the billing outcome of a transaction status, empty while the
+// payment is still open or for statuses outside the payment itself.
+func outcome(status *dto.TransactionStatus) entity.BillingPeriodStatus {
+	periodStatus, _ := billingPeriodStatus(status)
+	return periodStatus
+}
+
+// paymentOutcome is the billing outcome of the last status recorded for a
+// payment.
+func paymentOutcome(payment *entity.Payment) entity.BillingPeriodStatus {
+	return outcome(recordedStatus(payment))
+}
+
+// recordedStatus is the last transaction status recorded for a payment.
+func recordedStatus(payment *entity.Payment) *dto.TransactionStatus {
+	status := &dto.TransactionStatus{TransactionStatus: payment.Status}
+	if payment.FraudStatus != nil {
+		status.FraudStatus = *payment.FraudStatus
+	}
+
+	return status
+}
+
+// paymentStatusRank orders gateway transaction statuses by how far along the
+// payment lifecycle they are. Statuses sharing a rank are alternative
+// outcomes: whichever arrives first wins.
+var paymentStatusRank = map[string]int{
+	"pending":        0,
+	"authorize":      1,
+	"capture":        2,
+	"settlement":     3,
+	"deny":           3,
+	"cancel":         3,
+	"expire":         3,
+	"failure":        3,
+	"partial_refund": 4,
+	"refund":         5,
+}
+
+// canApplyPaymentStatus reports whether a payment currently in status current
+// may move to next. Only forward moves are allowed, so a late "pending" or an
+// "expire" arriving after "settlement" is ignored.
+func canApplyPaymentStatus(current *dto.TransactionStatus, next *dto.TransactionStatus) bool {
+	if acceptsFraudReview(current, next) {
+		return true
+	}
+
+	currentRank, ok := paymentStatusRank[current.TransactionStatus]
+	if !ok {
+		return true
+	}
+
+	nextRank, ok := paymentStatusRank[next.TransactionStatus]
+	if !ok {
+		return false
+	}
+
+	return nextRank > currentRank
+}
+
+// acceptsFraudReview reports whether next accepts a capture that current
+// held for fraud review. The transaction status stays "capture", only its
+// fraud status moves on.
+func acceptsFraudReview(current *dto.TransactionStatus, next *dto.TransactionStatus) bool {
+	return current.TransactionStatus == "capture" && next.TransactionStatus == "capture" &&
+		current.FraudStatus == "challenge" && next.FraudStatus == "accept"
+}
+
+func newPayment(subscriptionID uuid.UUID, status *dto.TransactionStatus) *entity.Payment {
+	grossAmount, _ := strconv.ParseFloat(status.GrossAmount, 64)
+
+	payment := &entity.Payment{
+		SubscriptionID:  subscriptionID,
+		OrderID:         status.OrderID,
+		GrossAmount:     grossAmount,
+		PaymentType:     status.PaymentType,
+		Status:          status.TransactionStatus,
+		RawNotification: status.Raw,
+	}
+
+	if status.TransactionID != "" {
+		payment.TransactionID = &status.TransactionID
+	}
+
+	if status.FraudStatus != "" {
+		payment.FraudStatus = &status.FraudStatus
+	}
+
+	if !status.TransactionTime.IsZero() {
+		payment.TransactionTime = &status.TransactionTime
+	}
+
+	return payment
+}
+
+// matchGrossAmount compares the gateway's gross amount (e.g. "180600.00") with
+// the amount that was charged for the subscription.
+func matchGrossAmount(grossAmount string, totalPrice float64) bool {
+	amount, err := strconv.ParseFloat(grossAmount, 64)
+	if err != nil {
+		return false
+	}
+
+	return pricing.FromFloat(amount) == pricing.FromFloat(totalPrice)
 }
 
+// UpdateExpiredSubscriptions finishes subscriptions whose end date passed
+// more than the renewal grace period ago, withdrawing any unpaid renewal.
 func (uc *SubscriptionUsecase) UpdateExpiredSubscriptions() *res.Err {
-	expiredSubs, err := uc.SubscriptionRepository.GetExpiredActiveSubscriptions()
+	before := civilDate(time.Now()).Add(-uc.conf.RenewalGracePeriod)
+
+	expiredSubs, err := uc.SubscriptionRepository.GetExpiredActiveSubscriptions(before)
 	if err != nil {
 		return res.ErrInternalServerError(res.FailedGetExpiredSubscriptions)
 	}
 
+	expired := func(sub *entity.Subscription) bool {
+		return sub.Status == entity.StatusActive && sub.EndDate != nil && sub.EndDate.Before(before)
+	}
+
 	for _, sub := range expiredSubs {
-		if err := uc.SubscriptionRepository.UpdateStatus(&sub, entity.StatusFinished); err != nil {
-			continue
+		if err := uc.updateScheduledStatus(sub.ID, entity.StatusFinished, expired, cancelPendingBillingPeriods); err != nil {
+			log.Printf("Failed to finish subscription %s: %v", sub.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// UpdatePausedSubscriptions starts pauses whose window has begun and
+// reactivates subscriptions whose pause window has elapsed.
+func (uc *SubscriptionUsecase) UpdatePausedSubscriptions() *res.Err {
+	today := civilDate(time.Now())
+
+	toPause, err := uc.SubscriptionRepository.GetSubscriptionsDueToPause(today)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetPausedSubscriptions)
+	}
+
+	pauseStarted := func(sub *entity.Subscription) bool {
+		return sub.Status == entity.StatusActive &&
+			sub.PauseStartDate != nil && daysBetween(*sub.PauseStartDate, today) >= 0 &&
+			sub.PauseEndDate != nil && daysBetween(today, *sub.PauseEndDate) >= 0
+	}
+
+	for _, sub := range toPause {
+		if err := uc.updateScheduledStatus(sub.ID, entity.StatusPaused, pauseStarted, nil); err != nil {
+			log.Printf("Failed to pause subscription %s: %v", sub.ID, err)
+		}
+	}
+
+	toResume, err := uc.SubscriptionRepository.GetSubscriptionsDueToResume(today)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedGetPausedSubscriptions)
+	}
+
+	pauseEnded := func(sub *entity.Subscription) bool {
+		return sub.Status == entity.StatusPaused && sub.PauseEndDate != nil && daysBetween(*sub.PauseEndDate, today) > 0
+	}
+
+	for _, sub := range toResume {
+		if err := uc.updateScheduledStatus(sub.ID, entity.StatusActive, pauseEnded, nil); err != nil {
+			log.Printf("Failed to resume subscription %s: %v", sub.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// updateScheduledStatus moves a subscription picked up by a scheduled job
+// to newStatus. The job's query ran without a lock, so whether the move is
+// still due is checked again on the locked row: a user may have paused,
+// resumed or cancelled it since. then, when given, runs in the same
+// transaction.
+func (uc *SubscriptionUsecase) updateScheduledStatus(subscriptionID uuid.UUID, newStatus entity.SubscriptionStatus, due func(sub *entity.Subscription) bool, then func(repos *txRepositories, sub *entity.Subscription) error) error {
+	var updated *entity.Subscription
+	var oldStatus entity.SubscriptionStatus
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		repos := uc.withTx(tx)
+
+		sub, err := repos.subscriptions.GetSubscriptionByIDForUpdate(subscriptionID)
+		if err != nil {
+			return err
+		}
+
+		if sub == nil || !due(sub) {
+			return nil
+		}
+
+		if err := checkTransition(sub.Status, newStatus, ActorScheduler); err != nil {
+			return nil
 		}
+
+		oldStatus = sub.Status
+		if err := repos.subscriptions.UpdateStatus(sub, newStatus); err != nil {
+			return err
+		}
+
+		if then != nil {
+			if err := then(repos, sub); err != nil {
+				return err
+			}
+		}
+
+		updated = sub
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if updated != nil {
+		uc.publishStatusChange(updated, oldStatus)
 	}
 
 	return nil
 }
+
+func (uc *SubscriptionUsecase) toSubscriptionResponse(sub *entity.Subscription) (*dto.SubscriptionResponse, *res.Err) {
+	mealPlan := sub.MealPlan
+	if mealPlan == nil {
+		var err error
+		mealPlan, err = uc.MealPlanRepository.GetMealPlanByID(sub.MealPlanID)
+		if err != nil || mealPlan == nil {
+			return nil, res.ErrInternalServerError(res.FailedGetMealPlanByID)
+		}
+	}
+
+	return &dto.SubscriptionResponse{
+		ID:              sub.ID,
+		Name:            sub.Name,
+		PhoneNumber:     sub.PhoneNumber,
+		DeliveryAddress: sub.DeliveryAddress,
+		City:            sub.City,
+		PostalCode:      sub.PostalCode,
+		Latitude:        sub.Latitude,
+		Longitude:       sub.Longitude,
+		DeliveryZoneID:  sub.DeliveryZoneID,
+		DeliveryNotes:   sub.DeliveryNotes,
+		MealPlan: dto.MealPlanResponse{
+			ID:          mealPlan.ID,
+			Name:        mealPlan.Name,
+			Description: mealPlan.Description,
+			Price:       mealPlan.Price,
+			PhotoURL:    mealPlan.PhotoURL,
+		},
+		MealTypes:      strings.Split(sub.MealTypes, ","),
+		DeliveryDays:   strings.Split(sub.DeliveryDays, ","),
+		Allergies:      sub.Allergies,
+		Subtotal:       sub.Subtotal,
+		DeliveryFee:    sub.DeliveryFee,
+		TaxAmount:      sub.TaxAmount,
+		TotalPrice:     sub.TotalPrice,
+		Status:         string(sub.Status),
+		AutoRenew:      sub.AutoRenew,
+		CreditBalance:  sub.CreditBalance,
+		PauseStartDate: sub.PauseStartDate,
+		PauseEndDate:   sub.PauseEndDate,
+		StartDate:      sub.StartDate,
+		EndDate:        sub.EndDate,
+		CreatedAt:      derefTime(sub.CreatedAt),
+	}, nil
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func derefTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+)
+
+// spendWalletCredit pays as much of amount as the user's wallet covers and
+// returns what it paid. The credit is taken off straight away so it cannot
+// be spent twice while the rest of the order waits for payment. reference
+// names what was paid for: the subscription for its first period, the order
+// for a renewal.
+func spendWalletCredit(repos *txRepositories, userID uuid.UUID, reference string, amount pricing.Money) (pricing.Money, error) {
+	wallet, err := repos.wallets.GetWalletByUserIDForUpdate(userID)
+	if err != nil {
+		return 0, err
+	}
+
+	credit := pricing.Min(pricing.FromFloat(wallet.Balance), amount)
+	if credit <= 0 {
+		return 0, nil
+	}
+
+	if _, err := repos.wallets.Debit(&entity.WalletTransaction{
+		UserID:    userID,
+		Amount:    credit.Float64(),
+		Reason:    entity.WalletSubscriptionPayment,
+		Reference: &reference,
+	}); err != nil {
+		return 0, err
+	}
+
+	return credit, nil
+}
+
+// releaseWalletCredit gives back the wallet credit spent under reference on
+// something that was never paid. It does nothing the second time, or when
+// no credit was spent.
+func releaseWalletCredit(repos *txRepositories, userID uuid.UUID, reference string) error {
+	spent, err := repos.wallets.GetTransactionByReference(userID, entity.WalletSubscriptionPayment, reference)
+	if err != nil || spent == nil {
+		return err
+	}
+
+	released, err := repos.wallets.GetTransactionByReference(userID, entity.WalletSubscriptionRelease, reference)
+	if err != nil || released != nil {
+		return err
+	}
+
+	return repos.wallets.Credit(&entity.WalletTransaction{
+		UserID:    userID,
+		Amount:    spent.Amount,
+		Reason:    entity.WalletSubscriptionRelease,
+		Reference: &reference,
+	})
+}
+
+func walletCreditItem(credit pricing.Money) dto.ChargeItem {
+	return dto.ChargeItem{
+		ID:    "wallet-credit",
+		Name:  "Wallet credit",
+		Price: -int64(credit),
+		Qty:   1,
+	}
+}
@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/Ablebil/sea-catering-be/internal/infra/geocoder"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/geo"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+)
+
+// deliveryArea is where an address lies: its location, when it could be
+// found, and the delivery zone covering it, if zones are set up at all.
+type deliveryArea struct {
+	point *geo.Point
+	zone  *entity.DeliveryZone
+}
+
+// resolveDeliveryArea locates an address and finds the active delivery zone
+// covering it, which must deliver on every chosen day. A customer's own
+// coordinates win over the geocoder's. Until any zone is active every
+// address is covered and priced with the configured zone fees, so city and
+// postal code are optional until then.
+func (uc *SubscriptionUsecase) resolveDeliveryArea(city string, postalCode string, latitude *float64, longitude *float64, deliveryDays []string) (*deliveryArea, *res.Err) {
+	area := &deliveryArea{}
+
+	if latitude != nil && longitude != nil {
+		area.point = &geo.Point{Latitude: *latitude, Longitude: *longitude}
+	} else if postalCode != "" {
+		location, err := uc.geocoder.Geocode(geocoder.Address{City: city, PostalCode: postalCode})
+		switch {
+		case errors.Is(err, geocoder.ErrAddressNotFound):
+		case err != nil:
+			return nil, res.ErrInternalServerError(res.FailedCheckAddressCoverage)
+		default:
+			area.point = &location.Point
+		}
+	}
+
+	zones, err := uc.DeliveryZoneRepository.GetActiveDeliveryZones()
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedCheckAddressCoverage)
+	}
+
+	if len(zones) == 0 {
+		return area, nil
+	}
+
+	if city == "" && postalCode == "" && area.point == nil {
+		return nil, res.ErrBadRequest(res.AddressAreaRequired)
+	}
+
+	area.zone = matchDeliveryZone(zones, city, postalCode, area.point)
+	if area.zone == nil {
+		return nil, res.ErrUnprocessableEntity(res.AddressNotCovered)
+	}
+
+	if resErr := checkZoneDeliveryDays(area.zone, deliveryDays); resErr != nil {
+		return nil, resErr
+	}
+
+	return area, nil
+}
+
+// matchDeliveryZone picks the zone covering an address. Postal codes are the
+// most precise, so they are tried first and the longest matching prefix
+// wins; then polygons, then zones that cover a whole city.
+func matchDeliveryZone(zones []entity.DeliveryZone, city string, postalCode string, point *geo.Point) *entity.DeliveryZone {
+	var match *entity.DeliveryZone
+	longest := 0
+
+	for i := range zones {
+		if zones[i].PostalCodes == nil || postalCode == "" {
+			continue
+		}
+
+		for _, prefix := range strings.Split(*zones[i].PostalCodes, ",") {
+			if len(prefix) > longest && strings.HasPrefix(postalCode, prefix) {
+				match = &zones[i]
+				longest = len(prefix)
+			}
+		}
+	}
+
+	if match != nil {
+		return match
+	}
+
+	if point != nil {
+		for i := range zones {
+			if zones[i].Polygon == nil {
+				continue
+			}
+
+			polygon, err := geo.ParsePolygon(*zones[i].Polygon)
+			if err != nil {
+				log.Printf("Delivery zone %s has an unreadable polygon: %v", zones[i].ID, err)
+				continue
+			}
+
+			if polygon.Contains(*point) {
+				return &zones[i]
+			}
+		}
+	}
+
+	for i := range zones {
+		if zones[i].PostalCodes == nil && zones[i].Polygon == nil && strings.EqualFold(strings.TrimSpace(city), zones[i].City) {
+			return &zones[i]
+		}
+	}
+
+	return nil
+}
+
+func checkZoneDeliveryDays(zone *entity.DeliveryZone, deliveryDays []string) *res.Err {
+	served := make(map[string]bool)
+	for _, day := range strings.Split(zone.DeliveryDays, ",") {
+		served[day] = true
+	}
+
+	for _, day := range deliveryDays {
+		if !served[day] {
+			return res.ErrUnprocessableEntity(res.DeliveryDayNotServed)
+		}
+	}
+
+	return nil
+}
+
+// subscriptionZone loads the delivery zone a subscription was placed in.
+// Subscriptions created before zones existed have none.
+func (uc *SubscriptionUsecase) subscriptionZone(sub *entity.Subscription) (*entity.DeliveryZone, error) {
+	if sub.DeliveryZoneID == nil {
+		return nil, nil
+	}
+
+	return uc.DeliveryZoneRepository.GetDeliveryZoneByID(*sub.DeliveryZoneID)
+}
+
+// deliveryCutoff is how long before a delivery day changes to it close: the
+// zone's own cutoff when it sets one, otherwise DELIVERY_CUTOFF.
+func (uc *SubscriptionUsecase) deliveryCutoff(repos *txRepositories, zoneID *uuid.UUID) (time.Duration, error) {
+	if zoneID == nil {
+		return uc.conf.DeliveryCutoff, nil
+	}
+
+	zone, err := repos.zones.GetDeliveryZoneByID(*zoneID)
+	if err != nil {
+		return 0, err
+	}
+
+	if zone == nil || zone.CutoffMinutes == nil {
+		return uc.conf.DeliveryCutoff, nil
+	}
+
+	return time.Duration(*zone.CutoffMinutes) * time.Minute, nil
+}
+
+func pricingZone(zone *entity.DeliveryZone) *pricing.Zone {
+	if zone == nil {
+		return nil
+	}
+
+	return &pricing.Zone{
+		Name: zone.Name,
+		Fee:  pricing.FromFloat(zone.DeliveryFee),
+	}
+}
@@ -2,24 +2,35 @@ package rest
 
 import (
 	userUsecase "github.com/Ablebil/sea-catering-be/internal/app/user/usecase"
-	_ "github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
 	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
 	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
 type UserHandler struct {
+	Validator   *validator.Validate
 	UserUsecase userUsecase.UserUsecaseItf
 }
 
-func NewUserHandler(routerGroup fiber.Router, userUsecase userUsecase.UserUsecaseItf, middleware middleware.MiddlewareItf) {
+func NewUserHandler(routerGroup fiber.Router, validator *validator.Validate, userUsecase userUsecase.UserUsecaseItf, middleware middleware.MiddlewareItf) {
 	userHandler := &UserHandler{
+		Validator:   validator,
 		UserUsecase: userUsecase,
 	}
 
+	adminRouterGroup := routerGroup.Group("/admin/users", middleware.Authentication, middleware.Authorization)
+	adminRouterGroup.Put("/:id/role", userHandler.UpdateUserRole)
+
 	routerGroup = routerGroup.Group("/users")
 	routerGroup.Get("/profile", middleware.Authentication, userHandler.GetProfile)
+	routerGroup.Post("/addresses", middleware.Authentication, userHandler.CreateUserAddress)
+	routerGroup.Get("/addresses", middleware.Authentication, userHandler.GetUserAddresses)
+	routerGroup.Get("/addresses/:id", middleware.Authentication, userHandler.GetUserAddress)
+	routerGroup.Put("/addresses/:id", middleware.Authentication, userHandler.UpdateUserAddress)
+	routerGroup.Delete("/addresses/:id", middleware.Authentication, userHandler.DeleteUserAddress)
 }
 
 // @Summary      Get User Profile
@@ -41,3 +52,204 @@ func (h UserHandler) GetProfile(ctx *fiber.Ctx) error {
 
 	return res.OK(ctx, profile, res.GetProfileSuccess)
 }
+
+// @Summary      Create Address
+// @Description  Add an address to the authenticated user's address book. The first address saved becomes the default; saving another with is_default moves the default to it.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        payload body dto.UserAddressRequest true "User Address Request"
+// @Success      201  {object}  res.Res{payload=dto.UserAddressResponse} "Address created successful"
+// @Failure      400  {object}  res.Err "Invalid request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /users/addresses [post]
+func (h UserHandler) CreateUserAddress(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	req := new(dto.UserAddressRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	address, err := h.UserUsecase.CreateUserAddress(userID, *req)
+	if err != nil {
+		return err
+	}
+
+	return res.Created(ctx, address, res.CreateUserAddressSuccess)
+}
+
+// @Summary      Get Addresses
+// @Description  List the authenticated user's address book, default address first.
+// @Tags         User
+// @Produce      json
+// @Success      200  {object}  res.Res{payload=[]dto.UserAddressResponse} "Get addresses successful"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /users/addresses [get]
+func (h UserHandler) GetUserAddresses(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	addresses, err := h.UserUsecase.GetUserAddresses(userID)
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, addresses, res.GetUserAddressesSuccess)
+}
+
+// @Summary      Get Address
+// @Description  Get an address from the authenticated user's address book.
+// @Tags         User
+// @Produce      json
+// @Param        id   path      string  true  "Address ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=dto.UserAddressResponse} "Get address successful"
+// @Failure      400  {object}  res.Err "Invalid address ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Address not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /users/addresses/{id} [get]
+func (h UserHandler) GetUserAddress(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidUserAddressID)
+	}
+
+	address, resErr := h.UserUsecase.GetUserAddress(userID, id)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, address, res.GetUserAddressSuccess)
+}
+
+// @Summary      Update Address
+// @Description  Replace an address in the authenticated user's address book. Subscriptions placed with it keep the address they were placed with. The default address stays the default until another address is made the default.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string                  true  "Address ID" Format(uuid)
+// @Param        payload body  dto.UserAddressRequest  true  "User Address Request"
+// @Success      200  {object}  res.Res{payload=dto.UserAddressResponse} "Address updated successful"
+// @Failure      400  {object}  res.Err "Invalid address ID, request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Address not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /users/addresses/{id} [put]
+func (h UserHandler) UpdateUserAddress(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidUserAddressID)
+	}
+
+	req := new(dto.UserAddressRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	address, resErr := h.UserUsecase.UpdateUserAddress(userID, id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, address, res.UpdateUserAddressSuccess)
+}
+
+// @Summary      Delete Address
+// @Description  Remove an address from the authenticated user's address book. When it was the default, the oldest remaining address becomes the default.
+// @Tags         User
+// @Produce      json
+// @Param        id   path      string  true  "Address ID" Format(uuid)
+// @Success      200  {object}  res.Res "Address deleted successful"
+// @Failure      400  {object}  res.Err "Invalid address ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      404  {object}  res.Err "Address not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /users/addresses/{id} [delete]
+func (h UserHandler) DeleteUserAddress(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidUserAddressID)
+	}
+
+	if resErr := h.UserUsecase.DeleteUserAddress(userID, id); resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, nil, res.DeleteUserAddressSuccess)
+}
+
+// @Summary      Update User Role
+// @Description  Make a user a courier or an admin, or back into a customer (admin only). The new role applies from the user's next login or token refresh. Admins cannot change their own role.
+// @Tags         User
+// @Accept       json
+// @Produce      json
+// @Param        id      path  string                     true  "User ID" Format(uuid)
+// @Param        payload body  dto.UpdateUserRoleRequest  true  "Update User Role Request"
+// @Success      200  {object}  res.Res{payload=dto.UserResponse} "User role updated successful"
+// @Failure      400  {object}  res.Err "Invalid user ID, request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required or changing own role"
+// @Failure      404  {object}  res.Err "User not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/users/{id}/role [put]
+func (h UserHandler) UpdateUserRole(ctx *fiber.Ctx) error {
+	adminID := ctx.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidUserID)
+	}
+
+	req := new(dto.UpdateUserRoleRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	user, resErr := h.UserUsecase.UpdateUserRole(adminID, id, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, user, res.UpdateUserRoleSuccess)
+}
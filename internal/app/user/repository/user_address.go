@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type UserAddressRepositoryItf interface {
+	WithTx(tx *gorm.DB) UserAddressRepositoryItf
+	CreateUserAddress(address *entity.UserAddress) error
+	UpdateUserAddress(address *entity.UserAddress) error
+	DeleteUserAddress(id uuid.UUID) error
+	GetUserAddresses(userID uuid.UUID) ([]entity.UserAddress, error)
+	GetUserAddressByIDAndUserID(id uuid.UUID, userID uuid.UUID) (*entity.UserAddress, error)
+	ClearDefaultUserAddress(userID uuid.UUID) error
+}
+
+type UserAddressRepository struct {
+	db *gorm.DB
+}
+
+func NewUserAddressRepository(db *gorm.DB) UserAddressRepositoryItf {
+	return &UserAddressRepository{
+		db: db,
+	}
+}
+
+func (r *UserAddressRepository) WithTx(tx *gorm.DB) UserAddressRepositoryItf {
+	return &UserAddressRepository{
+		db: tx,
+	}
+}
+
+func (r *UserAddressRepository) CreateUserAddress(address *entity.UserAddress) error {
+	return r.db.Create(address).Error
+}
+
+func (r *UserAddressRepository) UpdateUserAddress(address *entity.UserAddress) error {
+	return r.db.Save(address).Error
+}
+
+func (r *UserAddressRepository) DeleteUserAddress(id uuid.UUID) error {
+	return r.db.Delete(&entity.UserAddress{}, "id = ?", id).Error
+}
+
+// GetUserAddresses lists a user's address book, default address first and
+// the rest in the order they were added.
+func (r *UserAddressRepository) GetUserAddresses(userID uuid.UUID) ([]entity.UserAddress, error) {
+	var addresses []entity.UserAddress
+	err := r.db.Where("user_id = ?", userID).Order("is_default desc").Order("created_at asc").Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *UserAddressRepository) GetUserAddressByIDAndUserID(id uuid.UUID, userID uuid.UUID) (*entity.UserAddress, error) {
+	var address entity.UserAddress
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&address).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &address, nil
+}
+
+func (r *UserAddressRepository) ClearDefaultUserAddress(userID uuid.UUID) error {
+	return r.db.Model(&entity.UserAddress{}).
+		Where("user_id = ? AND is_default = ?", userID, true).
+		Update("is_default", false).Error
+}
@@ -3,22 +3,34 @@ package usecase
 import (
 	userRepository "github.com/Ablebil/sea-catering-be/internal/app/user/repository"
 	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
 	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type UserUsecaseItf interface {
 	GetProfile(id uuid.UUID) (*dto.UserResponse, *res.Err)
 	RemoveUnverifiedUsers() *res.Err
+	UpdateUserRole(adminID uuid.UUID, id uuid.UUID, req dto.UpdateUserRoleRequest) (*dto.UserResponse, *res.Err)
+	CreateUserAddress(userID uuid.UUID, req dto.UserAddressRequest) (*dto.UserAddressResponse, *res.Err)
+	GetUserAddresses(userID uuid.UUID) ([]dto.UserAddressResponse, *res.Err)
+	GetUserAddress(userID uuid.UUID, id uuid.UUID) (*dto.UserAddressResponse, *res.Err)
+	UpdateUserAddress(userID uuid.UUID, id uuid.UUID, req dto.UserAddressRequest) (*dto.UserAddressResponse, *res.Err)
+	DeleteUserAddress(userID uuid.UUID, id uuid.UUID) *res.Err
 }
 
 type UserUsecase struct {
-	UserRepository userRepository.UserRepositoryItf
+	UserRepository        userRepository.UserRepositoryItf
+	UserAddressRepository userRepository.UserAddressRepositoryItf
+	db                    *gorm.DB
 }
 
-func NewUserUsecase(userRepository userRepository.UserRepositoryItf) UserUsecaseItf {
+func NewUserUsecase(userRepository userRepository.UserRepositoryItf, userAddressRepository userRepository.UserAddressRepositoryItf, db *gorm.DB) UserUsecaseItf {
 	return &UserUsecase{
-		UserRepository: userRepository,
+		UserRepository:        userRepository,
+		UserAddressRepository: userAddressRepository,
+		db:                    db,
 	}
 }
 
@@ -35,6 +47,7 @@ func (uc *UserUsecase) GetProfile(id uuid.UUID) (*dto.UserResponse, *res.Err) {
 	result := &dto.UserResponse{
 		Name:  user.Name,
 		Email: user.Email,
+		Role:  string(user.Role),
 	}
 
 	return result, nil
@@ -47,3 +60,33 @@ func (uc *UserUsecase) RemoveUnverifiedUsers() *res.Err {
 
 	return nil
 }
+
+// UpdateUserRole makes a user a courier or an admin, or back into a customer.
+// Roles travel in access tokens, so the change takes effect the next time the
+// user logs in or refreshes their token. Admins cannot change their own role,
+// which keeps the last admin from locking everyone out.
+func (uc *UserUsecase) UpdateUserRole(adminID uuid.UUID, id uuid.UUID, req dto.UpdateUserRoleRequest) (*dto.UserResponse, *res.Err) {
+	if adminID == id {
+		return nil, res.ErrForbidden(res.CannotChangeOwnRole)
+	}
+
+	user, err := uc.UserRepository.GetUserByID(id)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetUserProfile)
+	}
+
+	if user == nil {
+		return nil, res.ErrNotFound(res.UserNotFound)
+	}
+
+	user.Role = entity.UserRole(req.Role)
+	if err := uc.UserRepository.UpdateUser(user.Email, &entity.User{Role: user.Role}); err != nil {
+		return nil, res.ErrInternalServerError(res.FailedUpdateUserRole)
+	}
+
+	return &dto.UserResponse{
+		Name:  user.Name,
+		Email: user.Email,
+		Role:  string(user.Role),
+	}, nil
+}
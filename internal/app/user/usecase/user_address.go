@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CreateUserAddress adds an address to a user's address book. The first
+// address a user saves becomes their default.
+func (uc *UserUsecase) CreateUserAddress(userID uuid.UUID, req dto.UserAddressRequest) (*dto.UserAddressResponse, *res.Err) {
+	address := &entity.UserAddress{UserID: userID}
+	fillUserAddress(address, req)
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		addressRepo := uc.UserAddressRepository.WithTx(tx)
+
+		existing, err := addressRepo.GetUserAddresses(userID)
+		if err != nil {
+			return err
+		}
+
+		if len(existing) == 0 {
+			address.IsDefault = true
+		}
+
+		if address.IsDefault {
+			if err := addressRepo.ClearDefaultUserAddress(userID); err != nil {
+				return err
+			}
+		}
+
+		return addressRepo.CreateUserAddress(address)
+	})
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSaveUserAddress)
+	}
+
+	return toUserAddressResponse(address), nil
+}
+
+func (uc *UserUsecase) GetUserAddresses(userID uuid.UUID) ([]dto.UserAddressResponse, *res.Err) {
+	addresses, err := uc.UserAddressRepository.GetUserAddresses(userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetUserAddresses)
+	}
+
+	result := make([]dto.UserAddressResponse, 0, len(addresses))
+	for i := range addresses {
+		result = append(result, *toUserAddressResponse(&addresses[i]))
+	}
+
+	return result, nil
+}
+
+func (uc *UserUsecase) GetUserAddress(userID uuid.UUID, id uuid.UUID) (*dto.UserAddressResponse, *res.Err) {
+	address, err := uc.UserAddressRepository.GetUserAddressByIDAndUserID(id, userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetUserAddresses)
+	}
+
+	if address == nil {
+		return nil, res.ErrNotFound(res.UserAddressNotFound)
+	}
+
+	return toUserAddressResponse(address), nil
+}
+
+// UpdateUserAddress replaces an address book entry. Subscriptions placed
+// with it keep the address they copied. The default moves only by making
+// another address the default, so a user always has one.
+func (uc *UserUsecase) UpdateUserAddress(userID uuid.UUID, id uuid.UUID, req dto.UserAddressRequest) (*dto.UserAddressResponse, *res.Err) {
+	var address *entity.UserAddress
+	var resErr *res.Err
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		addressRepo := uc.UserAddressRepository.WithTx(tx)
+
+		var err error
+		address, err = addressRepo.GetUserAddressByIDAndUserID(id, userID)
+		if err != nil {
+			return err
+		}
+
+		if address == nil {
+			resErr = res.ErrNotFound(res.UserAddressNotFound)
+			return resErr
+		}
+
+		wasDefault := address.IsDefault
+		fillUserAddress(address, req)
+
+		if address.IsDefault && !wasDefault {
+			if err := addressRepo.ClearDefaultUserAddress(userID); err != nil {
+				return err
+			}
+		}
+
+		address.IsDefault = address.IsDefault || wasDefault
+
+		return addressRepo.UpdateUserAddress(address)
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedSaveUserAddress)
+	}
+
+	return toUserAddressResponse(address), nil
+}
+
+// DeleteUserAddress removes an address book entry. When it was the default,
+// the oldest remaining address takes its place.
+func (uc *UserUsecase) DeleteUserAddress(userID uuid.UUID, id uuid.UUID) *res.Err {
+	var resErr *res.Err
+
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		addressRepo := uc.UserAddressRepository.WithTx(tx)
+
+		address, err := addressRepo.GetUserAddressByIDAndUserID(id, userID)
+		if err != nil {
+			return err
+		}
+
+		if address == nil {
+			resErr = res.ErrNotFound(res.UserAddressNotFound)
+			return resErr
+		}
+
+		if err := addressRepo.DeleteUserAddress(address.ID); err != nil {
+			return err
+		}
+
+		if !address.IsDefault {
+			return nil
+		}
+
+		remaining, err := addressRepo.GetUserAddresses(userID)
+		if err != nil || len(remaining) == 0 {
+			return err
+		}
+
+		remaining[0].IsDefault = true
+		return addressRepo.UpdateUserAddress(&remaining[0])
+	})
+
+	if resErr != nil {
+		return resErr
+	}
+
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedDeleteUserAddress)
+	}
+
+	return nil
+}
+
+func fillUserAddress(address *entity.UserAddress, req dto.UserAddressRequest) {
+	address.Label = strings.TrimSpace(req.Label)
+	address.RecipientName = req.RecipientName
+	address.PhoneNumber = req.PhoneNumber
+	address.Address = req.Address
+	address.City = strings.TrimSpace(req.City)
+	address.PostalCode = req.PostalCode
+	address.Latitude = req.Latitude
+	address.Longitude = req.Longitude
+	address.Notes = req.Notes
+	address.IsDefault = req.IsDefault
+}
+
+func toUserAddressResponse(address *entity.UserAddress) *dto.UserAddressResponse {
+	var createdAt time.Time
+	if address.CreatedAt != nil {
+		createdAt = *address.CreatedAt
+	}
+
+	return &dto.UserAddressResponse{
+		ID:            address.ID,
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		PhoneNumber:   address.PhoneNumber,
+		Address:       address.Address,
+		City:          address.City,
+		PostalCode:    address.PostalCode,
+		Latitude:      address.Latitude,
+		Longitude:     address.Longitude,
+		Notes:         address.Notes,
+		IsDefault:     address.IsDefault,
+		CreatedAt:     createdAt,
+	}
+}
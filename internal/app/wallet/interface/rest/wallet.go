@@ -0,0 +1,122 @@
+package rest
+
+import (
+	"github.com/Ablebil/sea-catering-be/internal/app/wallet/usecase"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/middleware"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type WalletHandler struct {
+	Validator     *validator.Validate
+	WalletUsecase usecase.WalletUsecaseItf
+}
+
+func NewWalletHandler(routerGroup fiber.Router, validator *validator.Validate, walletUsecase usecase.WalletUsecaseItf, middleware middleware.MiddlewareItf) {
+	walletHandler := WalletHandler{
+		Validator:     validator,
+		WalletUsecase: walletUsecase,
+	}
+
+	routerGroup.Get("/wallet", middleware.Authentication, walletHandler.GetWallet)
+
+	adminRouterGroup := routerGroup.Group("/admin/wallets", middleware.Authentication, middleware.Authorization)
+	adminRouterGroup.Get("/:userId", walletHandler.GetUserWallet)
+	adminRouterGroup.Post("/:userId/adjustments", walletHandler.AdjustWallet)
+}
+
+// @Summary      Get Wallet
+// @Description  Retrieve the store credit balance of the authenticated user together with its ledger, newest first. Refunds and skipped deliveries are credited here and can be spent on a new subscription.
+// @Tags         Wallet
+// @Produce      json
+// @Success      200  {object}  res.Res{payload=dto.WalletResponse} "Get wallet successful"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /wallet [get]
+func (h WalletHandler) GetWallet(ctx *fiber.Ctx) error {
+	userID := ctx.Locals("userID").(uuid.UUID)
+
+	wallet, err := h.WalletUsecase.GetWallet(userID)
+	if err != nil {
+		return err
+	}
+
+	return res.OK(ctx, wallet, res.GetWalletSuccess)
+}
+
+// @Summary      Get User Wallet
+// @Description  Retrieve the store credit balance and ledger of any user (admin only).
+// @Tags         Wallet
+// @Produce      json
+// @Param        userId path      string  true  "User ID" Format(uuid)
+// @Success      200  {object}  res.Res{payload=dto.WalletResponse} "Get wallet successful"
+// @Failure      400  {object}  res.Err "Invalid user ID"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "User not found"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/wallets/{userId} [get]
+func (h WalletHandler) GetUserWallet(ctx *fiber.Ctx) error {
+	userID, err := uuid.Parse(ctx.Params("userId"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidUserID)
+	}
+
+	wallet, resErr := h.WalletUsecase.GetUserWallet(userID)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.OK(ctx, wallet, res.GetWalletSuccess)
+}
+
+// @Summary      Adjust Wallet
+// @Description  Credit or debit a user's wallet by hand, e.g. as a goodwill gesture (admin only). The note and the admin are recorded in the ledger.
+// @Tags         Wallet
+// @Accept       json
+// @Produce      json
+// @Param        userId  path  string                   true  "User ID" Format(uuid)
+// @Param        payload body  dto.AdjustWalletRequest  true  "Adjust Wallet Request"
+// @Success      201  {object}  res.Res{payload=dto.WalletTransactionResponse} "Wallet adjusted successful"
+// @Failure      400  {object}  res.Err "Invalid user ID, request body or validation error"
+// @Failure      401  {object}  res.Err "Missing or invalid access token"
+// @Failure      403  {object}  res.Err "Admin access required"
+// @Failure      404  {object}  res.Err "User not found"
+// @Failure      409  {object}  res.Err "Wallet balance is too low"
+// @Failure      500  {object}  res.Err "Internal Server Error"
+// @Security     ApiKeyAuth
+// @Router       /admin/wallets/{userId}/adjustments [post]
+func (h WalletHandler) AdjustWallet(ctx *fiber.Ctx) error {
+	adminID := ctx.Locals("userID").(uuid.UUID)
+
+	userID, err := uuid.Parse(ctx.Params("userId"))
+	if err != nil {
+		return res.ErrBadRequest(res.InvalidUserID)
+	}
+
+	req := new(dto.AdjustWalletRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		return res.ErrBadRequest(res.FailedParsingRequestBody)
+	}
+
+	if err := h.Validator.Struct(req); err != nil {
+		validationErrors, ok := err.(validator.ValidationErrors)
+		if !ok {
+			return res.ErrInternalServerError(res.FailedValidateRequest)
+		}
+
+		return res.ErrValidation(validationErrors)
+	}
+
+	transaction, resErr := h.WalletUsecase.AdjustWallet(adminID, userID, *req)
+	if resErr != nil {
+		return resErr
+	}
+
+	return res.Created(ctx, transaction, res.AdjustWalletSuccess)
+}
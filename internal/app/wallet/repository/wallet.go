@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WalletRepositoryItf interface {
+	WithTx(tx *gorm.DB) WalletRepositoryItf
+	GetWalletByUserID(userID uuid.UUID) (*entity.Wallet, error)
+	GetWalletByUserIDForUpdate(userID uuid.UUID) (*entity.Wallet, error)
+	GetTransactionsByUserID(userID uuid.UUID) ([]entity.WalletTransaction, error)
+	GetTransactionByReference(userID uuid.UUID, reason entity.WalletReason, reference string) (*entity.WalletTransaction, error)
+	Credit(entry *entity.WalletTransaction) error
+	Debit(entry *entity.WalletTransaction) (bool, error)
+}
+
+type WalletRepository struct {
+	db *gorm.DB
+}
+
+func NewWalletRepository(db *gorm.DB) WalletRepositoryItf {
+	return &WalletRepository{
+		db: db,
+	}
+}
+
+func (r *WalletRepository) WithTx(tx *gorm.DB) WalletRepositoryItf {
+	return &WalletRepository{
+		db: tx,
+	}
+}
+
+func (r *WalletRepository) GetWalletByUserID(userID uuid.UUID) (*entity.Wallet, error) {
+	var wallet entity.Wallet
+	err := r.db.Where("user_id = ?", userID).First(&wallet).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &wallet, nil
+}
+
+// GetWalletByUserIDForUpdate locks the user's wallet until the surrounding
+// transaction ends, opening it first if the user has none yet. It must be
+// called on a repository from WithTx.
+func (r *WalletRepository) GetWalletByUserIDForUpdate(userID uuid.UUID) (*entity.Wallet, error) {
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoNothing: true,
+	}).Create(&entity.Wallet{UserID: userID}).Error; err != nil {
+		return nil, err
+	}
+
+	var wallet entity.Wallet
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ?", userID).First(&wallet).Error
+	return &wallet, err
+}
+
+func (r *WalletRepository) GetTransactionsByUserID(userID uuid.UUID) ([]entity.WalletTransaction, error) {
+	var transactions []entity.WalletTransaction
+	err := r.db.Where("user_id = ?", userID).Order("created_at desc").Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *WalletRepository) GetTransactionByReference(userID uuid.UUID, reason entity.WalletReason, reference string) (*entity.WalletTransaction, error) {
+	var transaction entity.WalletTransaction
+	err := r.db.Where("user_id = ? AND reason = ? AND reference = ?", userID, reason, reference).First(&transaction).Error
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &transaction, nil
+}
+
+// Credit adds entry.Amount to the wallet of entry.UserID and appends entry to
+// its ledger. It must be called on a repository from WithTx.
+func (r *WalletRepository) Credit(entry *entity.WalletTransaction) error {
+	wallet, err := r.GetWalletByUserIDForUpdate(entry.UserID)
+	if err != nil {
+		return err
+	}
+
+	entry.Type = entity.WalletCredit
+	wallet.Balance += entry.Amount
+	return r.post(wallet, entry)
+}
+
+// Debit takes entry.Amount off the wallet of entry.UserID and appends entry
+// to its ledger. It reports false and changes nothing when the balance is
+// short. It must be called on a repository from WithTx.
+func (r *WalletRepository) Debit(entry *entity.WalletTransaction) (bool, error) {
+	wallet, err := r.GetWalletByUserIDForUpdate(entry.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	if wallet.Balance < entry.Amount {
+		return false, nil
+	}
+
+	entry.Type = entity.WalletDebit
+	wallet.Balance -= entry.Amount
+	return true, r.post(wallet, entry)
+}
+
+func (r *WalletRepository) post(wallet *entity.Wallet, entry *entity.WalletTransaction) error {
+	if err := r.db.Save(wallet).Error; err != nil {
+		return err
+	}
+
+	entry.WalletID = wallet.ID
+	entry.BalanceAfter = wallet.Balance
+	return r.db.Create(entry).Error
+}
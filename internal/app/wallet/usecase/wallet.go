@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	userRepository "github.com/Ablebil/sea-catering-be/internal/app/user/repository"
+	walletRepository "github.com/Ablebil/sea-catering-be/internal/app/wallet/repository"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/domain/entity"
+	res "github.com/Ablebil/sea-catering-be/internal/infra/response"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WalletUsecaseItf interface {
+	GetWallet(userID uuid.UUID) (*dto.WalletResponse, *res.Err)
+	GetUserWallet(userID uuid.UUID) (*dto.WalletResponse, *res.Err)
+	AdjustWallet(adminID uuid.UUID, userID uuid.UUID, req dto.AdjustWalletRequest) (*dto.WalletTransactionResponse, *res.Err)
+}
+
+type WalletUsecase struct {
+	WalletRepository walletRepository.WalletRepositoryItf
+	UserRepository   userRepository.UserRepositoryItf
+	db               *gorm.DB
+}
+
+func NewWalletUsecase(walletRepository walletRepository.WalletRepositoryItf, userRepository userRepository.UserRepositoryItf, db *gorm.DB) WalletUsecaseItf {
+	return &WalletUsecase{
+		WalletRepository: walletRepository,
+		UserRepository:   userRepository,
+		db:               db,
+	}
+}
+
+// GetWallet returns the balance and ledger of a user's wallet. A user who
+// never received credit has an empty wallet.
+func (uc *WalletUsecase) GetWallet(userID uuid.UUID) (*dto.WalletResponse, *res.Err) {
+	wallet, err := uc.WalletRepository.GetWalletByUserID(userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetWallet)
+	}
+
+	transactions, err := uc.WalletRepository.GetTransactionsByUserID(userID)
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedGetWallet)
+	}
+
+	resp := &dto.WalletResponse{
+		UserID:       userID,
+		Transactions: make([]dto.WalletTransactionResponse, 0, len(transactions)),
+	}
+
+	if wallet != nil {
+		resp.Balance = wallet.Balance
+	}
+
+	for i := range transactions {
+		resp.Transactions = append(resp.Transactions, toWalletTransactionResponse(&transactions[i]))
+	}
+
+	return resp, nil
+}
+
+func (uc *WalletUsecase) GetUserWallet(userID uuid.UUID) (*dto.WalletResponse, *res.Err) {
+	if resErr := uc.checkUser(userID); resErr != nil {
+		return nil, resErr
+	}
+
+	return uc.GetWallet(userID)
+}
+
+// AdjustWallet credits or debits a user's wallet by hand, for goodwill
+// gestures and corrections. The note and the admin are kept in the ledger.
+func (uc *WalletUsecase) AdjustWallet(adminID uuid.UUID, userID uuid.UUID, req dto.AdjustWalletRequest) (*dto.WalletTransactionResponse, *res.Err) {
+	if resErr := uc.checkUser(userID); resErr != nil {
+		return nil, resErr
+	}
+
+	entry := &entity.WalletTransaction{
+		UserID:    userID,
+		Amount:    pricing.FromFloat(req.Amount).Float64(),
+		Reason:    entity.WalletAdjustment,
+		Reference: req.Reference,
+		Note:      &req.Note,
+		CreatedBy: &adminID,
+	}
+
+	var resErr *res.Err
+	err := uc.db.Transaction(func(tx *gorm.DB) error {
+		walletRepo := uc.WalletRepository.WithTx(tx)
+
+		if req.Type == string(entity.WalletCredit) {
+			return walletRepo.Credit(entry)
+		}
+
+		ok, err := walletRepo.Debit(entry)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			resErr = res.ErrConflict(res.InsufficientWalletBalance)
+			return resErr
+		}
+
+		return nil
+	})
+
+	if resErr != nil {
+		return nil, resErr
+	}
+
+	if err != nil {
+		return nil, res.ErrInternalServerError(res.FailedAdjustWallet)
+	}
+
+	resp := toWalletTransactionResponse(entry)
+	return &resp, nil
+}
+
+func (uc *WalletUsecase) checkUser(userID uuid.UUID) *res.Err {
+	user, err := uc.UserRepository.GetUserByID(userID)
+	if err != nil {
+		return res.ErrInternalServerError(res.FailedFindUser)
+	}
+
+	if user == nil {
+		return res.ErrNotFound(res.UserNotFound)
+	}
+
+	return nil
+}
+
+func toWalletTransactionResponse(t *entity.WalletTransaction) dto.WalletTransactionResponse {
+	return dto.WalletTransactionResponse{
+		ID:           t.ID,
+		Type:         string(t.Type),
+		Amount:       t.Amount,
+		BalanceAfter: t.BalanceAfter,
+		Reason:       string(t.Reason),
+		Reference:    t.Reference,
+		Note:         t.Note,
+		CreatedAt:    t.CreatedAt,
+	}
+}
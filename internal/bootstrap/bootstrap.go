@@ -1,22 +1,29 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	conf "github.com/Ablebil/sea-catering-be/config"
 	"github.com/Ablebil/sea-catering-be/internal/infra/email"
+	"github.com/Ablebil/sea-catering-be/internal/infra/event"
 	"github.com/Ablebil/sea-catering-be/internal/infra/fiber"
+	"github.com/Ablebil/sea-catering-be/internal/infra/geocoder"
 	"github.com/Ablebil/sea-catering-be/internal/infra/jwt"
-	"github.com/Ablebil/sea-catering-be/internal/infra/midtrans"
 	"github.com/Ablebil/sea-catering-be/internal/infra/oauth"
+	"github.com/Ablebil/sea-catering-be/internal/infra/payment"
 	"github.com/Ablebil/sea-catering-be/internal/infra/postgresql"
 	"github.com/Ablebil/sea-catering-be/internal/infra/redis"
 	"github.com/Ablebil/sea-catering-be/internal/infra/supabase"
 	"github.com/Ablebil/sea-catering-be/internal/middleware"
 	"github.com/Ablebil/sea-catering-be/internal/pkg/helper"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pricing"
 	"github.com/Ablebil/sea-catering-be/internal/pkg/scheduler"
-	"github.com/go-playground/validator/v10"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/validation"
 	"github.com/gofiber/swagger"
 
 	AuthHandler "github.com/Ablebil/sea-catering-be/internal/app/auth/interface/rest"
@@ -34,9 +41,43 @@ import (
 	MealPlanRepository "github.com/Ablebil/sea-catering-be/internal/app/meal_plan/repository"
 	MealPlanUsecase "github.com/Ablebil/sea-catering-be/internal/app/meal_plan/usecase"
 
+	PaymentHandler "github.com/Ablebil/sea-catering-be/internal/app/payment/interface/rest"
+	PaymentRepository "github.com/Ablebil/sea-catering-be/internal/app/payment/repository"
+	PaymentUsecase "github.com/Ablebil/sea-catering-be/internal/app/payment/usecase"
+
 	SubscriptionHandler "github.com/Ablebil/sea-catering-be/internal/app/subscription/interface/rest"
 	SubscriptionRepository "github.com/Ablebil/sea-catering-be/internal/app/subscription/repository"
 	SubscriptionUsecase "github.com/Ablebil/sea-catering-be/internal/app/subscription/usecase"
+
+	DeliveryHandler "github.com/Ablebil/sea-catering-be/internal/app/delivery/interface/rest"
+	DeliveryRepository "github.com/Ablebil/sea-catering-be/internal/app/delivery/repository"
+	DeliveryUsecase "github.com/Ablebil/sea-catering-be/internal/app/delivery/usecase"
+
+	PromoHandler "github.com/Ablebil/sea-catering-be/internal/app/promo/interface/rest"
+	PromoRepository "github.com/Ablebil/sea-catering-be/internal/app/promo/repository"
+	PromoUsecase "github.com/Ablebil/sea-catering-be/internal/app/promo/usecase"
+
+	RefundHandler "github.com/Ablebil/sea-catering-be/internal/app/refund/interface/rest"
+	RefundRepository "github.com/Ablebil/sea-catering-be/internal/app/refund/repository"
+	RefundUsecase "github.com/Ablebil/sea-catering-be/internal/app/refund/usecase"
+
+	WalletHandler "github.com/Ablebil/sea-catering-be/internal/app/wallet/interface/rest"
+	WalletRepository "github.com/Ablebil/sea-catering-be/internal/app/wallet/repository"
+	WalletUsecase "github.com/Ablebil/sea-catering-be/internal/app/wallet/usecase"
+
+	GiftHandler "github.com/Ablebil/sea-catering-be/internal/app/gift/interface/rest"
+	GiftRepository "github.com/Ablebil/sea-catering-be/internal/app/gift/repository"
+	GiftUsecase "github.com/Ablebil/sea-catering-be/internal/app/gift/usecase"
+
+	DeliveryZoneHandler "github.com/Ablebil/sea-catering-be/internal/app/delivery_zone/interface/rest"
+	DeliveryZoneRepository "github.com/Ablebil/sea-catering-be/internal/app/delivery_zone/repository"
+	DeliveryZoneUsecase "github.com/Ablebil/sea-catering-be/internal/app/delivery_zone/usecase"
+
+	KitchenHandler "github.com/Ablebil/sea-catering-be/internal/app/kitchen/interface/rest"
+	KitchenRepository "github.com/Ablebil/sea-catering-be/internal/app/kitchen/repository"
+	KitchenUsecase "github.com/Ablebil/sea-catering-be/internal/app/kitchen/usecase"
+
+	EventHandler "github.com/Ablebil/sea-catering-be/internal/app/event/interface/rest"
 )
 
 func Start() error {
@@ -65,27 +106,44 @@ func Start() error {
 		log.Printf("Failed to seed database: %v", err)
 	}
 
-	validator := validator.New()
+	// ctx is cancelled on SIGINT or SIGTERM, which shuts the server down.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	validator := validation.New()
 	jwt := jwt.NewJWT(config)
 	email := email.NewEmail(config)
 	redis := redis.NewRedis(config)
+	event := event.NewEvent(ctx, redis)
 	oauth := oauth.NewOAuth(config)
 	supabase := supabase.NewSupabase(config)
-	midtrans := midtrans.NewMidtrans(config)
+	paymentGateway := payment.NewPaymentGateway(config)
+	geocoder := geocoder.NewGeocoder(config)
 	middleware := middleware.NewMiddleware(jwt)
 	helper := helper.NewHelper()
+	zoneDeliveryFee, err := pricing.NewZoneDeliveryFee(config.DeliveryZoneFees)
+	if err != nil {
+		panic(err)
+	}
+
+	pricing := pricing.NewPricing(zoneDeliveryFee, pricing.NewTax(fmt.Sprintf("PPN %g%%", config.TaxRate), config.TaxRate))
 
 	app := fiber.New(config)
 	v1 := app.Group("/api/v1")
 
+	if fakeGateway, ok := paymentGateway.(*payment.Fake); ok {
+		fakeGateway.RegisterRoutes(app)
+	}
+
 	// Auth Domain
 	userRepository := UserRepository.NewUserRepository(db)
 	authUsecase := AuthUsecase.NewAuthUsecase(userRepository, db, config, jwt, email, redis, oauth)
 	AuthHandler.NewAuthHandler(v1, validator, authUsecase, config)
 
 	// User Domain
-	userUsecase := UserUsecase.NewUserUsecase(userRepository)
-	UserHandler.NewUserHandler(v1, userUsecase, middleware)
+	userAddressRepository := UserRepository.NewUserAddressRepository(db)
+	userUsecase := UserUsecase.NewUserUsecase(userRepository, userAddressRepository, db)
+	UserHandler.NewUserHandler(v1, validator, userUsecase, middleware)
 
 	// Testimonial Domain
 	testimonialRepository := TestimonialRepository.NewTestimonialRepository(db)
@@ -99,14 +157,90 @@ func Start() error {
 
 	// Subscription Domain
 	subscriptionRepository := SubscriptionRepository.NewSubscriptionRepository(db)
-	subscriptionUsecase := SubscriptionUsecase.NewSubscriptionUsecase(subscriptionRepository, mealPlanRepository, midtrans, helper)
+	billingPeriodRepository := SubscriptionRepository.NewBillingPeriodRepository(db)
+	subscriptionChangeRepository := SubscriptionRepository.NewSubscriptionChangeRepository(db)
+	subscriptionAddressChangeRepository := SubscriptionRepository.NewSubscriptionAddressChangeRepository(db)
+	paymentRepository := PaymentRepository.NewPaymentRepository(db)
+	deliveryRepository := DeliveryRepository.NewDeliveryRepository(db)
+	promoRepository := PromoRepository.NewPromoRepository(db)
+	promoRedemptionRepository := PromoRepository.NewPromoRedemptionRepository(db)
+	invoiceRepository := PaymentRepository.NewInvoiceRepository(db)
+	refundRepository := RefundRepository.NewRefundRepository(db)
+	reconciliationRepository := PaymentRepository.NewReconciliationRepository(db)
+	walletRepository := WalletRepository.NewWalletRepository(db)
+	giftRepository := GiftRepository.NewGiftRepository(db)
+	deliveryZoneRepository := DeliveryZoneRepository.NewDeliveryZoneRepository(db)
+	subscriptionUsecase := SubscriptionUsecase.NewSubscriptionUsecase(SubscriptionUsecase.SubscriptionRepositories{
+		Subscription:              subscriptionRepository,
+		MealPlan:                  mealPlanRepository,
+		Payment:                   paymentRepository,
+		BillingPeriod:             billingPeriodRepository,
+		SubscriptionChange:        subscriptionChangeRepository,
+		Delivery:                  deliveryRepository,
+		Promo:                     promoRepository,
+		PromoRedemption:           promoRedemptionRepository,
+		Invoice:                   invoiceRepository,
+		Refund:                    refundRepository,
+		Reconciliation:            reconciliationRepository,
+		Wallet:                    walletRepository,
+		Gift:                      giftRepository,
+		DeliveryZone:              deliveryZoneRepository,
+		UserAddress:               userAddressRepository,
+		SubscriptionAddressChange: subscriptionAddressChangeRepository,
+	}, db, config, paymentGateway, geocoder, email, supabase, event, helper, pricing)
 	SubscriptionHandler.NewSubscriptionHandler(v1, validator, subscriptionUsecase, middleware)
 
+	// Payment Domain
+	paymentUsecase := PaymentUsecase.NewPaymentUsecase(paymentRepository, invoiceRepository, reconciliationRepository, subscriptionRepository, helper)
+	PaymentHandler.NewPaymentHandler(v1, validator, paymentUsecase, middleware)
+
+	// Delivery Domain
+	deliveryUsecase := DeliveryUsecase.NewDeliveryUsecase(deliveryRepository, subscriptionRepository, walletRepository, db, config, supabase, event, helper)
+	DeliveryHandler.NewDeliveryHandler(v1, validator, deliveryUsecase, middleware, helper, config)
+
+	// Promo Domain
+	promoUsecase := PromoUsecase.NewPromoUsecase(promoRepository, promoRedemptionRepository, mealPlanRepository, db, helper)
+	PromoHandler.NewPromoHandler(v1, validator, promoUsecase, middleware)
+
+	// Refund Domain
+	refundUsecase := RefundUsecase.NewRefundUsecase(refundRepository, subscriptionRepository, walletRepository, db, paymentGateway)
+	RefundHandler.NewRefundHandler(v1, validator, refundUsecase, middleware)
+
+	// Wallet Domain
+	walletUsecase := WalletUsecase.NewWalletUsecase(walletRepository, userRepository, db)
+	WalletHandler.NewWalletHandler(v1, validator, walletUsecase, middleware)
+
+	// Gift Domain
+	giftUsecase := GiftUsecase.NewGiftUsecase(giftRepository, subscriptionRepository, db)
+	GiftHandler.NewGiftHandler(v1, validator, giftUsecase, middleware)
+
+	// Delivery Zone Domain
+	deliveryZoneUsecase := DeliveryZoneUsecase.NewDeliveryZoneUsecase(deliveryZoneRepository)
+	DeliveryZoneHandler.NewDeliveryZoneHandler(v1, validator, deliveryZoneUsecase, middleware)
+
+	// Kitchen Domain
+	kitchenRepository := KitchenRepository.NewKitchenRepository(db)
+	kitchenUsecase := KitchenUsecase.NewKitchenUsecase(kitchenRepository)
+	KitchenHandler.NewKitchenHandler(v1, validator, kitchenUsecase, middleware)
+
+	// Event Domain
+	EventHandler.NewEventHandler(v1, event, middleware)
+
 	scheduler := scheduler.NewScheduler(subscriptionUsecase, userUsecase)
 	scheduler.Start()
 
 	// Swagger Documentation
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
+	// Open event streams end once ctx is done, so Shutdown does not wait on
+	// them.
+	go func() {
+		<-ctx.Done()
+		scheduler.Stop()
+		if err := app.Shutdown(); err != nil {
+			log.Printf("Failed to shut down server: %v", err)
+		}
+	}()
+
 	return app.Listen(fmt.Sprintf("%s:%d", config.AppHost, config.AppPort))
 }
@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetDeliveriesRequest struct {
+	StartDate string `query:"start_date" validate:"required_with=EndDate,omitempty,datetime=2006-01-02" example:"2025-01-01"`
+	EndDate   string `query:"end_date" validate:"required_with=StartDate,omitempty,datetime=2006-01-02" example:"2025-01-31"`
+}
+
+type GetDeliveryManifestRequest struct {
+	Date string `query:"date" validate:"required,datetime=2006-01-02" example:"2025-01-15"`
+}
+
+type GetRouteManifestRequest struct {
+	Date     string `query:"date" validate:"required,datetime=2006-01-02" example:"2025-01-15"`
+	ZoneID   string `query:"zone_id" validate:"omitempty,uuid" example:"b3e1f8e2..."`
+	MealType string `query:"meal_type" validate:"omitempty,oneof=breakfast lunch dinner" example:"lunch"`
+}
+
+type UpdateDeliveryStatusRequest struct {
+	Status        string `json:"status" form:"status" validate:"required,oneof=picked_up delivered failed" example:"delivered"`
+	FailureReason string `json:"failure_reason" form:"failure_reason" validate:"required_if=Status failed,omitempty,max=255" example:"Nobody at home"`
+}
+
+type DeliveryResponse struct {
+	ID             uuid.UUID  `json:"id" example:"b3e1f8e2..."`
+	SubscriptionID uuid.UUID  `json:"subscription_id" example:"b3e1f8e2..."`
+	DeliveryDate   time.Time  `json:"delivery_date" example:"2025-01-15"`
+	MealType       string     `json:"meal_type" example:"lunch"`
+	Status         string     `json:"status" example:"delivered"`
+	CreditAmount   float64    `json:"credit_amount" example:"0"`
+	PickedUpAt     *time.Time `json:"picked_up_at" example:"2025-01-15T10:30:00+07:00"`
+	DeliveredAt    *time.Time `json:"delivered_at" example:"2025-01-15T11:45:00+07:00"`
+	FailedAt       *time.Time `json:"failed_at"`
+	FailureReason  *string    `json:"failure_reason"`
+	ProofPhotoURL  *string    `json:"proof_photo_url" example:"https://..."`
+}
+
+type DeliveryManifestItem struct {
+	DeliveryID      uuid.UUID `json:"delivery_id" example:"b3e1f8e2..."`
+	SubscriptionID  uuid.UUID `json:"subscription_id" example:"b3e1f8e2..."`
+	Name            string    `json:"name" example:"John Doe"`
+	PhoneNumber     string    `json:"phone_number" example:"08123456789"`
+	DeliveryAddress string    `json:"delivery_address" example:"123 Main St, Jakarta"`
+	DeliveryNotes   *string   `json:"delivery_notes" example:"Please leave at the front door"`
+	Allergies       *string   `json:"allergies" example:"Peanuts, Shellfish"`
+	MealPlan        string    `json:"meal_plan" example:"Protein Plan"`
+	MealType        string    `json:"meal_type" example:"lunch"`
+	Status          string    `json:"status" example:"scheduled"`
+}
+
+type DeliveryManifestResponse struct {
+	Date       string                 `json:"date" example:"2025-01-15"`
+	Total      int                    `json:"total" example:"42"`
+	Deliveries []DeliveryManifestItem `json:"deliveries"`
+}
+
+type RouteStopMeal struct {
+	DeliveryID uuid.UUID `json:"delivery_id" example:"b3e1f8e2..."`
+	MealType   string    `json:"meal_type" example:"lunch"`
+	Status     string    `json:"status" example:"scheduled"`
+}
+
+type RouteStop struct {
+	SubscriptionID  uuid.UUID       `json:"subscription_id" example:"b3e1f8e2..."`
+	Name            string          `json:"name" example:"John Doe"`
+	PhoneNumber     string          `json:"phone_number" example:"08123456789"`
+	DeliveryAddress string          `json:"delivery_address" example:"123 Main St, Jakarta"`
+	City            *string         `json:"city" example:"Jakarta Selatan"`
+	PostalCode      *string         `json:"postal_code" example:"12110"`
+	Latitude        *float64        `json:"latitude" example:"-6.2443"`
+	Longitude       *float64        `json:"longitude" example:"106.8007"`
+	DeliveryNotes   *string         `json:"delivery_notes" example:"Please leave at the front door"`
+	Allergies       *string         `json:"allergies" example:"Peanuts, Shellfish"`
+	MealPlan        string          `json:"meal_plan" example:"Protein Plan"`
+	Meals           []RouteStopMeal `json:"meals"`
+}
+
+type RouteZone struct {
+	ZoneID   *uuid.UUID  `json:"zone_id" example:"b3e1f8e2..."`
+	ZoneName string      `json:"zone_name" example:"Jakarta Selatan"`
+	Total    int         `json:"total" example:"12"`
+	Stops    []RouteStop `json:"stops"`
+}
+
+type RouteManifestResponse struct {
+	Date  string      `json:"date" example:"2025-01-15"`
+	Total int         `json:"total" example:"42"`
+	Zones []RouteZone `json:"zones"`
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryZoneRequest describes where a zone delivers. Postal codes match
+// every code they prefix, so "171" covers all of 171xx; the polygon is a list
+// of [latitude, longitude] points.
+type DeliveryZoneRequest struct {
+	Name          string      `json:"name" validate:"required,max=100" example:"Bekasi"`
+	City          string      `json:"city" validate:"required,max=100" example:"Bekasi"`
+	PostalCodes   []string    `json:"postal_codes" validate:"unique,dive,numeric,min=2,max=5" example:"171,174"`
+	Polygon       [][]float64 `json:"polygon" validate:"omitempty,min=3,dive,len=2"`
+	DeliveryFee   float64     `json:"delivery_fee" validate:"gte=0" example:"10000"`
+	DeliveryDays  []string    `json:"delivery_days" validate:"required,min=1,unique,dive,weekday" example:"monday,wednesday,friday"`
+	CutoffMinutes *int        `json:"cutoff_minutes" validate:"omitempty,min=0,max=10080" example:"360"`
+	IsActive      *bool       `json:"is_active" validate:"required" example:"true"`
+}
+
+type DeliveryZoneResponse struct {
+	ID            uuid.UUID   `json:"id" example:"b3e1f8e2..."`
+	Name          string      `json:"name" example:"Bekasi"`
+	City          string      `json:"city" example:"Bekasi"`
+	PostalCodes   []string    `json:"postal_codes" example:"171,174"`
+	Polygon       [][]float64 `json:"polygon"`
+	DeliveryFee   float64     `json:"delivery_fee" example:"10000"`
+	DeliveryDays  []string    `json:"delivery_days" example:"monday,wednesday,friday"`
+	CutoffMinutes *int        `json:"cutoff_minutes" example:"360"`
+	IsActive      bool        `json:"is_active" example:"true"`
+	CreatedAt     time.Time   `json:"created_at" example:"2025-01-10T10:00:00+07:00"`
+}
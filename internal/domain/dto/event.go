@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PaymentEvent struct {
+	SubscriptionID uuid.UUID `json:"subscription_id" example:"b3e1f8e2..."`
+	OrderID        string    `json:"order_id" example:"RENEW-b3e1f8e2..."`
+	Status         string    `json:"status" example:"settlement"`
+}
+
+type SubscriptionStatusEvent struct {
+	SubscriptionID uuid.UUID `json:"subscription_id" example:"b3e1f8e2..."`
+	OldStatus      string    `json:"old_status" example:"pending"`
+	Status         string    `json:"status" example:"active"`
+}
+
+type DeliveryStatusEvent struct {
+	DeliveryID     uuid.UUID `json:"delivery_id" example:"b3e1f8e2..."`
+	SubscriptionID uuid.UUID `json:"subscription_id" example:"b3e1f8e2..."`
+	DeliveryDate   time.Time `json:"delivery_date" example:"2025-01-15"`
+	MealType       string    `json:"meal_type" example:"lunch"`
+	Status         string    `json:"status" example:"picked_up"`
+}
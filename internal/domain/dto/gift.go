@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GiftRequest turns a new subscription into a gift. The subscription's name,
+// phone number and delivery address are then the recipient's.
+type GiftRequest struct {
+	RecipientEmail string  `json:"recipient_email" validate:"required,email" example:"mom@example.com"`
+	Message        *string `json:"message" validate:"omitempty,max=500" example:"Happy birthday, Mom!"`
+}
+
+type ClaimGiftRequest struct {
+	Code string `json:"code" validate:"required,max=20" example:"GIFT-7KQ2-M9XD-4HPA"`
+}
+
+type GiftResponse struct {
+	ID                 uuid.UUID  `json:"id" example:"b3e1f8e2..."`
+	SubscriptionID     uuid.UUID  `json:"subscription_id" example:"b3e1f8e2..."`
+	MealPlan           string     `json:"meal_plan" example:"Protein Plan"`
+	RecipientName      string     `json:"recipient_name" example:"Jane Doe"`
+	RecipientEmail     string     `json:"recipient_email" example:"mom@example.com"`
+	Message            *string    `json:"message" example:"Happy birthday, Mom!"`
+	Code               *string    `json:"code" example:"GIFT-7KQ2-M9XD-4HPA"`
+	Status             string     `json:"status" example:"issued"`
+	SubscriptionStatus string     `json:"subscription_status" example:"active"`
+	IssuedAt           *time.Time `json:"issued_at" example:"2025-01-10T10:05:00+07:00"`
+	ClaimedAt          *time.Time `json:"claimed_at" example:"2025-01-11T08:00:00+07:00"`
+	CreatedAt          *time.Time `json:"created_at" example:"2025-01-10T10:00:00+07:00"`
+}
@@ -0,0 +1,36 @@
+package dto
+
+import "github.com/google/uuid"
+
+type GetKitchenForecastRequest struct {
+	Date   string `query:"date" validate:"required,datetime=2006-01-02" example:"2025-01-15"`
+	Period string `query:"period" validate:"omitempty,oneof=day week" example:"week"`
+	Format string `query:"format" validate:"omitempty,oneof=json csv labels" example:"csv"`
+}
+
+type KitchenAllergyCount struct {
+	Allergy  string `json:"allergy" example:"peanuts"`
+	Portions int64  `json:"portions" example:"3"`
+}
+
+type KitchenForecastItem struct {
+	MealPlanID uuid.UUID             `json:"meal_plan_id" example:"b3e1f8e2..."`
+	MealPlan   string                `json:"meal_plan" example:"Protein Plan"`
+	MealType   string                `json:"meal_type" example:"lunch"`
+	Portions   int64                 `json:"portions" example:"42"`
+	Allergies  []KitchenAllergyCount `json:"allergies"`
+}
+
+type KitchenForecastDay struct {
+	Date     string                `json:"date" example:"2025-01-15"`
+	Day      string                `json:"day" example:"wednesday"`
+	Portions int64                 `json:"portions" example:"120"`
+	Items    []KitchenForecastItem `json:"items"`
+}
+
+type KitchenForecastResponse struct {
+	StartDate string               `json:"start_date" example:"2025-01-13"`
+	EndDate   string               `json:"end_date" example:"2025-01-19"`
+	Portions  int64                `json:"portions" example:"640"`
+	Days      []KitchenForecastDay `json:"days"`
+}
@@ -0,0 +1,112 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChargeRequest asks the payment gateway for a hosted checkout page.
+// ItemDetails must add up to Amount.
+type ChargeRequest struct {
+	OrderID         string
+	Amount          int64
+	SubscriptionID  uuid.UUID
+	ExpiryDuration  time.Duration
+	ItemDetails     []ChargeItem
+	CustomerDetails ChargeCustomer
+}
+
+type ChargeItem struct {
+	ID    string
+	Name  string
+	Price int64
+	Qty   int32
+}
+
+type ChargeCustomer struct {
+	Name  string
+	Email string
+	Phone string
+}
+
+// TransactionStatus is the state of an order as reported by the payment
+// gateway. Every gateway maps its statuses onto the Midtrans vocabulary
+// (pending, capture, settlement, deny, cancel, expire, failure, refund and
+// partial_refund), which is what payments are stored with.
+type TransactionStatus struct {
+	OrderID           string
+	TransactionID     string
+	TransactionStatus string
+	GrossAmount       string
+	PaymentType       string
+	FraudStatus       string
+	TransactionTime   time.Time
+	Raw               string
+}
+
+type GatewayRefundRequest struct {
+	OrderID   string
+	RefundKey string
+	Amount    int64
+	Reason    string
+}
+
+// GatewayRefundResponse reports a refund. TransactionStatus is refund or
+// partial_refund once the money is on its way back, or empty while the
+// gateway is still processing it.
+type GatewayRefundResponse struct {
+	RefundKey         string
+	RefundAmount      string
+	TransactionStatus string
+}
+
+type GetPaymentsRequest struct {
+	StartDate   string `query:"start_date" validate:"required_with=EndDate,omitempty,datetime=2006-01-02" example:"2025-01-01"`
+	EndDate     string `query:"end_date" validate:"required_with=StartDate,omitempty,datetime=2006-01-02" example:"2025-01-31"`
+	Status      string `query:"status" example:"settlement"`
+	PaymentType string `query:"payment_type" example:"bank_transfer"`
+}
+
+type PaymentDetailResponse struct {
+	ID              uuid.UUID  `json:"id" example:"b3e1f8e2..."`
+	SubscriptionID  uuid.UUID  `json:"subscription_id" example:"b3e1f8e2..."`
+	OrderID         string     `json:"order_id" example:"SUBS-b3e1f8e2..."`
+	TransactionID   *string    `json:"transaction_id" example:"9aed5972-5b6a-401e-894b-a32c91ed1a3a"`
+	GrossAmount     float64    `json:"gross_amount" example:"180600"`
+	PaymentType     string     `json:"payment_type" example:"bank_transfer"`
+	FraudStatus     *string    `json:"fraud_status" example:"accept"`
+	Status          string     `json:"status" example:"settlement"`
+	TransactionTime *time.Time `json:"transaction_time" example:"2025-01-10T10:00:00+07:00"`
+	CreatedAt       *time.Time `json:"created_at" example:"2025-01-10T10:00:00+07:00"`
+}
+
+type InvoiceResponse struct {
+	ID             uuid.UUID  `json:"id" example:"b3e1f8e2..."`
+	Number         string     `json:"number" example:"INV-202501-000042"`
+	SubscriptionID uuid.UUID  `json:"subscription_id" example:"b3e1f8e2..."`
+	OrderID        string     `json:"order_id" example:"SUBS-b3e1f8e2..."`
+	Amount         float64    `json:"amount" example:"859140"`
+	PaymentType    string     `json:"payment_type" example:"bank_transfer"`
+	PaidAt         time.Time  `json:"paid_at" example:"2025-01-10T10:00:00+07:00"`
+	DownloadURL    string     `json:"download_url" example:"https://example.supabase.co/storage/v1/object/public/media/invoices/b3e1f8e2.../INV-202501-000042.pdf"`
+	CreatedAt      *time.Time `json:"created_at" example:"2025-01-10T10:00:00+07:00"`
+}
+
+type ReconciliationReportResponse struct {
+	ID         uuid.UUID                    `json:"id" example:"b3e1f8e2..."`
+	StartedAt  time.Time                    `json:"started_at" example:"2025-01-10T02:30:00+07:00"`
+	FinishedAt time.Time                    `json:"finished_at" example:"2025-01-10T02:31:12+07:00"`
+	Matched    int                          `json:"matched" example:"120"`
+	Fixed      int                          `json:"fixed" example:"2"`
+	Unresolved int                          `json:"unresolved" example:"1"`
+	Items      []ReconciliationItemResponse `json:"items,omitempty"`
+}
+
+type ReconciliationItemResponse struct {
+	OrderID       string  `json:"order_id" example:"SUBS-b3e1f8e2..."`
+	LocalStatus   string  `json:"local_status" example:"pending"`
+	GatewayStatus *string `json:"gateway_status" example:"settlement"`
+	Outcome       string  `json:"outcome" example:"fixed"`
+	Note          *string `json:"note" example:"Gross amount does not match subscription price"`
+}
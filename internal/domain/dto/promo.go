@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PromoRequest struct {
+	Code           string      `json:"code" validate:"required,alphanum,min=3,max=30" example:"HEALTHY10"`
+	Description    string      `json:"description" validate:"required" example:"10% off your first month"`
+	DiscountType   string      `json:"discount_type" validate:"required,oneof=percentage fixed" example:"percentage"`
+	DiscountValue  float64     `json:"discount_value" validate:"required,gt=0" example:"10"`
+	MaxDiscount    *float64    `json:"max_discount" validate:"omitempty,gt=0" example:"50000"`
+	MinSpend       float64     `json:"min_spend" validate:"gte=0" example:"200000"`
+	ValidFrom      string      `json:"valid_from" validate:"required,datetime=2006-01-02" example:"2025-01-01"`
+	ValidUntil     string      `json:"valid_until" validate:"required,datetime=2006-01-02,date_gtefield=ValidFrom" example:"2025-01-31"`
+	MaxRedemptions *int        `json:"max_redemptions" validate:"omitempty,min=1" example:"100"`
+	PerUserLimit   *int        `json:"per_user_limit" validate:"omitempty,min=1" example:"1"`
+	MealPlanIDs    []uuid.UUID `json:"meal_plan_ids" validate:"unique" example:"b3e1f8e2..."`
+	IsActive       *bool       `json:"is_active" validate:"required" example:"true"`
+}
+
+type PromoResponse struct {
+	ID              uuid.UUID   `json:"id" example:"b3e1f8e2..."`
+	Code            string      `json:"code" example:"HEALTHY10"`
+	Description     string      `json:"description" example:"10% off your first month"`
+	DiscountType    string      `json:"discount_type" example:"percentage"`
+	DiscountValue   float64     `json:"discount_value" example:"10"`
+	MaxDiscount     *float64    `json:"max_discount" example:"50000"`
+	MinSpend        float64     `json:"min_spend" example:"200000"`
+	ValidFrom       time.Time   `json:"valid_from" example:"2025-01-01"`
+	ValidUntil      time.Time   `json:"valid_until" example:"2025-01-31"`
+	MaxRedemptions  *int        `json:"max_redemptions" example:"100"`
+	PerUserLimit    *int        `json:"per_user_limit" example:"1"`
+	RedemptionCount int         `json:"redemption_count" example:"12"`
+	MealPlanIDs     []uuid.UUID `json:"meal_plan_ids"`
+	IsActive        bool        `json:"is_active" example:"true"`
+	CreatedAt       time.Time   `json:"created_at" example:"2025-01-01"`
+}
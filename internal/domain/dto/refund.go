@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type GetRefundsRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=requested approved rejected refunded" example:"requested"`
+}
+
+type ApproveRefundRequest struct {
+	Destination string `query:"destination" validate:"omitempty,oneof=wallet gateway" example:"wallet"`
+}
+
+type RejectRefundRequest struct {
+	Reason string `json:"reason" validate:"required,max=500" example:"Subscription was cancelled after the refund window"`
+}
+
+type RefundResponse struct {
+	ID              uuid.UUID  `json:"id" example:"b3e1f8e2..."`
+	SubscriptionID  uuid.UUID  `json:"subscription_id" example:"b3e1f8e2..."`
+	OrderID         string     `json:"order_id" example:"SUBS-b3e1f8e2..."`
+	PaidAmount      float64    `json:"paid_amount" example:"859140"`
+	UndeliveredDays int        `json:"undelivered_days" example:"15"`
+	TotalDays       int        `json:"total_days" example:"30"`
+	Fee             float64    `json:"fee" example:"10000"`
+	Amount          float64    `json:"amount" example:"419570"`
+	Status          string     `json:"status" example:"requested"`
+	Destination     *string    `json:"destination" example:"wallet"`
+	RejectReason    *string    `json:"reject_reason"`
+	ReviewedAt      *time.Time `json:"reviewed_at" example:"2025-01-12T10:00:00+07:00"`
+	RefundedAt      *time.Time `json:"refunded_at" example:"2025-01-12T10:05:00+07:00"`
+	CreatedAt       *time.Time `json:"created_at" example:"2025-01-10T10:00:00+07:00"`
+}
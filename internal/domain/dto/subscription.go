@@ -7,45 +7,74 @@ import (
 )
 
 type CreateSubscriptionRequest struct {
-	Name            string    `json:"name" validate:"required,min=3,max=50" example:"John Doe"`
-	PhoneNumber     string    `json:"phone_number" validate:"required,min=10" example:"081234567890"`
-	DeliveryAddress string    `json:"delivery_address" validate:"required,min=10" example:"123 Main St, Jakarta"`
-	DeliveryNotes   *string   `json:"delivery_notes" example:"Please leave at the front door"`
+	AddressID       *uuid.UUID   `json:"address_id" example:"b3e1f8e2..."`
+	Name            string       `json:"name" validate:"required_without=AddressID,omitempty,min=3,max=50" example:"John Doe"`
+	PhoneNumber     string       `json:"phone_number" validate:"required_without=AddressID,omitempty,min=10" example:"081234567890"`
+	DeliveryAddress string       `json:"delivery_address" validate:"required_without=AddressID,omitempty,min=10" example:"123 Main St, Jakarta"`
+	City            string       `json:"city" validate:"omitempty,max=100" example:"Jakarta Selatan"`
+	PostalCode      string       `json:"postal_code" validate:"omitempty,numeric,len=5" example:"12110"`
+	Latitude        *float64     `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90" example:"-6.2443"`
+	Longitude       *float64     `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180" example:"106.8007"`
+	DeliveryNotes   *string      `json:"delivery_notes" example:"Please leave at the front door"`
+	MealPlanID      uuid.UUID    `json:"meal_plan_id" validate:"required,uuid" example:"b3e1f8e2..."`
+	MealTypes       []string     `json:"meal_types" validate:"required,min=1,unique,dive,meal_type" example:"breakfast,lunch"`
+	DeliveryDays    []string     `json:"delivery_days" validate:"required,min=1,unique,dive,weekday" example:"monday,tuesday,wednesday"`
+	Allergies       *string      `json:"allergies" example:"Peanuts, Shellfish"`
+	AutoRenew       bool         `json:"auto_renew" example:"true"`
+	PromoCode       *string      `json:"promo_code" validate:"omitempty,alphanum,min=3,max=30" example:"HEALTHY10"`
+	UseWalletCredit bool         `json:"use_wallet_credit" example:"true"`
+	Gift            *GiftRequest `json:"gift"`
+}
+
+type UpdateSubscriptionRequest struct {
+	MealPlanID   *uuid.UUID `json:"meal_plan_id" example:"b3e1f8e2..."`
+	MealTypes    []string   `json:"meal_types" validate:"omitempty,min=1,unique,dive,meal_type" example:"breakfast,dinner"`
+	DeliveryDays []string   `json:"delivery_days" validate:"omitempty,min=1,unique,dive,weekday" example:"monday,friday"`
+}
+
+type QuoteSubscriptionRequest struct {
 	MealPlanID      uuid.UUID `json:"meal_plan_id" validate:"required,uuid" example:"b3e1f8e2..."`
-	MealTypes       []string  `json:"meal_types" validate:"required,min=1" example:"breakfast,lunch"`
-	DeliveryDays    []string  `json:"delivery_days" validate:"required,min=1" example:"monday,tuesday,wednesday"`
-	Allergies       *string   `json:"allergies" example:"Peanuts, Shellfish"`
+	MealTypes       []string  `json:"meal_types" validate:"required,min=1,unique,dive,meal_type" example:"breakfast,lunch"`
+	DeliveryDays    []string  `json:"delivery_days" validate:"required,min=1,unique,dive,weekday" example:"monday,tuesday,wednesday"`
+	DeliveryAddress string    `json:"delivery_address" example:"123 Main St, Bekasi"`
+	City            string    `json:"city" validate:"omitempty,max=100" example:"Bekasi"`
+	PostalCode      string    `json:"postal_code" validate:"omitempty,numeric,len=5" example:"17141"`
+	Latitude        *float64  `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90" example:"-6.2383"`
+	Longitude       *float64  `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180" example:"106.9756"`
 }
 
-type PauseSubscriptionRequest struct {
-	StartDate string `json:"start_date" validate:"required,datetime=2006-01-02" example:"2025-01-15"`
-	EndDate   string `json:"end_date" validate:"required,datetime=2006-01-02" example:"2025-01-30"`
+type ChangeDeliveryAddressRequest struct {
+	AddressID     uuid.UUID `json:"address_id" validate:"required" example:"b3e1f8e2..."`
+	EffectiveDate string    `json:"effective_date" validate:"required,datetime=2006-01-02,not_past" example:"2025-01-20"`
 }
 
-type GetSubscriptionStatisticRequest struct {
-	StartDate string `query:"start_date" validate:"required,datetime=2006-01-02" example:"2025-01-15"`
-	EndDate   string `query:"end_date" validate:"required,datetime=2006-01-02" example:"2025-01-30"`
+type UpdateAutoRenewRequest struct {
+	AutoRenew *bool `json:"auto_renew" validate:"required" example:"true"`
 }
 
-type MidtransRequest struct {
-	OrderID         string
-	Amount          int64
-	SubscriptionID  uuid.UUID
-	ItemDetails     []MidtransItemDetail
-	CustomerDetails MidtransCustomerDetails
+type PauseSubscriptionRequest struct {
+	StartDate string `json:"start_date" validate:"required,datetime=2006-01-02,not_past" example:"2025-01-15"`
+	EndDate   string `json:"end_date" validate:"required,datetime=2006-01-02,date_gtefield=StartDate,max_days_after=StartDate 30" example:"2025-01-30"`
 }
 
-type MidtransItemDetail struct {
-	ID    string
-	Name  string
-	Price int64
-	Qty   int32
+type GetSubscriptionStatisticRequest struct {
+	StartDate string `query:"start_date" validate:"required,datetime=2006-01-02" example:"2025-01-15"`
+	EndDate   string `query:"end_date" validate:"required,datetime=2006-01-02" example:"2025-01-30"`
 }
 
-type MidtransCustomerDetails struct {
-	Name  string
-	Email string
-	Phone string
+type MidtransNotification struct {
+	TransactionTime   string `json:"transaction_time" example:"2025-01-10 10:00:00"`
+	TransactionStatus string `json:"transaction_status" validate:"required" example:"settlement"`
+	TransactionID     string `json:"transaction_id" example:"9aed5972-5b6a-401e-894b-a32c91ed1a3a"`
+	StatusMessage     string `json:"status_message" example:"midtrans payment notification"`
+	StatusCode        string `json:"status_code" validate:"required" example:"200"`
+	SignatureKey      string `json:"signature_key" validate:"required" example:"fe5f725ea770c451017e9d6300af72b8..."`
+	PaymentType       string `json:"payment_type" example:"bank_transfer"`
+	OrderID           string `json:"order_id" validate:"required" example:"SUBS-b3e1f8e2..."`
+	MerchantID        string `json:"merchant_id" example:"G141532850"`
+	GrossAmount       string `json:"gross_amount" validate:"required" example:"180600.00"`
+	FraudStatus       string `json:"fraud_status" example:"accept"`
+	Currency          string `json:"currency" example:"IDR"`
 }
 
 type SubscriptionResponse struct {
@@ -53,13 +82,23 @@ type SubscriptionResponse struct {
 	Name            string           `json:"name" example:"John Doe"`
 	PhoneNumber     string           `json:"phone_number" example:"08123456789"`
 	DeliveryAddress string           `json:"delivery_address"`
+	City            *string          `json:"city" example:"Jakarta Selatan"`
+	PostalCode      *string          `json:"postal_code" example:"12110"`
+	Latitude        *float64         `json:"latitude" example:"-6.2443"`
+	Longitude       *float64         `json:"longitude" example:"106.8007"`
+	DeliveryZoneID  *uuid.UUID       `json:"delivery_zone_id" example:"b3e1f8e2..."`
 	DeliveryNotes   *string          `json:"delivery_notes"`
 	MealPlan        MealPlanResponse `json:"meal_plan"`
 	MealTypes       []string         `json:"meal_types" example:"breakfast,lunch"`
 	DeliveryDays    []string         `json:"delivery_days" example:"monday,tuesday,wednesday"`
 	Allergies       *string          `json:"allergies" example:"Peanuts, Shellfish"`
-	TotalPrice      float64          `json:"total_price" example:"180000"`
+	Subtotal        float64          `json:"subtotal" example:"774000"`
+	DeliveryFee     float64          `json:"delivery_fee" example:"0"`
+	TaxAmount       float64          `json:"tax_amount" example:"85140"`
+	TotalPrice      float64          `json:"total_price" example:"859140"`
 	Status          string           `json:"status" example:"pending"`
+	AutoRenew       bool             `json:"auto_renew" example:"true"`
+	CreditBalance   float64          `json:"credit_balance" example:"0"`
 	PauseStartDate  *time.Time       `json:"pause_start_date" example:"2025-01-15"`
 	PauseEndDate    *time.Time       `json:"pause_end_date" example:"2025-01-30"`
 	StartDate       time.Time        `json:"start_date" example:"2025-01-10"`
@@ -67,6 +106,81 @@ type SubscriptionResponse struct {
 	CreatedAt       time.Time        `json:"created_at" example:"2025-01-10"`
 }
 
+type QuoteItemResponse struct {
+	ID     string `json:"id" example:"meal-breakfast"`
+	Kind   string `json:"kind" example:"meal_type"`
+	Name   string `json:"name" example:"Breakfast meals"`
+	Amount int64  `json:"amount" example:"387000"`
+}
+
+type QuoteResponse struct {
+	MealPlan    MealPlanResponse    `json:"meal_plan"`
+	BasePrice   int64               `json:"base_price" example:"30000"`
+	PerMealType int64               `json:"per_meal_type" example:"387000"`
+	PerDay      int64               `json:"per_day" example:"258000"`
+	Items       []QuoteItemResponse `json:"items"`
+	Subtotal    int64               `json:"subtotal" example:"774000"`
+	Discount    int64               `json:"discount" example:"0"`
+	Fees        int64               `json:"fees" example:"0"`
+	Tax         int64               `json:"tax" example:"0"`
+	Total       int64               `json:"total" example:"774000"`
+}
+
+type BillingPeriodResponse struct {
+	ID           uuid.UUID  `json:"id" example:"b3e1f8e2..."`
+	PeriodNumber int        `json:"period_number" example:"2"`
+	StartDate    time.Time  `json:"start_date" example:"2025-02-10"`
+	EndDate      time.Time  `json:"end_date" example:"2025-03-12"`
+	Amount       float64    `json:"amount" example:"180600"`
+	WalletCredit float64    `json:"wallet_credit" example:"0"`
+	OrderID      string     `json:"order_id" example:"RENEW-b3e1f8e2..."`
+	PaymentURL   *string    `json:"payment_url" example:"https://app.sandbox.midtrans.com/snap/v3/redirection/66e4fa55..."`
+	Status       string     `json:"status" example:"paid"`
+	PaidAt       *time.Time `json:"paid_at" example:"2025-02-08T10:00:00Z"`
+}
+
+type SubscriptionChangeResponse struct {
+	ID              uuid.UUID  `json:"id" example:"b3e1f8e2..."`
+	OldMealPlanID   uuid.UUID  `json:"old_meal_plan_id" example:"b3e1f8e2..."`
+	NewMealPlanID   uuid.UUID  `json:"new_meal_plan_id" example:"c4f2a9f3..."`
+	OldMealTypes    []string   `json:"old_meal_types" example:"breakfast,lunch"`
+	NewMealTypes    []string   `json:"new_meal_types" example:"breakfast,dinner"`
+	OldDeliveryDays []string   `json:"old_delivery_days" example:"monday,tuesday,wednesday"`
+	NewDeliveryDays []string   `json:"new_delivery_days" example:"monday,friday"`
+	OldTotalPrice   float64    `json:"old_total_price" example:"180600"`
+	NewTotalPrice   float64    `json:"new_total_price" example:"240800"`
+	RemainingDays   int        `json:"remaining_days" example:"15"`
+	ProratedAmount  float64    `json:"prorated_amount" example:"30100"`
+	CreditApplied   float64    `json:"credit_applied" example:"0"`
+	ChargeAmount    float64    `json:"charge_amount" example:"30100"`
+	PaymentURL      *string    `json:"payment_url" example:"https://app.sandbox.midtrans.com/snap/v3/redirection/66e4fa55..."`
+	Status          string     `json:"status" example:"pending"`
+	AppliedAt       *time.Time `json:"applied_at" example:"2025-01-20T10:00:00Z"`
+	CreatedAt       time.Time  `json:"created_at" example:"2025-01-20T09:55:00Z"`
+}
+
+type SubscriptionAddressChangeResponse struct {
+	ID              uuid.UUID  `json:"id" example:"b3e1f8e2..."`
+	AddressID       *uuid.UUID `json:"address_id" example:"c4f2a9f3..."`
+	Name            string     `json:"name" example:"John Doe"`
+	PhoneNumber     string     `json:"phone_number" example:"081234567890"`
+	DeliveryAddress string     `json:"delivery_address" example:"45 Office Park, Jakarta"`
+	City            string     `json:"city" example:"Jakarta Pusat"`
+	PostalCode      string     `json:"postal_code" example:"10220"`
+	DeliveryNotes   *string    `json:"delivery_notes" example:"Leave at the lobby"`
+	DeliveryZoneID  *uuid.UUID `json:"delivery_zone_id" example:"b3e1f8e2..."`
+	EffectiveDate   time.Time  `json:"effective_date" example:"2025-01-20"`
+	Status          string     `json:"status" example:"pending"`
+	AppliedAt       *time.Time `json:"applied_at" example:"2025-01-20T00:10:00Z"`
+	CreatedAt       time.Time  `json:"created_at" example:"2025-01-15T09:55:00Z"`
+}
+
+type UpdateSubscriptionResponse struct {
+	Subscription SubscriptionResponse       `json:"subscription"`
+	Change       SubscriptionChangeResponse `json:"change"`
+	Payment      *PaymentResponse           `json:"payment"`
+}
+
 type PaymentResponse struct {
 	Token       string `json:"token" example:"66e4fa55..."`
 	RedirectURL string `json:"redirect_url" example:"https://app.sandbox.midtrans.com/snap/v3/redirection/66e4fa55..."`
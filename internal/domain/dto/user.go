@@ -1,6 +1,45 @@
 package dto
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 type UserResponse struct {
 	Email string `json:"email" example:"john@example.com"`
 	Name  string `json:"name" example:"John Doe"`
+	Role  string `json:"role" example:"user"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user courier admin" example:"courier"`
+}
+
+type UserAddressRequest struct {
+	Label         string   `json:"label" validate:"required,max=50" example:"Home"`
+	RecipientName string   `json:"recipient_name" validate:"required,min=3,max=50" example:"John Doe"`
+	PhoneNumber   string   `json:"phone_number" validate:"required,min=10" example:"081234567890"`
+	Address       string   `json:"address" validate:"required,min=10" example:"123 Main St, Jakarta"`
+	City          string   `json:"city" validate:"required,max=100" example:"Jakarta Selatan"`
+	PostalCode    string   `json:"postal_code" validate:"required,numeric,len=5" example:"12110"`
+	Latitude      *float64 `json:"latitude" validate:"required_with=Longitude,omitempty,gte=-90,lte=90" example:"-6.2443"`
+	Longitude     *float64 `json:"longitude" validate:"required_with=Latitude,omitempty,gte=-180,lte=180" example:"106.8007"`
+	Notes         *string  `json:"notes" example:"Please leave at the front door"`
+	IsDefault     bool     `json:"is_default" example:"true"`
+}
+
+type UserAddressResponse struct {
+	ID            uuid.UUID `json:"id" example:"b3e1f8e2..."`
+	Label         string    `json:"label" example:"Home"`
+	RecipientName string    `json:"recipient_name" example:"John Doe"`
+	PhoneNumber   string    `json:"phone_number" example:"081234567890"`
+	Address       string    `json:"address" example:"123 Main St, Jakarta"`
+	City          string    `json:"city" example:"Jakarta Selatan"`
+	PostalCode    string    `json:"postal_code" example:"12110"`
+	Latitude      *float64  `json:"latitude" example:"-6.2443"`
+	Longitude     *float64  `json:"longitude" example:"106.8007"`
+	Notes         *string   `json:"notes" example:"Please leave at the front door"`
+	IsDefault     bool      `json:"is_default" example:"true"`
+	CreatedAt     time.Time `json:"created_at" example:"2025-01-10T10:00:00+07:00"`
 }
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AdjustWalletRequest struct {
+	Type      string  `json:"type" validate:"required,oneof=credit debit" example:"credit"`
+	Amount    float64 `json:"amount" validate:"required,gt=0" example:"25000"`
+	Note      string  `json:"note" validate:"required,max=500" example:"Apology for the late delivery on 12 January"`
+	Reference *string `json:"reference" validate:"omitempty,max=255" example:"TICKET-1042"`
+}
+
+type WalletResponse struct {
+	UserID       uuid.UUID                   `json:"user_id" example:"b3e1f8e2..."`
+	Balance      float64                     `json:"balance" example:"75000"`
+	Transactions []WalletTransactionResponse `json:"transactions"`
+}
+
+type WalletTransactionResponse struct {
+	ID           uuid.UUID  `json:"id" example:"b3e1f8e2..."`
+	Type         string     `json:"type" example:"credit"`
+	Amount       float64    `json:"amount" example:"25000"`
+	BalanceAfter float64    `json:"balance_after" example:"75000"`
+	Reason       string     `json:"reason" example:"adjustment"`
+	Reference    *string    `json:"reference" example:"TICKET-1042"`
+	Note         *string    `json:"note" example:"Apology for the late delivery on 12 January"`
+	CreatedAt    *time.Time `json:"created_at" example:"2025-01-12T10:00:00+07:00"`
+}
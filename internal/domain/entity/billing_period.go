@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type BillingPeriodStatus string
+
+const (
+	BillingPending   BillingPeriodStatus = "pending"
+	BillingPaid      BillingPeriodStatus = "paid"
+	BillingFailed    BillingPeriodStatus = "failed"
+	BillingCancelled BillingPeriodStatus = "cancelled"
+)
+
+// BillingPeriod is one paid stretch of a subscription. The first period is
+// created with the subscription; every renewal adds another one. Amount is
+// what the gateway charges, after subscription credit and wallet credit.
+// ItemDetails keeps the line items the period was charged with for its
+// invoice.
+type BillingPeriod struct {
+	ID                  uuid.UUID           `gorm:"column:id;type:char(36);primaryKey;not null"`
+	SubscriptionID      uuid.UUID           `gorm:"column:subscription_id;type:char(36);not null;index"`
+	Subscription        *Subscription       `gorm:"foreignKey:subscription_id;constraint:OnDelete:CASCADE"`
+	PeriodNumber        int                 `gorm:"column:period_number;type:int;not null"`
+	StartDate           time.Time           `gorm:"column:start_date;type:date;not null"`
+	EndDate             time.Time           `gorm:"column:end_date;type:date;not null"`
+	Amount              float64             `gorm:"column:amount;type:decimal(15,2);not null"`
+	CreditApplied       float64             `gorm:"column:credit_applied;type:decimal(15,2);not null;default:0"`
+	WalletCreditApplied float64             `gorm:"column:wallet_credit_applied;type:decimal(15,2);not null;default:0"`
+	OrderID             string              `gorm:"column:order_id;type:varchar(255);unique;not null"`
+	ItemDetails         *string             `gorm:"column:item_details;type:jsonb"`
+	PaymentURL          *string             `gorm:"column:payment_url;type:text"`
+	Status              BillingPeriodStatus `gorm:"column:status;type:varchar(20);default:'pending';not null"`
+	PaidAt              *time.Time          `gorm:"column:paid_at;type:timestamp"`
+	CreatedAt           *time.Time          `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt           *time.Time          `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (bp *BillingPeriod) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	bp.ID = id
+	return
+}
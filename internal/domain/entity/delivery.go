@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryScheduled DeliveryStatus = "scheduled"
+	DeliverySkipped   DeliveryStatus = "skipped"
+	DeliveryPickedUp  DeliveryStatus = "picked_up"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Delivery is one meal owed to a subscriber on a given date. Deliveries are
+// generated from the subscription's dates, delivery days, meal types and
+// pause window, and regenerated whenever those change. A skipped delivery
+// holds the credit it added to the subscription. On the day, a courier picks
+// the meal up and then marks it delivered, with a proof photo, or failed,
+// with a reason.
+type Delivery struct {
+	ID             uuid.UUID      `gorm:"column:id;type:char(36);primaryKey;not null"`
+	SubscriptionID uuid.UUID      `gorm:"column:subscription_id;type:char(36);not null;uniqueIndex:idx_delivery_slot"`
+	Subscription   *Subscription  `gorm:"foreignKey:subscription_id;constraint:OnDelete:CASCADE"`
+	DeliveryDate   time.Time      `gorm:"column:delivery_date;type:date;not null;uniqueIndex:idx_delivery_slot;index"`
+	MealType       string         `gorm:"column:meal_type;type:varchar(20);not null;uniqueIndex:idx_delivery_slot"`
+	Status         DeliveryStatus `gorm:"column:status;type:varchar(20);default:'scheduled';not null"`
+	CreditAmount   float64        `gorm:"column:credit_amount;type:decimal(15,2);not null;default:0"`
+	CourierID      *uuid.UUID     `gorm:"column:courier_id;type:char(36);index"`
+	Courier        *User          `gorm:"foreignKey:courier_id;constraint:OnDelete:SET NULL"`
+	PickedUpAt     *time.Time     `gorm:"column:picked_up_at;type:timestamp"`
+	DeliveredAt    *time.Time     `gorm:"column:delivered_at;type:timestamp"`
+	FailedAt       *time.Time     `gorm:"column:failed_at;type:timestamp"`
+	FailureReason  *string        `gorm:"column:failure_reason;type:text"`
+	ProofPhotoURL  *string        `gorm:"column:proof_photo_url;type:text"`
+	CreatedAt      *time.Time     `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt      *time.Time     `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (d *Delivery) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	d.ID = id
+	return
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeliveryZone is an area we deliver to. An address is covered when its
+// postal code starts with one of PostalCodes, or else when its location lies
+// inside Polygon, a JSON array of [latitude, longitude] pairs. A zone with
+// neither covers its whole City. DeliveryFee is charged per delivery day a
+// week, only DeliveryDays are served, and CutoffMinutes, when set, replaces
+// the default delivery cutoff.
+type DeliveryZone struct {
+	ID            uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	Name          string     `gorm:"column:name;type:varchar(100);unique;not null"`
+	City          string     `gorm:"column:city;type:varchar(100);not null"`
+	PostalCodes   *string    `gorm:"column:postal_codes;type:text"`
+	Polygon       *string    `gorm:"column:polygon;type:text"`
+	DeliveryFee   float64    `gorm:"column:delivery_fee;type:decimal(15,2);not null;default:0"`
+	DeliveryDays  string     `gorm:"column:delivery_days;type:text;not null"`
+	CutoffMinutes *int       `gorm:"column:cutoff_minutes;type:int"`
+	IsActive      bool       `gorm:"column:is_active;type:bool;not null"`
+	CreatedAt     *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt     *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (z *DeliveryZone) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	z.ID = id
+	return
+}
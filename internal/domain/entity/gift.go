@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type GiftStatus string
+
+const (
+	GiftPending GiftStatus = "pending"
+	GiftIssued  GiftStatus = "issued"
+	GiftClaimed GiftStatus = "claimed"
+)
+
+// Gift is a subscription bought for someone else. Its code is issued once
+// the first period is paid and emailed to RecipientEmail; whoever claims it
+// becomes the owner of the subscription. PurchaserID keeps the buyer, who
+// also receives any refund.
+type Gift struct {
+	ID             uuid.UUID     `gorm:"column:id;type:char(36);primaryKey;not null"`
+	SubscriptionID uuid.UUID     `gorm:"column:subscription_id;type:char(36);unique;not null"`
+	Subscription   *Subscription `gorm:"foreignKey:subscription_id;constraint:OnDelete:CASCADE"`
+	PurchaserID    uuid.UUID     `gorm:"column:purchaser_id;type:char(36);not null;index"`
+	Purchaser      *User         `gorm:"foreignKey:purchaser_id;constraint:OnDelete:CASCADE"`
+	RecipientEmail string        `gorm:"column:recipient_email;type:varchar(255);not null"`
+	Message        *string       `gorm:"column:message;type:text"`
+	Code           *string       `gorm:"column:code;type:varchar(20);unique"`
+	Status         GiftStatus    `gorm:"column:status;type:varchar(20);default:'pending';not null"`
+	IssuedAt       *time.Time    `gorm:"column:issued_at;type:timestamp"`
+	NotifiedAt     *time.Time    `gorm:"column:notified_at;type:timestamp"`
+	ClaimedBy      *uuid.UUID    `gorm:"column:claimed_by;type:char(36)"`
+	ClaimedAt      *time.Time    `gorm:"column:claimed_at;type:timestamp"`
+	CreatedAt      *time.Time    `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt      *time.Time    `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (g *Gift) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	g.ID = id
+	return
+}
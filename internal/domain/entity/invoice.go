@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Invoice is the receipt issued once the payment for an order settles.
+// Sequence numbers invoices in the order they were issued and Number is
+// derived from it. FileURL stays nil until the PDF has been stored.
+type Invoice struct {
+	ID             uuid.UUID     `gorm:"column:id;type:char(36);primaryKey;not null"`
+	Sequence       int64         `gorm:"column:sequence;autoIncrement;unique;not null"`
+	Number         string        `gorm:"column:number;type:varchar(30);index"`
+	SubscriptionID uuid.UUID     `gorm:"column:subscription_id;type:char(36);not null;index"`
+	Subscription   *Subscription `gorm:"foreignKey:subscription_id;constraint:OnDelete:CASCADE"`
+	UserID         uuid.UUID     `gorm:"column:user_id;type:char(36);not null;index"`
+	User           *User         `gorm:"foreignKey:user_id;constraint:OnDelete:CASCADE"`
+	OrderID        string        `gorm:"column:order_id;type:varchar(255);unique;not null"`
+	Amount         float64       `gorm:"column:amount;type:decimal(15,2);not null"`
+	PaymentType    string        `gorm:"column:payment_type;type:varchar(50)"`
+	PaidAt         time.Time     `gorm:"column:paid_at;type:timestamp;not null"`
+	FileURL        *string       `gorm:"column:file_url;type:text"`
+	CreatedAt      *time.Time    `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt      *time.Time    `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (i *Invoice) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	i.ID = id
+	return
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type Payment struct {
+	ID              uuid.UUID     `gorm:"column:id;type:char(36);primaryKey;not null"`
+	SubscriptionID  uuid.UUID     `gorm:"column:subscription_id;type:char(36);not null;index"`
+	Subscription    *Subscription `gorm:"foreignKey:subscription_id;constraint:OnDelete:CASCADE"`
+	OrderID         string        `gorm:"column:order_id;type:varchar(255);unique;not null"`
+	TransactionID   *string       `gorm:"column:transaction_id;type:varchar(255)"`
+	GrossAmount     float64       `gorm:"column:gross_amount;type:decimal(15,2);not null"`
+	PaymentType     string        `gorm:"column:payment_type;type:varchar(50);index"`
+	FraudStatus     *string       `gorm:"column:fraud_status;type:varchar(20)"`
+	Status          string        `gorm:"column:status;type:varchar(20);not null;index"`
+	TransactionTime *time.Time    `gorm:"column:transaction_time;type:timestamp"`
+	RawNotification string        `gorm:"column:raw_notification;type:jsonb"`
+	CreatedAt       *time.Time    `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt       *time.Time    `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (p *Payment) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	p.ID = id
+	return
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaymentNotification records every gateway notification that has been
+// applied, so retried or replayed deliveries can be recognised and dropped.
+// The fraud status is part of the key: a challenged capture that is accepted
+// later keeps its order, transaction and status.
+type PaymentNotification struct {
+	ID                uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	OrderID           string     `gorm:"column:order_id;type:varchar(255);not null;uniqueIndex:idx_payment_notification_key"`
+	TransactionID     string     `gorm:"column:transaction_id;type:varchar(255);not null;uniqueIndex:idx_payment_notification_key"`
+	TransactionStatus string     `gorm:"column:transaction_status;type:varchar(20);not null;uniqueIndex:idx_payment_notification_key"`
+	FraudStatus       string     `gorm:"column:fraud_status;type:varchar(20);not null;default:'';uniqueIndex:idx_payment_notification_key"`
+	ReceivedAt        *time.Time `gorm:"column:received_at;type:timestamp;autoCreateTime"`
+}
+
+func (pn *PaymentNotification) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	pn.ID = id
+	return
+}
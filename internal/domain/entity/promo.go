@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PromoDiscountType string
+
+const (
+	PromoPercentage PromoDiscountType = "percentage"
+	PromoFixed      PromoDiscountType = "fixed"
+)
+
+// Promo is a discount code for the first billing period of a new
+// subscription. MaxRedemptions and PerUserLimit are unlimited when nil, and a
+// promo without meal plans applies to every meal plan.
+type Promo struct {
+	ID              uuid.UUID         `gorm:"column:id;type:char(36);primaryKey;not null"`
+	Code            string            `gorm:"column:code;type:varchar(50);uniqueIndex;not null"`
+	Description     string            `gorm:"column:description;type:text;not null"`
+	DiscountType    PromoDiscountType `gorm:"column:discount_type;type:varchar(20);not null"`
+	DiscountValue   float64           `gorm:"column:discount_value;type:decimal(15,2);not null"`
+	MaxDiscount     *float64          `gorm:"column:max_discount;type:decimal(15,2)"`
+	MinSpend        float64           `gorm:"column:min_spend;type:decimal(15,2);not null;default:0"`
+	ValidFrom       time.Time         `gorm:"column:valid_from;type:date;not null"`
+	ValidUntil      time.Time         `gorm:"column:valid_until;type:date;not null"`
+	MaxRedemptions  *int              `gorm:"column:max_redemptions;type:int"`
+	PerUserLimit    *int              `gorm:"column:per_user_limit;type:int"`
+	RedemptionCount int               `gorm:"column:redemption_count;type:int;not null;default:0"`
+	IsActive        bool              `gorm:"column:is_active;type:bool;not null;default:true"`
+	MealPlans       []PromoMealPlan   `gorm:"foreignKey:PromoID;constraint:OnDelete:CASCADE"`
+	CreatedAt       *time.Time        `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt       *time.Time        `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (p *Promo) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	p.ID = id
+	return
+}
+
+// PromoMealPlan limits a promo to a meal plan.
+type PromoMealPlan struct {
+	PromoID    uuid.UUID `gorm:"column:promo_id;type:char(36);primaryKey;not null"`
+	MealPlanID uuid.UUID `gorm:"column:meal_plan_id;type:char(36);primaryKey;not null"`
+	MealPlan   *MealPlan `gorm:"foreignKey:meal_plan_id;constraint:OnDelete:CASCADE"`
+}
+
+type PromoRedemptionStatus string
+
+const (
+	RedemptionRedeemed PromoRedemptionStatus = "redeemed"
+	RedemptionReleased PromoRedemptionStatus = "released"
+)
+
+// PromoRedemption is a promo used on a subscription. A redemption is released
+// again when the subscription is cancelled before its first payment.
+type PromoRedemption struct {
+	ID             uuid.UUID             `gorm:"column:id;type:char(36);primaryKey;not null"`
+	PromoID        uuid.UUID             `gorm:"column:promo_id;type:char(36);not null;index:idx_promo_redemption_user"`
+	Promo          *Promo                `gorm:"foreignKey:promo_id"`
+	UserID         uuid.UUID             `gorm:"column:user_id;type:char(36);not null;index:idx_promo_redemption_user"`
+	User           *User                 `gorm:"foreignKey:user_id;constraint:OnDelete:CASCADE"`
+	SubscriptionID uuid.UUID             `gorm:"column:subscription_id;type:char(36);not null;uniqueIndex"`
+	Subscription   *Subscription         `gorm:"foreignKey:subscription_id;constraint:OnDelete:CASCADE"`
+	DiscountAmount float64               `gorm:"column:discount_amount;type:decimal(15,2);not null"`
+	Status         PromoRedemptionStatus `gorm:"column:status;type:varchar(20);default:'redeemed';not null"`
+	CreatedAt      *time.Time            `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt      *time.Time            `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (pr *PromoRedemption) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	pr.ID = id
+	return
+}
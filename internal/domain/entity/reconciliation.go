@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ReconciliationOutcome string
+
+const (
+	ReconciliationMatched    ReconciliationOutcome = "matched"
+	ReconciliationFixed      ReconciliationOutcome = "fixed"
+	ReconciliationUnresolved ReconciliationOutcome = "unresolved"
+)
+
+// ReconciliationReport is one run of comparing our orders with the payment
+// gateway. Matched orders are only counted; fixed and unresolved ones are
+// kept as items for finance to review.
+type ReconciliationReport struct {
+	ID         uuid.UUID            `gorm:"column:id;type:char(36);primaryKey;not null"`
+	StartedAt  time.Time            `gorm:"column:started_at;type:timestamp;not null;index"`
+	FinishedAt time.Time            `gorm:"column:finished_at;type:timestamp;not null"`
+	Matched    int                  `gorm:"column:matched;type:int;not null;default:0"`
+	Fixed      int                  `gorm:"column:fixed;type:int;not null;default:0"`
+	Unresolved int                  `gorm:"column:unresolved;type:int;not null;default:0"`
+	Items      []ReconciliationItem `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE"`
+	CreatedAt  *time.Time           `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+}
+
+func (r *ReconciliationReport) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	r.ID = id
+	return
+}
+
+// ReconciliationItem is an order whose status differed from the gateway's.
+// LocalStatus is what we had recorded before the run.
+type ReconciliationItem struct {
+	ID            uuid.UUID             `gorm:"column:id;type:char(36);primaryKey;not null"`
+	ReportID      uuid.UUID             `gorm:"column:report_id;type:char(36);not null;index"`
+	OrderID       string                `gorm:"column:order_id;type:varchar(255);not null;index"`
+	LocalStatus   string                `gorm:"column:local_status;type:varchar(20);not null"`
+	GatewayStatus *string               `gorm:"column:gateway_status;type:varchar(20)"`
+	Outcome       ReconciliationOutcome `gorm:"column:outcome;type:varchar(20);not null"`
+	Note          *string               `gorm:"column:note;type:text"`
+	CreatedAt     *time.Time            `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+}
+
+func (i *ReconciliationItem) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	i.ID = id
+	return
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefundStatus string
+
+const (
+	RefundRequested RefundStatus = "requested"
+	RefundApproved  RefundStatus = "approved"
+	RefundRejected  RefundStatus = "rejected"
+	RefundRefunded  RefundStatus = "refunded"
+)
+
+type RefundDestination string
+
+const (
+	RefundToWallet  RefundDestination = "wallet"
+	RefundToGateway RefundDestination = "gateway"
+)
+
+// Refund returns part of one payment after its subscription was cancelled:
+// PaidAmount pro rata for UndeliveredDays out of TotalDays, less Fee. It waits
+// for an admin to approve it. A refund to the customer's wallet is refunded
+// on approval; one paid out by the gateway is refunded once the gateway
+// reports the money was returned. PayerID is set when someone other than
+// the subscription's owner paid the order, as the buyer of a gift does; wallet
+// refunds go to them.
+type Refund struct {
+	ID              uuid.UUID          `gorm:"column:id;type:char(36);primaryKey;not null"`
+	SubscriptionID  uuid.UUID          `gorm:"column:subscription_id;type:char(36);not null;index"`
+	Subscription    *Subscription      `gorm:"foreignKey:subscription_id;constraint:OnDelete:CASCADE"`
+	OrderID         string             `gorm:"column:order_id;type:varchar(255);unique;not null"`
+	PaidAmount      float64            `gorm:"column:paid_amount;type:decimal(15,2);not null"`
+	UndeliveredDays int                `gorm:"column:undelivered_days;type:int;not null"`
+	TotalDays       int                `gorm:"column:total_days;type:int;not null"`
+	Fee             float64            `gorm:"column:fee;type:decimal(15,2);not null;default:0"`
+	Amount          float64            `gorm:"column:amount;type:decimal(15,2);not null"`
+	Status          RefundStatus       `gorm:"column:status;type:varchar(20);default:'requested';not null;index"`
+	Destination     *RefundDestination `gorm:"column:destination;type:varchar(20)"`
+	PayerID         *uuid.UUID         `gorm:"column:payer_id;type:char(36)"`
+	RefundKey       *string            `gorm:"column:refund_key;type:varchar(255)"`
+	RejectReason    *string            `gorm:"column:reject_reason;type:text"`
+	ReviewedAt      *time.Time         `gorm:"column:reviewed_at;type:timestamp"`
+	RefundedAt      *time.Time         `gorm:"column:refunded_at;type:timestamp"`
+	CreatedAt       *time.Time         `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt       *time.Time         `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (r *Refund) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	r.ID = id
+	return
+}
@@ -26,13 +26,24 @@ type Subscription struct {
 	Name            string             `gorm:"column:name;type:varchar(255);not null"`
 	PhoneNumber     string             `gorm:"column:phone_number;type:varchar(20);not null"`
 	DeliveryAddress string             `gorm:"column:delivery_address;type:text;not null"`
+	City            *string            `gorm:"column:city;type:varchar(100)"`
+	PostalCode      *string            `gorm:"column:postal_code;type:varchar(10)"`
+	Latitude        *float64           `gorm:"column:latitude;type:decimal(9,6)"`
+	Longitude       *float64           `gorm:"column:longitude;type:decimal(9,6)"`
+	DeliveryZoneID  *uuid.UUID         `gorm:"column:delivery_zone_id;type:char(36);index"`
+	DeliveryZone    *DeliveryZone      `gorm:"foreignKey:delivery_zone_id;constraint:OnDelete:SET NULL"`
 	DeliveryNotes   *string            `gorm:"column:delivery_notes;type:text"`
 	MealTypes       string             `gorm:"column:meal_types;type:text;not null"`
 	DeliveryDays    string             `gorm:"column:delivery_days;type:text;not null"`
 	Allergies       *string            `gorm:"column:allergies;type:text"`
+	Subtotal        float64            `gorm:"column:subtotal;type:decimal(15,2);not null;default:0"`
+	DeliveryFee     float64            `gorm:"column:delivery_fee;type:decimal(15,2);not null;default:0"`
+	TaxAmount       float64            `gorm:"column:tax_amount;type:decimal(15,2);not null;default:0"`
 	TotalPrice      float64            `gorm:"column:total_price;type:decimal(15,2);not null"`
 	Status          SubscriptionStatus `gorm:"column:status;type:varchar(20);default:'pending'"`
 	OrderID         *string            `gorm:"column:order_id;type:varchar(255);unique"`
+	AutoRenew       bool               `gorm:"column:auto_renew;type:bool;default:false"`
+	CreditBalance   float64            `gorm:"column:credit_balance;type:decimal(15,2);not null;default:0"`
 	PauseStartDate  *time.Time         `gorm:"column:pause_start_date;type:date"`
 	PauseEndDate    *time.Time         `gorm:"column:pause_end_date;type:date"`
 	StartDate       time.Time          `gorm:"column:start_date;type:date;not null"`
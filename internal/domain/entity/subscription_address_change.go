@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type AddressChangeStatus string
+
+const (
+	AddressChangePending   AddressChangeStatus = "pending"
+	AddressChangeApplied   AddressChangeStatus = "applied"
+	AddressChangeCancelled AddressChangeStatus = "cancelled"
+)
+
+// SubscriptionAddressChange moves a running subscription to another delivery
+// address from EffectiveDate on. The address is copied from the customer's
+// address book when the change is scheduled, together with the delivery zone
+// covering it, and replaces the subscription's own on the effective date.
+type SubscriptionAddressChange struct {
+	ID              uuid.UUID           `gorm:"column:id;type:char(36);primaryKey;not null"`
+	SubscriptionID  uuid.UUID           `gorm:"column:subscription_id;type:char(36);not null;index"`
+	Subscription    *Subscription       `gorm:"foreignKey:subscription_id;constraint:OnDelete:CASCADE"`
+	UserAddressID   *uuid.UUID          `gorm:"column:user_address_id;type:char(36)"`
+	UserAddress     *UserAddress        `gorm:"foreignKey:user_address_id;constraint:OnDelete:SET NULL"`
+	Name            string              `gorm:"column:name;type:varchar(255);not null"`
+	PhoneNumber     string              `gorm:"column:phone_number;type:varchar(20);not null"`
+	DeliveryAddress string              `gorm:"column:delivery_address;type:text;not null"`
+	City            string              `gorm:"column:city;type:varchar(100);not null"`
+	PostalCode      string              `gorm:"column:postal_code;type:varchar(10);not null"`
+	Latitude        *float64            `gorm:"column:latitude;type:decimal(9,6)"`
+	Longitude       *float64            `gorm:"column:longitude;type:decimal(9,6)"`
+	DeliveryNotes   *string             `gorm:"column:delivery_notes;type:text"`
+	DeliveryZoneID  *uuid.UUID          `gorm:"column:delivery_zone_id;type:char(36)"`
+	DeliveryZone    *DeliveryZone       `gorm:"foreignKey:delivery_zone_id;constraint:OnDelete:SET NULL"`
+	EffectiveDate   time.Time           `gorm:"column:effective_date;type:date;not null;index"`
+	Status          AddressChangeStatus `gorm:"column:status;type:varchar(20);default:'pending';not null"`
+	AppliedAt       *time.Time          `gorm:"column:applied_at;type:timestamp"`
+	CreatedAt       *time.Time          `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt       *time.Time          `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (ac *SubscriptionAddressChange) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	ac.ID = id
+	return
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SubscriptionChangeStatus string
+
+const (
+	ChangePending   SubscriptionChangeStatus = "pending"
+	ChangeApplied   SubscriptionChangeStatus = "applied"
+	ChangeCancelled SubscriptionChangeStatus = "cancelled"
+)
+
+// SubscriptionChange records a mid-period change of meal plan, meal types or
+// delivery days. ProratedAmount is the price difference for the remaining
+// days: positive for an upgrade, negative for a downgrade credited back.
+// Upgrades not fully covered by credit wait for ChargeAmount to be paid,
+// charged with the line items in ItemDetails.
+type SubscriptionChange struct {
+	ID              uuid.UUID                `gorm:"column:id;type:char(36);primaryKey;not null"`
+	SubscriptionID  uuid.UUID                `gorm:"column:subscription_id;type:char(36);not null;index"`
+	Subscription    *Subscription            `gorm:"foreignKey:subscription_id;constraint:OnDelete:CASCADE"`
+	OldMealPlanID   uuid.UUID                `gorm:"column:old_meal_plan_id;type:char(36);not null"`
+	NewMealPlanID   uuid.UUID                `gorm:"column:new_meal_plan_id;type:char(36);not null"`
+	OldMealTypes    string                   `gorm:"column:old_meal_types;type:text;not null"`
+	NewMealTypes    string                   `gorm:"column:new_meal_types;type:text;not null"`
+	OldDeliveryDays string                   `gorm:"column:old_delivery_days;type:text;not null"`
+	NewDeliveryDays string                   `gorm:"column:new_delivery_days;type:text;not null"`
+	OldTotalPrice   float64                  `gorm:"column:old_total_price;type:decimal(15,2);not null"`
+	NewSubtotal     float64                  `gorm:"column:new_subtotal;type:decimal(15,2);not null;default:0"`
+	NewDeliveryFee  float64                  `gorm:"column:new_delivery_fee;type:decimal(15,2);not null;default:0"`
+	NewTaxAmount    float64                  `gorm:"column:new_tax_amount;type:decimal(15,2);not null;default:0"`
+	NewTotalPrice   float64                  `gorm:"column:new_total_price;type:decimal(15,2);not null"`
+	RemainingDays   int                      `gorm:"column:remaining_days;type:int;not null"`
+	ProratedAmount  float64                  `gorm:"column:prorated_amount;type:decimal(15,2);not null"`
+	CreditApplied   float64                  `gorm:"column:credit_applied;type:decimal(15,2);not null;default:0"`
+	ChargeAmount    float64                  `gorm:"column:charge_amount;type:decimal(15,2);not null;default:0"`
+	ItemDetails     *string                  `gorm:"column:item_details;type:jsonb"`
+	OrderID         *string                  `gorm:"column:order_id;type:varchar(255);unique"`
+	PaymentURL      *string                  `gorm:"column:payment_url;type:text"`
+	Status          SubscriptionChangeStatus `gorm:"column:status;type:varchar(20);default:'pending';not null"`
+	AppliedAt       *time.Time               `gorm:"column:applied_at;type:timestamp"`
+	CreatedAt       *time.Time               `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt       *time.Time               `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (sc *SubscriptionChange) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	sc.ID = id
+	return
+}
@@ -12,6 +12,7 @@ type UserRole string
 const (
 	RoleUser UserRole = "user"
 	RoleAdmin UserRole = "admin"
+	RoleCourier UserRole = "courier"
 )
 
 type User struct {
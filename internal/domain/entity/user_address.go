@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserAddress is an entry in a customer's address book. Subscriptions copy
+// the address they are placed with, so editing or deleting an entry never
+// changes where an existing subscription is delivered.
+type UserAddress struct {
+	ID            uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	UserID        uuid.UUID  `gorm:"column:user_id;type:char(36);not null;index"`
+	User          *User      `gorm:"foreignKey:user_id;constraint:OnDelete:CASCADE"`
+	Label         string     `gorm:"column:label;type:varchar(50);not null"`
+	RecipientName string     `gorm:"column:recipient_name;type:varchar(255);not null"`
+	PhoneNumber   string     `gorm:"column:phone_number;type:varchar(20);not null"`
+	Address       string     `gorm:"column:address;type:text;not null"`
+	City          string     `gorm:"column:city;type:varchar(100);not null"`
+	PostalCode    string     `gorm:"column:postal_code;type:varchar(10);not null"`
+	Latitude      *float64   `gorm:"column:latitude;type:decimal(9,6)"`
+	Longitude     *float64   `gorm:"column:longitude;type:decimal(9,6)"`
+	Notes         *string    `gorm:"column:notes;type:text"`
+	IsDefault     bool       `gorm:"column:is_default;type:bool;not null;default:false"`
+	CreatedAt     *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt     *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (a *UserAddress) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	a.ID = id
+	return
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WalletTransactionType string
+
+const (
+	WalletCredit WalletTransactionType = "credit"
+	WalletDebit  WalletTransactionType = "debit"
+)
+
+type WalletReason string
+
+const (
+	WalletRefund              WalletReason = "refund"
+	WalletSkipCredit          WalletReason = "skip_credit"
+	WalletSkipReversal        WalletReason = "skip_reversal"
+	WalletSubscriptionPayment WalletReason = "subscription_payment"
+	WalletSubscriptionRelease WalletReason = "subscription_release"
+	WalletAdjustment          WalletReason = "adjustment"
+)
+
+// Wallet holds a user's store credit. It is opened on the first credit, and
+// Balance always equals the sum of its ledger.
+type Wallet struct {
+	ID        uuid.UUID  `gorm:"column:id;type:char(36);primaryKey;not null"`
+	UserID    uuid.UUID  `gorm:"column:user_id;type:char(36);unique;not null"`
+	User      *User      `gorm:"foreignKey:user_id;constraint:OnDelete:CASCADE"`
+	Balance   float64    `gorm:"column:balance;type:decimal(15,2);not null;default:0"`
+	CreatedAt *time.Time `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:timestamp;autoUpdateTime"`
+}
+
+func (w *Wallet) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	w.ID = id
+	return
+}
+
+// WalletTransaction is one entry of a wallet's append-only ledger. Amount is
+// always positive; Type says which way it moved the balance. Reference
+// points at what caused it, such as a refund, delivery or subscription, and
+// CreatedBy is the admin behind an adjustment.
+type WalletTransaction struct {
+	ID           uuid.UUID             `gorm:"column:id;type:char(36);primaryKey;not null"`
+	WalletID     uuid.UUID             `gorm:"column:wallet_id;type:char(36);not null;index"`
+	Wallet       *Wallet               `gorm:"foreignKey:wallet_id;constraint:OnDelete:CASCADE"`
+	UserID       uuid.UUID             `gorm:"column:user_id;type:char(36);not null;index"`
+	Type         WalletTransactionType `gorm:"column:type;type:varchar(10);not null"`
+	Amount       float64               `gorm:"column:amount;type:decimal(15,2);not null"`
+	BalanceAfter float64               `gorm:"column:balance_after;type:decimal(15,2);not null"`
+	Reason       WalletReason          `gorm:"column:reason;type:varchar(30);not null"`
+	Reference    *string               `gorm:"column:reference;type:varchar(255);index"`
+	Note         *string               `gorm:"column:note;type:text"`
+	CreatedBy    *uuid.UUID            `gorm:"column:created_by;type:char(36)"`
+	CreatedAt    *time.Time            `gorm:"column:created_at;type:timestamp;autoCreateTime"`
+}
+
+func (wt *WalletTransaction) BeforeCreate(tx *gorm.DB) (err error) {
+	id, _ := uuid.NewV7()
+	wt.ID = id
+	return
+}
@@ -1,12 +1,21 @@
 package email
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	conf "github.com/Ablebil/sea-catering-be/config"
 	"gopkg.in/gomail.v2"
 )
 
 type EmailItf interface {
 	SendOTPEmail(to string, otp string) error
+	SendRenewalEmail(to string, planName string, amount float64, paymentURL string, dueDate time.Time) error
+	SendExpiryReminderEmail(to string, planName string, endDate time.Time) error
+	SendInvoiceEmail(to string, invoiceNumber string, amount float64, downloadURL string) error
+	SendReconciliationReportEmail(to string, startedAt time.Time, matched int, fixed int, unresolved int, details []string) error
+	SendGiftEmail(to string, recipientName string, senderName string, planName string, code string, message *string) error
 }
 
 type Email struct {
@@ -28,6 +37,89 @@ func (e *Email) SendOTPEmail(to string, otp string) error {
 	mail.SetHeader("Subject", "Your OTP Code")
 	mail.SetBody("text/plain", "Your OTP code is: "+otp)
 
+	return e.send(mail)
+}
+
+func (e *Email) SendRenewalEmail(to string, planName string, amount float64, paymentURL string, dueDate time.Time) error {
+	mail := gomail.NewMessage()
+	mail.SetHeader("From", e.sender)
+	mail.SetHeader("To", to)
+	mail.SetHeader("Subject", "Your Subscription Renewal")
+	mail.SetBody("text/plain", fmt.Sprintf(
+		"Your %s subscription renews on %s. Please pay Rp%.0f before then to keep your deliveries going: %s",
+		planName, dueDate.Format("2 January 2006"), amount, paymentURL,
+	))
+
+	return e.send(mail)
+}
+
+func (e *Email) SendExpiryReminderEmail(to string, planName string, endDate time.Time) error {
+	mail := gomail.NewMessage()
+	mail.SetHeader("From", e.sender)
+	mail.SetHeader("To", to)
+	mail.SetHeader("Subject", "Your Subscription Is Ending Soon")
+	mail.SetBody("text/plain", fmt.Sprintf(
+		"Your %s subscription ends on %s. Turn on auto-renew or subscribe again to keep your deliveries going.",
+		planName, endDate.Format("2 January 2006"),
+	))
+
+	return e.send(mail)
+}
+
+func (e *Email) SendInvoiceEmail(to string, invoiceNumber string, amount float64, downloadURL string) error {
+	mail := gomail.NewMessage()
+	mail.SetHeader("From", e.sender)
+	mail.SetHeader("To", to)
+	mail.SetHeader("Subject", "Your Invoice "+invoiceNumber)
+	mail.SetBody("text/plain", fmt.Sprintf(
+		"Thank you for your payment of Rp%.0f. Your invoice %s is ready to download: %s",
+		amount, invoiceNumber, downloadURL,
+	))
+
+	return e.send(mail)
+}
+
+func (e *Email) SendReconciliationReportEmail(to string, startedAt time.Time, matched int, fixed int, unresolved int, details []string) error {
+	body := fmt.Sprintf(
+		"Payment reconciliation of %s\n\nMatched: %d\nFixed: %d\nUnresolved: %d\n",
+		startedAt.Format("2 January 2006 15:04"), matched, fixed, unresolved,
+	)
+
+	if len(details) > 0 {
+		body += "\n" + strings.Join(details, "\n") + "\n"
+	}
+
+	mail := gomail.NewMessage()
+	mail.SetHeader("From", e.sender)
+	mail.SetHeader("To", to)
+	mail.SetHeader("Subject", "Payment Reconciliation Report")
+	mail.SetBody("text/plain", body)
+
+	return e.send(mail)
+}
+
+func (e *Email) SendGiftEmail(to string, recipientName string, senderName string, planName string, code string, message *string) error {
+	body := fmt.Sprintf(
+		"Hi %s,\n\n%s has given you a %s subscription from SEA Catering!\n",
+		recipientName, senderName, planName,
+	)
+
+	if message != nil && *message != "" {
+		body += "\n\"" + *message + "\"\n"
+	}
+
+	body += fmt.Sprintf("\nSign in and claim it with this code to start managing your deliveries: %s\n", code)
+
+	mail := gomail.NewMessage()
+	mail.SetHeader("From", e.sender)
+	mail.SetHeader("To", to)
+	mail.SetHeader("Subject", senderName+" Sent You a Meal Plan")
+	mail.SetBody("text/plain", body)
+
+	return e.send(mail)
+}
+
+func (e *Email) send(mail *gomail.Message) error {
 	dialer := gomail.NewDialer("smtp.gmail.com", 587, e.sender, e.password)
 	return dialer.DialAndSend(mail)
 }
@@ -0,0 +1,190 @@
+package event
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/infra/redis"
+	gojson "github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+type Type string
+
+const (
+	PaymentSettled            Type = "payment.settled"
+	PaymentFailed             Type = "payment.failed"
+	SubscriptionStatusChanged Type = "subscription.status_changed"
+	DeliveryStatusChanged     Type = "delivery.status_changed"
+)
+
+// channel is the Redis channel every API instance publishes to and listens
+// on, so an event reaches the user wherever their stream is connected.
+const channel = "events"
+
+// subscriberBuffer is how many events a stream may fall behind before new
+// ones are dropped for it.
+const subscriberBuffer = 16
+
+// Resubscribing after the subscription to Redis closes waits minBackoff,
+// doubling on every failed attempt up to maxBackoff.
+const (
+	minBackoff = time.Second
+	maxBackoff = 30 * time.Second
+)
+
+// Event is something that happened to one user's orders. Data is the JSON
+// payload sent to the user's streams.
+type Event struct {
+	ID     uuid.UUID         `json:"id"`
+	UserID uuid.UUID         `json:"user_id"`
+	Type   Type              `json:"type"`
+	Data   gojson.RawMessage `json:"data"`
+	Time   time.Time         `json:"time"`
+}
+
+type EventItf interface {
+	Publish(userID uuid.UUID, eventType Type, data interface{}) error
+	Subscribe(userID uuid.UUID) (<-chan Event, func())
+}
+
+// EventHub fans the events published on Redis out to the streams open on this
+// instance, keyed by the user they belong to. Once ctx is done every stream's
+// channel is closed, so open streams end and the server can shut down.
+type EventHub struct {
+	ctx         context.Context
+	redis       redis.RedisItf
+	mu          sync.Mutex
+	closed      bool
+	subscribers map[uuid.UUID]map[chan Event]struct{}
+}
+
+func NewEvent(ctx context.Context, redis redis.RedisItf) EventItf {
+	hub := &EventHub{
+		ctx:         ctx,
+		redis:       redis,
+		subscribers: make(map[uuid.UUID]map[chan Event]struct{}),
+	}
+
+	go hub.listen()
+	return hub
+}
+
+func (h *EventHub) Publish(userID uuid.UUID, eventType Type, data interface{}) error {
+	payload, err := gojson.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	message, err := gojson.Marshal(Event{
+		ID:     uuid.New(),
+		UserID: userID,
+		Type:   eventType,
+		Data:   payload,
+		Time:   time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return h.redis.Publish(channel, message)
+}
+
+// Subscribe returns the user's events as they arrive and a function that
+// stops them, which must be called once the stream closes. The channel is
+// closed when the hub shuts down.
+func (h *EventHub) Subscribe(userID uuid.UUID) (<-chan Event, func()) {
+	events := make(chan Event, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		close(events)
+		return events, func() {}
+	}
+
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[chan Event]struct{})
+	}
+
+	h.subscribers[userID][events] = struct{}{}
+	h.mu.Unlock()
+
+	return events, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		delete(h.subscribers[userID], events)
+		if len(h.subscribers[userID]) == 0 {
+			delete(h.subscribers, userID)
+		}
+	}
+}
+
+// listen relays events from Redis until the hub's context is done. Should
+// the subscription close before then, it subscribes again after a backoff
+// rather than leaving this instance's streams silent.
+func (h *EventHub) listen() {
+	defer h.close()
+
+	backoff := minBackoff
+	for {
+		for message := range h.redis.Subscribe(h.ctx, channel) {
+			backoff = minBackoff
+
+			var e Event
+			if err := gojson.Unmarshal(message, &e); err != nil {
+				log.Printf("Dropping unreadable event: %v", err)
+				continue
+			}
+
+			h.dispatch(e)
+		}
+
+		if h.ctx.Err() != nil {
+			log.Printf("Stopped listening for events on %s", channel)
+			return
+		}
+
+		log.Printf("Subscription to %s closed, resubscribing in %s", channel, backoff)
+		select {
+		case <-h.ctx.Done():
+			log.Printf("Stopped listening for events on %s", channel)
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// close ends every open stream and turns new ones away.
+func (h *EventHub) close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for userID, streams := range h.subscribers {
+		for events := range streams {
+			close(events)
+		}
+
+		delete(h.subscribers, userID)
+	}
+}
+
+// dispatch hands an event to each of its user's streams. A stream too far
+// behind misses it rather than holding up everyone else's.
+func (h *EventHub) dispatch(e Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for events := range h.subscribers[e.UserID] {
+		select {
+		case events <- e:
+		default:
+		}
+	}
+}
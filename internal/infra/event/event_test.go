@@ -0,0 +1,119 @@
+package event
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/infra/redis"
+	gojson "github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+// fakeRedis hands out one subscription per Subscribe call, so a test can
+// close it to simulate the connection to Redis going away.
+type fakeRedis struct {
+	redis.RedisItf
+	subscriptions chan chan []byte
+}
+
+func (f *fakeRedis) Subscribe(ctx context.Context, channel string) <-chan []byte {
+	messages := make(chan []byte)
+	f.subscriptions <- messages
+	return messages
+}
+
+func nextSubscription(t *testing.T, f *fakeRedis, within time.Duration) chan []byte {
+	t.Helper()
+
+	select {
+	case messages := <-f.subscriptions:
+		return messages
+	case <-time.After(within):
+		t.Fatal("hub did not subscribe to Redis")
+		return nil
+	}
+}
+
+func send(t *testing.T, messages chan []byte, e Event) {
+	t.Helper()
+
+	raw, err := gojson.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	messages <- raw
+}
+
+func receive(t *testing.T, events <-chan Event) Event {
+	t.Helper()
+
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("stream closed")
+		}
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return Event{}
+	}
+}
+
+func TestEventHubResubscribes(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	fake := &fakeRedis{subscriptions: make(chan chan []byte)}
+	hub := NewEvent(ctx, fake)
+
+	userID := uuid.New()
+	events, unsubscribe := hub.Subscribe(userID)
+	defer unsubscribe()
+
+	first := nextSubscription(t, fake, time.Second)
+	send(t, first, Event{ID: uuid.New(), UserID: userID, Type: PaymentSettled})
+	if e := receive(t, events); e.Type != PaymentSettled {
+		t.Fatalf("got %s, want %s", e.Type, PaymentSettled)
+	}
+
+	// The subscription dropping must not leave the stream silent.
+	close(first)
+
+	second := nextSubscription(t, fake, minBackoff+time.Second)
+	send(t, second, Event{ID: uuid.New(), UserID: userID, Type: DeliveryStatusChanged})
+	if e := receive(t, events); e.Type != DeliveryStatusChanged {
+		t.Fatalf("got %s, want %s", e.Type, DeliveryStatusChanged)
+	}
+}
+
+func TestEventHubClosesStreamsOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	fake := &fakeRedis{subscriptions: make(chan chan []byte)}
+	hub := NewEvent(ctx, fake)
+
+	events, unsubscribe := hub.Subscribe(uuid.New())
+	defer unsubscribe()
+
+	messages := nextSubscription(t, fake, time.Second)
+
+	// The real client closes its channel once ctx is done.
+	cancel()
+	close(messages)
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Fatal("got an event, want the stream closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("stream not closed on shutdown")
+	}
+
+	late, _ := hub.Subscribe(uuid.New())
+	if _, ok := <-late; ok {
+		t.Fatal("stream opened after shutdown is not closed")
+	}
+}
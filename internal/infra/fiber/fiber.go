@@ -45,11 +45,18 @@ func New(conf *conf.Config) *fiber.App {
 
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: conf.FEURL,
-		AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
+		AllowMethods: "GET, POST, PUT, PATCH, DELETE, OPTIONS",
 		AllowHeaders: "Content-Type, Authorization",
 	}))
 
-	app.Use(compress.New(compress.Config{Level: compress.LevelBestSpeed}))
+	// Event streams are left uncompressed, since compressing would buffer
+	// their events instead of sending them as they happen.
+	app.Use(compress.New(compress.Config{
+		Level: compress.LevelBestSpeed,
+		Next: func(ctx *fiber.Ctx) bool {
+			return ctx.Get(fiber.HeaderAccept) == "text/event-stream"
+		},
+	}))
 	app.Use(limiter.Global())
 
 	return app
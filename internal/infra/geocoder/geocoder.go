@@ -0,0 +1,44 @@
+package geocoder
+
+import (
+	"errors"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/geo"
+)
+
+var ErrAddressNotFound = errors.New("address could not be located")
+
+type Address struct {
+	Street     string
+	City       string
+	PostalCode string
+}
+
+type Location struct {
+	Point      geo.Point
+	City       string
+	PostalCode string
+}
+
+// GeocoderItf locates an address on the map. Geocode returns
+// ErrAddressNotFound when it cannot place the address at all.
+type GeocoderItf interface {
+	Geocode(address Address) (*Location, error)
+}
+
+// NewGeocoder picks the geocoder named by GEOCODER, defaulting to the
+// offline postal code table.
+func NewGeocoder(conf *conf.Config) GeocoderItf {
+	switch conf.Geocoder {
+	case "", "postal_code":
+		geocoder, err := NewPostalCodeGeocoder(conf.GeocoderPostalCodesFile)
+		if err != nil {
+			panic(err)
+		}
+
+		return geocoder
+	default:
+		panic("Invalid GEOCODER: " + conf.Geocoder)
+	}
+}
@@ -0,0 +1,106 @@
+package geocoder
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Ablebil/sea-catering-be/internal/pkg/geo"
+)
+
+// defaultPostalCodes maps postal code prefixes in the areas we deliver to
+// onto the rough centre of their city.
+//
+//go:embed postal_codes.csv
+var defaultPostalCodes []byte
+
+// PostalCodeGeocoder places an address at the centre of the area its postal
+// code belongs to, using a table of postal code prefixes. It works offline,
+// so it is only as precise as the table; customers who share their exact
+// location are placed there instead.
+type PostalCodeGeocoder struct {
+	entries []postalCodeEntry
+}
+
+type postalCodeEntry struct {
+	prefix string
+	city   string
+	point  geo.Point
+}
+
+// NewPostalCodeGeocoder loads the table from path, a CSV file with prefix,
+// city, latitude and longitude columns and a header row. Without a path it
+// uses the built-in table.
+func NewPostalCodeGeocoder(path string) (*PostalCodeGeocoder, error) {
+	table := defaultPostalCodes
+	if path != "" {
+		var err error
+		table, err = os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(table))
+	reader.FieldsPerRecord = 4
+
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("reading postal code table header: %w", err)
+	}
+
+	g := &PostalCodeGeocoder{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("reading postal code table: %w", err)
+		}
+
+		lat, latErr := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		lng, lngErr := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+		if latErr != nil || lngErr != nil {
+			return nil, fmt.Errorf("invalid coordinates for postal code prefix %q", record[0])
+		}
+
+		g.entries = append(g.entries, postalCodeEntry{
+			prefix: strings.TrimSpace(record[0]),
+			city:   strings.TrimSpace(record[1]),
+			point:  geo.Point{Latitude: lat, Longitude: lng},
+		})
+	}
+
+	// Longer prefixes are tried first so the most specific area wins.
+	sort.SliceStable(g.entries, func(i, j int) bool {
+		return len(g.entries[i].prefix) > len(g.entries[j].prefix)
+	})
+
+	return g, nil
+}
+
+func (g *PostalCodeGeocoder) Geocode(address Address) (*Location, error) {
+	postalCode := strings.TrimSpace(address.PostalCode)
+	if postalCode == "" {
+		return nil, ErrAddressNotFound
+	}
+
+	for _, entry := range g.entries {
+		if strings.HasPrefix(postalCode, entry.prefix) {
+			return &Location{
+				Point:      entry.point,
+				City:       entry.city,
+				PostalCode: postalCode,
+			}, nil
+		}
+	}
+
+	return nil, ErrAddressNotFound
+}
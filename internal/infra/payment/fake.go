@@ -0,0 +1,361 @@
+package payment
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	gojson "github.com/goccy/go-json"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// Fake is an in-memory payment gateway for local development and
+// integration tests. Its checkout page is served by the app itself, and
+// paying or declining there posts a signed webhook to the app, just like a
+// real provider would. Orders are lost when the process restarts.
+type Fake struct {
+	mu         sync.Mutex
+	orders     map[string]*fakeOrder
+	secret     []byte
+	appURL     string
+	httpClient *http.Client
+}
+
+type fakeOrder struct {
+	req           dto.ChargeRequest
+	transactionID string
+	status        string
+	paymentType   string
+	updatedAt     time.Time
+	refunded      int64
+	refundKeys    map[string]bool
+}
+
+type fakeNotification struct {
+	OrderID           string    `json:"order_id"`
+	TransactionID     string    `json:"transaction_id"`
+	TransactionStatus string    `json:"transaction_status"`
+	GrossAmount       string    `json:"gross_amount"`
+	PaymentType       string    `json:"payment_type"`
+	TransactionTime   time.Time `json:"transaction_time"`
+}
+
+func NewFake(conf *conf.Config) PaymentGatewayItf {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(err)
+	}
+
+	return &Fake{
+		orders:     make(map[string]*fakeOrder),
+		secret:     secret,
+		appURL:     strings.TrimSuffix(conf.AppUrl, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (f *Fake) CreateCharge(req *dto.ChargeRequest) (*dto.PaymentResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.orders[req.OrderID]; ok {
+		return nil, fmt.Errorf("fake gateway already has order %s", req.OrderID)
+	}
+
+	order := &fakeOrder{
+		req:           *req,
+		transactionID: uuid.NewString(),
+		status:        "pending",
+		updatedAt:     time.Now(),
+		refundKeys:    make(map[string]bool),
+	}
+	f.orders[req.OrderID] = order
+
+	return &dto.PaymentResponse{
+		Token:       order.transactionID,
+		RedirectURL: f.appURL + "/fake-gateway/checkout/" + req.OrderID,
+	}, nil
+}
+
+func (f *Fake) GetStatus(orderID string) (*dto.TransactionStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	order, ok := f.orders[orderID]
+	if !ok {
+		return nil, ErrOrderNotFound
+	}
+
+	notification := order.notification()
+	raw, _ := gojson.Marshal(notification)
+	return notification.toTransactionStatus(string(raw)), nil
+}
+
+func (f *Fake) ExpireCharge(orderID string) error {
+	if !f.complete(orderID, "expire", "") {
+		return nil
+	}
+
+	go f.notifyAndLog(orderID)
+	return nil
+}
+
+func (f *Fake) Refund(req *dto.GatewayRefundRequest) (*dto.GatewayRefundResponse, error) {
+	f.mu.Lock()
+	order, ok := f.orders[req.OrderID]
+	if !ok {
+		f.mu.Unlock()
+		return nil, fmt.Errorf("fake gateway has no order %s", req.OrderID)
+	}
+
+	if !order.refundKeys[req.RefundKey] {
+		if order.status != "settlement" && order.status != "partial_refund" {
+			f.mu.Unlock()
+			return nil, fmt.Errorf("fake gateway cannot refund order %s in status %s", req.OrderID, order.status)
+		}
+
+		if order.refunded+req.Amount > order.req.Amount {
+			f.mu.Unlock()
+			return nil, fmt.Errorf("fake gateway cannot refund more than order %s was paid", req.OrderID)
+		}
+
+		order.refundKeys[req.RefundKey] = true
+		order.refunded += req.Amount
+		order.status = "partial_refund"
+		if order.refunded == order.req.Amount {
+			order.status = "refund"
+		}
+		order.updatedAt = time.Now()
+	}
+
+	resp := &dto.GatewayRefundResponse{
+		RefundKey:         req.RefundKey,
+		RefundAmount:      fmt.Sprintf("%d.00", req.Amount),
+		TransactionStatus: order.status,
+	}
+	f.mu.Unlock()
+
+	// The refund is being recorded by a transaction that waits for this
+	// call, so its notification can only be delivered afterwards.
+	go f.notifyAndLog(req.OrderID)
+	return resp, nil
+}
+
+// ParseWebhook checks the X-Fake-Signature header, an HMAC-SHA256 of the
+// body under a key that only lives in this process.
+func (f *Fake) ParseWebhook(header http.Header, body []byte) (*dto.TransactionStatus, error) {
+	signature := header.Get("X-Fake-Signature")
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(f.sign(body))) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	var notification fakeNotification
+	if err := gojson.Unmarshal(body, &notification); err != nil {
+		return nil, ErrInvalidNotification
+	}
+
+	if notification.OrderID == "" || notification.TransactionStatus == "" {
+		return nil, ErrInvalidNotification
+	}
+
+	return notification.toTransactionStatus(string(body)), nil
+}
+
+// RegisterRoutes serves the checkout page the fake's payment links point to.
+func (f *Fake) RegisterRoutes(router fiber.Router) {
+	router.Get("/fake-gateway/checkout/:orderID", f.checkoutPage)
+	router.Post("/fake-gateway/checkout/:orderID/:action", f.checkoutAction)
+}
+
+func (f *Fake) checkoutPage(ctx *fiber.Ctx) error {
+	f.mu.Lock()
+	order, ok := f.orders[ctx.Params("orderID")]
+	var data map[string]interface{}
+	if ok {
+		data = map[string]interface{}{
+			"OrderID":  order.req.OrderID,
+			"Status":   order.status,
+			"Amount":   order.req.Amount,
+			"Customer": order.req.CustomerDetails,
+			"Items":    order.req.ItemDetails,
+			"Payable":  order.status == "pending",
+		}
+	}
+	f.mu.Unlock()
+
+	if !ok {
+		return ctx.Status(fiber.StatusNotFound).SendString("Unknown order")
+	}
+
+	return render(ctx, checkoutTemplate, data)
+}
+
+func (f *Fake) checkoutAction(ctx *fiber.Ctx) error {
+	orderID := ctx.Params("orderID")
+
+	var status string
+	switch ctx.Params("action") {
+	case "pay":
+		status = "settlement"
+	case "deny":
+		status = "deny"
+	case "expire":
+		status = "expire"
+	default:
+		return ctx.Status(fiber.StatusNotFound).SendString("Unknown action")
+	}
+
+	if !f.complete(orderID, status, "fake_checkout") {
+		return ctx.Status(fiber.StatusConflict).SendString("Order is not awaiting payment")
+	}
+
+	data := map[string]interface{}{"OrderID": orderID, "Status": status}
+	if err := f.notify(orderID); err != nil {
+		data["Error"] = err.Error()
+	}
+
+	return render(ctx, resultTemplate, data)
+}
+
+// complete moves a pending order to status, reporting whether it did.
+func (f *Fake) complete(orderID string, status string, paymentType string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	order, ok := f.orders[orderID]
+	if !ok || order.status != "pending" {
+		return false
+	}
+
+	order.status = status
+	order.paymentType = paymentType
+	order.updatedAt = time.Now()
+	return true
+}
+
+// notify posts the order's current status to the app's payment webhook.
+func (f *Fake) notify(orderID string) error {
+	f.mu.Lock()
+	order, ok := f.orders[orderID]
+	if !ok {
+		f.mu.Unlock()
+		return fmt.Errorf("fake gateway has no order %s", orderID)
+	}
+
+	body, err := gojson.Marshal(order.notification())
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, f.appURL+"/api/v1/subscriptions/webhook/payment", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Fake-Signature", f.sign(body))
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (f *Fake) notifyAndLog(orderID string) {
+	if err := f.notify(orderID); err != nil {
+		log.Printf("Fake gateway failed to notify order %s: %v", orderID, err)
+	}
+}
+
+func (f *Fake) sign(body []byte) string {
+	mac := hmac.New(sha256.New, f.secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (o *fakeOrder) notification() fakeNotification {
+	return fakeNotification{
+		OrderID:           o.req.OrderID,
+		TransactionID:     o.transactionID,
+		TransactionStatus: o.status,
+		GrossAmount:       fmt.Sprintf("%d.00", o.req.Amount),
+		PaymentType:       o.paymentType,
+		TransactionTime:   o.updatedAt,
+	}
+}
+
+func (n fakeNotification) toTransactionStatus(raw string) *dto.TransactionStatus {
+	return &dto.TransactionStatus{
+		OrderID:           n.OrderID,
+		TransactionID:     n.TransactionID,
+		TransactionStatus: n.TransactionStatus,
+		GrossAmount:       n.GrossAmount,
+		PaymentType:       n.PaymentType,
+		TransactionTime:   n.TransactionTime,
+		Raw:               raw,
+	}
+}
+
+func render(ctx *fiber.Ctx, tmpl *template.Template, data map[string]interface{}) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	ctx.Type("html")
+	return ctx.Send(buf.Bytes())
+}
+
+var checkoutTemplate = template.Must(template.New("checkout").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Fake checkout {{.OrderID}}</title></head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 40px auto">
+<h1>Fake checkout</h1>
+<p>This payment page is simulated. No money changes hands.</p>
+<p>Order <code>{{.OrderID}}</code> for {{.Customer.Name}} ({{.Customer.Email}})</p>
+<table style="width: 100%">
+{{range .Items}}<tr><td>{{.Name}}</td><td style="text-align: right">IDR {{.Price}}</td></tr>
+{{end}}<tr><th style="text-align: left">Total</th><th style="text-align: right">IDR {{.Amount}}</th></tr>
+</table>
+{{if .Payable}}
+<form method="post" action="{{.OrderID}}/pay"><button type="submit">Pay</button></form>
+<form method="post" action="{{.OrderID}}/deny"><button type="submit">Decline</button></form>
+<form method="post" action="{{.OrderID}}/expire"><button type="submit">Let it expire</button></form>
+{{else}}
+<p>This order is <strong>{{.Status}}</strong>.</p>
+{{end}}
+</body>
+</html>
+`))
+
+var resultTemplate = template.Must(template.New("result").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Fake checkout {{.OrderID}}</title></head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 40px auto">
+<h1>Fake checkout</h1>
+<p>Order <code>{{.OrderID}}</code> is now <strong>{{.Status}}</strong>.</p>
+{{if .Error}}<p>The webhook could not be delivered: {{.Error}}</p>{{else}}<p>The webhook was delivered.</p>{{end}}
+</body>
+</html>
+`))
@@ -0,0 +1,244 @@
+package payment
+
+import (
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	gojson "github.com/goccy/go-json"
+	"github.com/midtrans/midtrans-go"
+	"github.com/midtrans/midtrans-go/coreapi"
+	"github.com/midtrans/midtrans-go/snap"
+)
+
+type Midtrans struct {
+	snapClient *snap.Client
+	coreClient *coreapi.Client
+	conf       *conf.Config
+}
+
+// NewMidtrans talks to the Midtrans sandbox unless MIDTRANS_ENVIRONMENT is
+// production.
+func NewMidtrans(conf *conf.Config) PaymentGatewayItf {
+	env := midtrans.Sandbox
+	if conf.MidtransEnvironment == "production" {
+		env = midtrans.Production
+	}
+
+	var snapClient snap.Client
+	snapClient.New(conf.MidtransServerKey, env)
+
+	var coreClient coreapi.Client
+	coreClient.New(conf.MidtransServerKey, env)
+
+	if conf.MidtransAPIURL != "" {
+		httpClient := newRedirectedHttpClient(conf.MidtransAPIURL, env)
+		snapClient.HttpClient = httpClient
+		coreClient.HttpClient = httpClient
+	}
+
+	return &Midtrans{
+		snapClient: &snapClient,
+		coreClient: &coreClient,
+		conf:       conf,
+	}
+}
+
+func (m *Midtrans) CreateCharge(req *dto.ChargeRequest) (*dto.PaymentResponse, error) {
+	var items []midtrans.ItemDetails
+	for _, item := range req.ItemDetails {
+		items = append(items, midtrans.ItemDetails{
+			ID:    item.ID,
+			Price: item.Price,
+			Qty:   item.Qty,
+			Name:  item.Name,
+		})
+	}
+
+	expiry := req.ExpiryDuration
+	if expiry <= 0 {
+		expiry = m.conf.MidtransPaymentDuration
+	}
+
+	snapReq := &snap.Request{
+		TransactionDetails: midtrans.TransactionDetails{
+			OrderID:  req.OrderID,
+			GrossAmt: req.Amount,
+		},
+		CustomerDetail: &midtrans.CustomerDetails{
+			FName: req.CustomerDetails.Name,
+			Email: req.CustomerDetails.Email,
+			Phone: req.CustomerDetails.Phone,
+		},
+		Items: &items,
+		Expiry: &snap.ExpiryDetails{
+			StartTime: time.Now().Format("2006-01-02 15:04:05 -0700"),
+			Unit:      "minutes",
+			Duration:  int64(expiry.Minutes()),
+		},
+		CustomField1: req.SubscriptionID.String(),
+	}
+
+	snapResp, err := m.snapClient.CreateTransaction(snapReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PaymentResponse{
+		Token:       snapResp.Token,
+		RedirectURL: snapResp.RedirectURL,
+	}, nil
+}
+
+func (m *Midtrans) GetStatus(orderID string) (*dto.TransactionStatus, error) {
+	statusResp, midtransErr := m.coreClient.CheckTransaction(orderID)
+	if midtransErr != nil {
+		if midtransErr.StatusCode == http.StatusNotFound {
+			return nil, ErrOrderNotFound
+		}
+
+		return nil, midtransErr
+	}
+
+	raw, _ := gojson.Marshal(statusResp)
+	return &dto.TransactionStatus{
+		OrderID:           statusResp.OrderID,
+		TransactionID:     statusResp.TransactionID,
+		TransactionStatus: statusResp.TransactionStatus,
+		GrossAmount:       statusResp.GrossAmount,
+		PaymentType:       statusResp.PaymentType,
+		FraudStatus:       statusResp.FraudStatus,
+		TransactionTime:   parseTransactionTime(statusResp.TransactionTime),
+		Raw:               string(raw),
+	}, nil
+}
+
+// ExpireCharge voids an unpaid transaction. Midtrans only knows an order
+// once a payment method was picked on the Snap page, so a 404 is expected.
+func (m *Midtrans) ExpireCharge(orderID string) error {
+	_, midtransErr := m.coreClient.ExpireTransaction(orderID)
+	if midtransErr != nil && midtransErr.StatusCode != http.StatusNotFound {
+		return midtransErr
+	}
+
+	return nil
+}
+
+func (m *Midtrans) Refund(req *dto.GatewayRefundRequest) (*dto.GatewayRefundResponse, error) {
+	refundResp, midtransErr := m.coreClient.RefundTransaction(req.OrderID, &coreapi.RefundReq{
+		RefundKey: req.RefundKey,
+		Amount:    req.Amount,
+		Reason:    req.Reason,
+	})
+	if midtransErr != nil {
+		return nil, midtransErr
+	}
+
+	if refundResp.StatusCode != "200" {
+		return nil, fmt.Errorf("midtrans refused refund of %s: %s %s", req.OrderID, refundResp.StatusCode, refundResp.StatusMessage)
+	}
+
+	return &dto.GatewayRefundResponse{
+		RefundKey:         refundResp.RefundKey,
+		RefundAmount:      refundResp.RefundAmount,
+		TransactionStatus: refundResp.TransactionStatus,
+	}, nil
+}
+
+// ParseWebhook checks the notification's signature_key, which Midtrans
+// computes as SHA512(order_id + status_code + gross_amount + server key).
+// The body is only trusted as a hint; with MIDTRANS_VERIFY_STATUS set, the
+// Status API is the source of truth for what actually happened.
+func (m *Midtrans) ParseWebhook(header http.Header, body []byte) (*dto.TransactionStatus, error) {
+	var notification dto.MidtransNotification
+	if err := gojson.Unmarshal(body, &notification); err != nil {
+		return nil, ErrInvalidNotification
+	}
+
+	if notification.OrderID == "" || notification.TransactionStatus == "" || notification.StatusCode == "" || notification.GrossAmount == "" {
+		return nil, ErrInvalidNotification
+	}
+
+	expected := Signature(notification.OrderID, notification.StatusCode, notification.GrossAmount, m.conf.MidtransServerKey)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(notification.SignatureKey)) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	status := &dto.TransactionStatus{
+		OrderID:           notification.OrderID,
+		TransactionID:     notification.TransactionID,
+		TransactionStatus: notification.TransactionStatus,
+		GrossAmount:       notification.GrossAmount,
+		PaymentType:       notification.PaymentType,
+		FraudStatus:       notification.FraudStatus,
+		TransactionTime:   parseTransactionTime(notification.TransactionTime),
+	}
+
+	if m.conf.MidtransVerifyStatus {
+		confirmed, err := m.GetStatus(notification.OrderID)
+		if err != nil {
+			return nil, err
+		}
+
+		if confirmed.OrderID != notification.OrderID {
+			return nil, ErrInvalidNotification
+		}
+
+		status = confirmed
+	}
+
+	status.Raw = string(body)
+	return status, nil
+}
+
+func Signature(orderID string, statusCode string, grossAmount string, serverKey string) string {
+	sum := sha512.Sum512([]byte(orderID + statusCode + grossAmount + serverKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// redirectTransport sends every request to baseURL regardless of the host the
+// Midtrans SDK picked, so the client can talk to a fake server.
+type redirectTransport struct {
+	baseURL *url.URL
+	next    http.RoundTripper
+}
+
+func (t *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.baseURL.Scheme
+	req.URL.Host = t.baseURL.Host
+	req.Host = t.baseURL.Host
+	return t.next.RoundTrip(req)
+}
+
+// parseTransactionTime reads Midtrans' transaction_time, which is in WIB
+// without an offset.
+func parseTransactionTime(transactionTime string) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", transactionTime, time.FixedZone("WIB", 7*60*60))
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
+
+func newRedirectedHttpClient(baseURL string, env midtrans.EnvironmentType) midtrans.HttpClient {
+	target, err := url.Parse(baseURL)
+	if err != nil {
+		panic("Invalid MIDTRANS_API_URL: " + baseURL)
+	}
+
+	return &midtrans.HttpClientImplementation{
+		HttpClient: &http.Client{
+			Timeout:   midtrans.DefaultHttpTimeout,
+			Transport: &redirectTransport{baseURL: target, next: http.DefaultTransport},
+		},
+		Logger: midtrans.GetDefaultLogger(env),
+	}
+}
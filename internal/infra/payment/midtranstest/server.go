@@ -0,0 +1,176 @@
+// Package midtranstest provides a fake Midtrans API for exercising the
+// payment flow without reaching the sandbox. Point MIDTRANS_API_URL at
+// Server.URL and use Notify to build signed webhook payloads.
+package midtranstest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/infra/payment"
+	gojson "github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+type transaction struct {
+	OrderID           string `json:"order_id"`
+	TransactionID     string `json:"transaction_id"`
+	TransactionStatus string `json:"transaction_status"`
+	StatusCode        string `json:"status_code"`
+	GrossAmount       string `json:"gross_amount"`
+	PaymentType       string `json:"payment_type"`
+	FraudStatus       string `json:"fraud_status"`
+	TransactionTime   string `json:"transaction_time"`
+}
+
+type Server struct {
+	*httptest.Server
+	serverKey    string
+	mu           sync.Mutex
+	transactions map[string]*transaction
+}
+
+func NewServer(serverKey string) *Server {
+	s := &Server{
+		serverKey:    serverKey,
+		transactions: make(map[string]*transaction),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/snap/v1/transactions", s.createTransaction)
+	mux.HandleFunc("/v2/", s.getStatus)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// SetStatus changes what the status API reports for orderID, as if the
+// customer had progressed through the Snap page.
+func (s *Server) SetStatus(orderID string, transactionStatus string, paymentType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trx, ok := s.transactions[orderID]
+	if !ok {
+		trx = &transaction{OrderID: orderID, TransactionID: uuid.NewString(), GrossAmount: "0.00"}
+		s.transactions[orderID] = trx
+	}
+
+	trx.TransactionStatus = transactionStatus
+	trx.StatusCode = statusCode(transactionStatus)
+	trx.PaymentType = paymentType
+	trx.FraudStatus = "accept"
+}
+
+// SetFraudStatus changes the fraud review outcome reported for orderID, such
+// as "challenge" for a card capture held for review.
+func (s *Server) SetFraudStatus(orderID string, fraudStatus string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if trx, ok := s.transactions[orderID]; ok {
+		trx.FraudStatus = fraudStatus
+	}
+}
+
+// Notify returns a correctly signed notification for the current state of
+// orderID, ready to be posted to the webhook.
+func (s *Server) Notify(orderID string) *dto.MidtransNotification {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trx, ok := s.transactions[orderID]
+	if !ok {
+		return nil
+	}
+
+	return &dto.MidtransNotification{
+		TransactionTime:   trx.TransactionTime,
+		TransactionStatus: trx.TransactionStatus,
+		TransactionID:     trx.TransactionID,
+		StatusCode:        trx.StatusCode,
+		SignatureKey:      payment.Signature(trx.OrderID, trx.StatusCode, trx.GrossAmount, s.serverKey),
+		PaymentType:       trx.PaymentType,
+		OrderID:           trx.OrderID,
+		GrossAmount:       trx.GrossAmount,
+		FraudStatus:       trx.FraudStatus,
+		Currency:          "IDR",
+	}
+}
+
+func (s *Server) createTransaction(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		TransactionDetails struct {
+			OrderID  string `json:"order_id"`
+			GrossAmt int64  `json:"gross_amount"`
+		} `json:"transaction_details"`
+	}
+
+	if err := gojson.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"error_messages": []string{err.Error()}})
+		return
+	}
+
+	orderID := req.TransactionDetails.OrderID
+	token := uuid.NewString()
+
+	s.mu.Lock()
+	s.transactions[orderID] = &transaction{
+		OrderID:           orderID,
+		TransactionID:     uuid.NewString(),
+		TransactionStatus: "pending",
+		StatusCode:        statusCode("pending"),
+		GrossAmount:       fmt.Sprintf("%d.00", req.TransactionDetails.GrossAmt),
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"token":        token,
+		"redirect_url": s.URL + "/snap/v3/redirection/" + token,
+	})
+}
+
+func (s *Server) getStatus(w http.ResponseWriter, r *http.Request) {
+	orderID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v2/"), "/status")
+
+	s.mu.Lock()
+	trx, ok := s.transactions[orderID]
+	var body transaction
+	if ok {
+		body = *trx
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{
+			"status_code":    "404",
+			"status_message": "Transaction doesn't exist.",
+		})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+func statusCode(transactionStatus string) string {
+	switch transactionStatus {
+	case "capture", "settlement", "refund", "partial_refund":
+		return "200"
+	case "pending":
+		return "201"
+	case "expire":
+		return "407"
+	default:
+		return "202"
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	gojson.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,52 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+)
+
+var (
+	ErrInvalidSignature    = errors.New("webhook signature does not match")
+	ErrInvalidNotification = errors.New("webhook payload is malformed")
+	ErrOrderNotFound       = errors.New("payment gateway has no such order")
+	ErrIgnoredNotification = errors.New("webhook carries no order status")
+)
+
+// PaymentGatewayItf is what the app needs from a payment provider: a hosted
+// checkout page per order, its status, voiding and refunding it, and reading
+// the provider's webhooks. Orders are identified by our own order ID.
+type PaymentGatewayItf interface {
+	CreateCharge(req *dto.ChargeRequest) (*dto.PaymentResponse, error)
+	// GetStatus returns ErrOrderNotFound for an order the provider has never
+	// seen, which for some providers means no payment was attempted yet.
+	GetStatus(orderID string) (*dto.TransactionStatus, error)
+	// ExpireCharge voids an unpaid order so it can no longer be paid. An
+	// order the provider has never seen has nothing to void.
+	ExpireCharge(orderID string) error
+	// Refund returns part or all of a paid order. Retrying with the same
+	// refund key does not refund twice.
+	Refund(req *dto.GatewayRefundRequest) (*dto.GatewayRefundResponse, error)
+	// ParseWebhook authenticates a webhook delivery and reads the order
+	// status from it. It returns ErrInvalidSignature when the delivery did
+	// not come from the provider, and ErrIgnoredNotification for a genuine
+	// delivery that changes no order, which is acknowledged and logged.
+	ParseWebhook(header http.Header, body []byte) (*dto.TransactionStatus, error)
+}
+
+// NewPaymentGateway picks the provider named by PAYMENT_GATEWAY, defaulting
+// to Midtrans.
+func NewPaymentGateway(conf *conf.Config) PaymentGatewayItf {
+	switch conf.PaymentGateway {
+	case "", "midtrans":
+		return NewMidtrans(conf)
+	case "xendit":
+		return NewXendit(conf)
+	case "fake":
+		return NewFake(conf)
+	default:
+		panic("Invalid PAYMENT_GATEWAY: " + conf.PaymentGateway)
+	}
+}
@@ -0,0 +1,364 @@
+package payment
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	gojson "github.com/goccy/go-json"
+)
+
+const xenditAPIURL = "https://api.xendit.co"
+
+// Xendit charges through the Invoice API, whose hosted invoice page plays the
+// part of the Midtrans Snap page. Our order ID is the invoice's external_id.
+type Xendit struct {
+	httpClient *http.Client
+	baseURL    string
+	conf       *conf.Config
+}
+
+func NewXendit(conf *conf.Config) PaymentGatewayItf {
+	baseURL := conf.XenditAPIURL
+	if baseURL == "" {
+		baseURL = xenditAPIURL
+	}
+
+	return &Xendit{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		conf:       conf,
+	}
+}
+
+type xenditInvoice struct {
+	ID             string  `json:"id"`
+	ExternalID     string  `json:"external_id"`
+	Status         string  `json:"status"`
+	Amount         float64 `json:"amount"`
+	PaidAmount     float64 `json:"paid_amount"`
+	PaymentMethod  string  `json:"payment_method"`
+	PaymentChannel string  `json:"payment_channel"`
+	PaidAt         string  `json:"paid_at"`
+	InvoiceURL     string  `json:"invoice_url"`
+}
+
+type xenditError struct {
+	StatusCode int
+	ErrorCode  string `json:"error_code"`
+	Message    string `json:"message"`
+}
+
+func (e *xenditError) Error() string {
+	return fmt.Sprintf("xendit responded %d %s: %s", e.StatusCode, e.ErrorCode, e.Message)
+}
+
+func (x *Xendit) CreateCharge(req *dto.ChargeRequest) (*dto.PaymentResponse, error) {
+	expiry := req.ExpiryDuration
+	if expiry <= 0 {
+		expiry = x.conf.MidtransPaymentDuration
+	}
+
+	// Xendit wants items at or above zero, so discounts and spent credit go
+	// in as negative fees instead.
+	items := []map[string]interface{}{}
+	fees := []map[string]interface{}{}
+	for _, item := range req.ItemDetails {
+		if item.Price < 0 {
+			fees = append(fees, map[string]interface{}{
+				"type":  item.Name,
+				"value": item.Price * int64(item.Qty),
+			})
+			continue
+		}
+
+		items = append(items, map[string]interface{}{
+			"reference_id": item.ID,
+			"name":         item.Name,
+			"quantity":     item.Qty,
+			"price":        item.Price,
+		})
+	}
+
+	body := map[string]interface{}{
+		"external_id":      req.OrderID,
+		"amount":           req.Amount,
+		"currency":         "IDR",
+		"description":      "SEA Catering subscription " + req.OrderID,
+		"invoice_duration": int64(expiry.Seconds()),
+		"payer_email":      req.CustomerDetails.Email,
+		"customer": map[string]string{
+			"given_names":   req.CustomerDetails.Name,
+			"email":         req.CustomerDetails.Email,
+			"mobile_number": req.CustomerDetails.Phone,
+		},
+		"items":    items,
+		"metadata": map[string]string{"subscription_id": req.SubscriptionID.String()},
+	}
+
+	if len(fees) > 0 {
+		body["fees"] = fees
+	}
+
+	var invoice xenditInvoice
+	if err := x.do(http.MethodPost, "/v2/invoices", nil, body, &invoice); err != nil {
+		return nil, err
+	}
+
+	return &dto.PaymentResponse{
+		Token:       invoice.ID,
+		RedirectURL: invoice.InvoiceURL,
+	}, nil
+}
+
+func (x *Xendit) GetStatus(orderID string) (*dto.TransactionStatus, error) {
+	invoice, err := x.findInvoice(orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice == nil {
+		return nil, ErrOrderNotFound
+	}
+
+	raw, _ := gojson.Marshal(invoice)
+	return invoice.toTransactionStatus(string(raw)), nil
+}
+
+func (x *Xendit) ExpireCharge(orderID string) error {
+	invoice, err := x.findInvoice(orderID)
+	if err != nil || invoice == nil {
+		return err
+	}
+
+	if invoice.Status != "PENDING" {
+		return nil
+	}
+
+	return x.do(http.MethodPost, "/invoices/"+url.PathEscape(invoice.ID)+"/expire!", nil, nil, nil)
+}
+
+// Refund refunds through the Refund API, which settles asynchronously for
+// most payment methods; such refunds are reported as still processing.
+func (x *Xendit) Refund(req *dto.GatewayRefundRequest) (*dto.GatewayRefundResponse, error) {
+	invoice, err := x.findInvoice(req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if invoice == nil {
+		return nil, fmt.Errorf("xendit has no invoice for order %s", req.OrderID)
+	}
+
+	var refund struct {
+		ReferenceID string  `json:"reference_id"`
+		Amount      float64 `json:"amount"`
+		Status      string  `json:"status"`
+		FailureCode string  `json:"failure_code"`
+	}
+
+	header := http.Header{}
+	header.Set("Idempotency-key", req.RefundKey)
+	if err := x.do(http.MethodPost, "/refunds", header, map[string]interface{}{
+		"invoice_id":   invoice.ID,
+		"reference_id": req.RefundKey,
+		"amount":       req.Amount,
+		"currency":     "IDR",
+		"reason":       "REQUESTED_BY_CUSTOMER",
+		"metadata":     map[string]string{"note": req.Reason},
+	}, &refund); err != nil {
+		return nil, err
+	}
+
+	resp := &dto.GatewayRefundResponse{
+		RefundKey:    refund.ReferenceID,
+		RefundAmount: strconv.FormatFloat(refund.Amount, 'f', 2, 64),
+	}
+
+	switch refund.Status {
+	case "FAILED":
+		return nil, fmt.Errorf("xendit refused refund of %s: %s", req.OrderID, refund.FailureCode)
+	case "SUCCEEDED":
+		resp.TransactionStatus = "partial_refund"
+		if refund.Amount >= invoice.Amount {
+			resp.TransactionStatus = "refund"
+		}
+	}
+
+	return resp, nil
+}
+
+type xenditRefundCallback struct {
+	Event string `json:"event"`
+	Data  struct {
+		ID          string  `json:"id"`
+		InvoiceID   string  `json:"invoice_id"`
+		ReferenceID string  `json:"reference_id"`
+		Amount      float64 `json:"amount"`
+		Status      string  `json:"status"`
+		FailureCode string  `json:"failure_code"`
+	} `json:"data"`
+}
+
+// ParseWebhook reads an invoice or refund callback. Xendit authenticates
+// callbacks with the verification token from its dashboard in
+// x-callback-token.
+func (x *Xendit) ParseWebhook(header http.Header, body []byte) (*dto.TransactionStatus, error) {
+	token := header.Get("X-Callback-Token")
+	if x.conf.XenditCallbackToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(x.conf.XenditCallbackToken)) != 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	var refund xenditRefundCallback
+	if err := gojson.Unmarshal(body, &refund); err == nil && strings.HasPrefix(refund.Event, "refund.") {
+		return x.parseRefundCallback(&refund, string(body))
+	}
+
+	var invoice xenditInvoice
+	if err := gojson.Unmarshal(body, &invoice); err != nil {
+		return nil, ErrInvalidNotification
+	}
+
+	if invoice.ExternalID == "" || invoice.Status == "" {
+		return nil, ErrInvalidNotification
+	}
+
+	return invoice.toTransactionStatus(string(body)), nil
+}
+
+// parseRefundCallback reports a refund that settled after Refund returned.
+// Refund callbacks name the invoice rather than our order, so the invoice is
+// fetched to find the order and whether the refund covered all of it.
+func (x *Xendit) parseRefundCallback(refund *xenditRefundCallback, raw string) (*dto.TransactionStatus, error) {
+	if refund.Data.InvoiceID == "" {
+		return nil, ErrInvalidNotification
+	}
+
+	if refund.Data.Status != "SUCCEEDED" {
+		return nil, fmt.Errorf("%w: refund %s is %s %s", ErrIgnoredNotification, refund.Data.ReferenceID, refund.Data.Status, refund.Data.FailureCode)
+	}
+
+	var invoice xenditInvoice
+	if err := x.do(http.MethodGet, "/v2/invoices/"+url.PathEscape(refund.Data.InvoiceID), nil, nil, &invoice); err != nil {
+		return nil, err
+	}
+
+	status := invoice.toTransactionStatus(raw)
+	status.TransactionID = refund.Data.ID
+	status.TransactionStatus = "partial_refund"
+	if refund.Data.Amount >= invoice.Amount {
+		status.TransactionStatus = "refund"
+	}
+
+	return status, nil
+}
+
+func (x *Xendit) findInvoice(orderID string) (*xenditInvoice, error) {
+	var invoices []xenditInvoice
+	err := x.do(http.MethodGet, "/v2/invoices?external_id="+url.QueryEscape(orderID), nil, nil, &invoices)
+
+	var xErr *xenditError
+	if errors.As(err, &xErr) && xErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(invoices) == 0 {
+		return nil, nil
+	}
+
+	return &invoices[0], nil
+}
+
+func (x *Xendit) do(method string, path string, header http.Header, body interface{}, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		raw, err := gojson.Marshal(body)
+		if err != nil {
+			return err
+		}
+
+		reqBody = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, x.baseURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	req.SetBasicAuth(x.conf.XenditSecretKey, "")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := x.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		xErr := &xenditError{StatusCode: resp.StatusCode}
+		_ = gojson.Unmarshal(respBody, xErr)
+		return xErr
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return gojson.Unmarshal(respBody, out)
+}
+
+// toTransactionStatus maps an invoice onto the Midtrans vocabulary. A PAID
+// invoice has been paid but not yet disbursed to us, which is what Midtrans
+// calls settlement as far as the customer is concerned.
+func (i *xenditInvoice) toTransactionStatus(raw string) *dto.TransactionStatus {
+	status := &dto.TransactionStatus{
+		OrderID:         i.ExternalID,
+		TransactionID:   i.ID,
+		GrossAmount:     strconv.FormatFloat(i.Amount, 'f', 2, 64),
+		PaymentType:     strings.ToLower(i.PaymentMethod),
+		TransactionTime: parseXenditTime(i.PaidAt),
+		Raw:             raw,
+	}
+
+	switch i.Status {
+	case "PAID", "SETTLED":
+		status.TransactionStatus = "settlement"
+	case "EXPIRED":
+		status.TransactionStatus = "expire"
+	default:
+		status.TransactionStatus = "pending"
+	}
+
+	return status
+}
+
+func parseXenditTime(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return t
+}
@@ -0,0 +1,76 @@
+package payment
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	conf "github.com/Ablebil/sea-catering-be/config"
+)
+
+func TestXenditRefundCallback(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/invoices/inv-1" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"inv-1","external_id":"SUBS-1","status":"PAID","amount":180600,"payment_method":"EWALLET"}`))
+	}))
+	defer api.Close()
+
+	gateway := NewXendit(&conf.Config{XenditAPIURL: api.URL, XenditCallbackToken: "token"})
+
+	header := http.Header{}
+	header.Set("X-Callback-Token", "token")
+
+	tests := []struct {
+		name    string
+		body    string
+		want    string
+		wantErr error
+	}{
+		{
+			name: "full refund",
+			body: `{"event":"refund.succeeded","data":{"id":"rfd-1","invoice_id":"inv-1","reference_id":"key","amount":180600,"status":"SUCCEEDED"}}`,
+			want: "refund",
+		},
+		{
+			name: "partial refund",
+			body: `{"event":"refund.succeeded","data":{"id":"rfd-2","invoice_id":"inv-1","reference_id":"key","amount":60200,"status":"SUCCEEDED"}}`,
+			want: "partial_refund",
+		},
+		{
+			name:    "failed refund",
+			body:    `{"event":"refund.failed","data":{"id":"rfd-3","invoice_id":"inv-1","reference_id":"key","amount":60200,"status":"FAILED","failure_code":"INSUFFICIENT_BALANCE"}}`,
+			wantErr: ErrIgnoredNotification,
+		},
+		{
+			name:    "missing invoice",
+			body:    `{"event":"refund.succeeded","data":{"id":"rfd-4","amount":60200,"status":"SUCCEEDED"}}`,
+			wantErr: ErrInvalidNotification,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := gateway.ParseWebhook(header, []byte(tt.body))
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("got %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if status.OrderID != "SUBS-1" || status.GrossAmount != "180600.00" || status.TransactionStatus != tt.want {
+				t.Fatalf("got order %s, gross amount %s, status %s, want SUBS-1, 180600.00, %s", status.OrderID, status.GrossAmount, status.TransactionStatus, tt.want)
+			}
+		})
+	}
+}
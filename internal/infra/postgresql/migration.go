@@ -9,9 +9,27 @@ func Migrate(db *gorm.DB) error {
 	return db.AutoMigrate(
 		&entity.User{},
 		&entity.RefreshToken{},
+		&entity.UserAddress{},
 		&entity.Testimonial{},
 		&entity.MealPlan{},
+		&entity.DeliveryZone{},
 		&entity.Subscription{},
 		&entity.SubscriptionStatusLog{},
+		&entity.BillingPeriod{},
+		&entity.SubscriptionChange{},
+		&entity.SubscriptionAddressChange{},
+		&entity.Delivery{},
+		&entity.Promo{},
+		&entity.PromoMealPlan{},
+		&entity.PromoRedemption{},
+		&entity.Payment{},
+		&entity.PaymentNotification{},
+		&entity.Invoice{},
+		&entity.Refund{},
+		&entity.ReconciliationReport{},
+		&entity.ReconciliationItem{},
+		&entity.Wallet{},
+		&entity.WalletTransaction{},
+		&entity.Gift{},
 	)
 }
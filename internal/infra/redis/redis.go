@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"time"
 
 	conf "github.com/Ablebil/sea-catering-be/config"
@@ -18,6 +19,8 @@ type RedisItf interface {
 	SetOAuthState(state string, value []byte, exp time.Duration) error
 	GetOAuthState(state string) ([]byte, error)
 	DeleteOAuthState(state string) error
+	Publish(channel string, message []byte) error
+	Subscribe(ctx context.Context, channel string) <-chan []byte
 }
 
 type Redis struct {
@@ -95,3 +98,40 @@ func (r *Redis) DeleteOAuthState(state string) error {
 	key := "gstate:" + state
 	return r.store.Delete(key)
 }
+
+func (r *Redis) Publish(channel string, message []byte) error {
+	return r.store.Conn().Publish(context.Background(), channel, message).Err()
+}
+
+// Subscribe delivers the messages published on channel until ctx is done,
+// then closes the returned channel. The client reconnects and resubscribes
+// by itself when the connection drops; messages sent meanwhile are lost.
+func (r *Redis) Subscribe(ctx context.Context, channel string) <-chan []byte {
+	pubsub := r.store.Conn().Subscribe(ctx, channel)
+	messages := make(chan []byte)
+
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+
+		incoming := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-incoming:
+				if !ok {
+					return
+				}
+
+				select {
+				case messages <- []byte(message.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return messages
+}
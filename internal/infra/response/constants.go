@@ -30,10 +30,23 @@ const (
 
 // User Domain
 const (
+	UserAddressNotFound = "Address not found"
+	CannotChangeOwnRole = "You cannot change your own role"
+
 	FailedRemoveUnverifiedUsers = "Failed to remove unverified users"
 	FailedGetUserProfile        = "Failed to get user profile"
+	FailedGetUserAddresses      = "Failed to get addresses"
+	FailedSaveUserAddress       = "Failed to save address"
+	FailedDeleteUserAddress     = "Failed to delete address"
+	FailedUpdateUserRole        = "Failed to update user role"
 
-	GetProfileSuccess = "Get profile successful"
+	GetProfileSuccess        = "Get profile successful"
+	CreateUserAddressSuccess = "Address created successful"
+	GetUserAddressesSuccess  = "Get addresses successful"
+	GetUserAddressSuccess    = "Get address successful"
+	UpdateUserAddressSuccess = "Address updated successful"
+	DeleteUserAddressSuccess = "Address deleted successful"
+	UpdateUserRoleSuccess    = "User role updated successful"
 )
 
 // Testimonial Domain
@@ -60,7 +73,22 @@ const (
 
 // Subscription Domain
 const (
-	SubscriptionNotFound = "Subscription not found"
+	SubscriptionNotFound       = "Subscription not found"
+	InvalidStatusTransition    = "Subscription cannot move to the requested status"
+	StatusTransitionNotAllowed = "Not allowed to change subscription to the requested status"
+	PauseAlreadyScheduled      = "Subscription already has a pause scheduled"
+	PauseOutsidePeriod         = "Pause must fall within the subscription period"
+	SubscriptionNotPaused      = "Subscription is not paused"
+	SubscriptionNotRenewable   = "Subscription can no longer be renewed"
+	SubscriptionNotModifiable  = "Subscription can only be changed while active or paused"
+	SubscriptionChangePending  = "Subscription already has a change awaiting payment"
+	SubscriptionModified       = "Subscription was changed while this request was handled, try again"
+	NoSubscriptionChanges      = "Requested changes match the current subscription"
+	SubscriptionNotPending     = "Subscription is not awaiting payment"
+	InvalidEffectiveDate       = "Invalid effective date. Use YYYY-MM-DD."
+	AddressChangeTooSoon       = "Address change must take effect after the next delivery cutoff"
+	AddressChangeOutsidePeriod = "Address change must take effect before the subscription ends"
+	NoAddressChangePending     = "Subscription has no address change scheduled"
 
 	FailedSaveSubscription            = "Failed to save subscription"
 	FailedCreatePaymentTransaction    = "Failed to create payment transaction"
@@ -70,15 +98,37 @@ const (
 	FailedPauseSubscription           = "Failed to pause subscription"
 	FailedCancelSubscription          = "Failed to cancel subscription"
 	FailedGetExpiredSubscriptions     = "Failed to get expired subscriptions"
+	FailedResumeSubscription          = "Failed to resume subscription"
+	FailedGetPausedSubscriptions      = "Failed to get paused subscriptions"
 	FailedGetNewSubscriptionsCount    = "Failed to get new subscriptions count"
 	FailedCalculateMMR                = "Failed to calculate MMR"
 	FailedGetTotalActiveSubscriptions = "Failed to get total active subscriptions"
 	FailedGetReactivationStats        = "Failed to get reactivation stats"
+	FailedGetTransactionStatus        = "Failed to get transaction status"
+	FailedSaveBillingPeriod           = "Failed to save billing period"
+	FailedGetBillingPeriods           = "Failed to get billing periods"
+	FailedGetRenewalSubscriptions     = "Failed to get subscriptions due for renewal"
+	FailedSaveSubscriptionChange      = "Failed to save subscription change"
+	FailedGetSubscriptionChanges      = "Failed to get subscription changes"
+	FailedVoidPaymentTransaction      = "Failed to void previous payment transaction"
+	FailedGetPendingSubscriptions     = "Failed to get pending subscriptions"
+	FailedSaveAddressChange           = "Failed to save address change"
+	FailedGetAddressChanges           = "Failed to get address changes"
 
 	CreateSubscriptionSuccess          = "Subscription created successful"
+	QuoteSubscriptionSuccess           = "Quote subscription successful"
 	GetAllSubscriptionsSuccess         = "Get all subscriptions successful"
 	PauseSubscriptionSuccess           = "Subscription paused successful"
 	CancelSubscriptionSuccess          = "Subscription cancelled successful"
+	ResumeSubscriptionSuccess          = "Subscription resumed successful"
+	UpdateAutoRenewSuccess             = "Auto-renew updated successful"
+	GetBillingPeriodsSuccess           = "Get billing periods successful"
+	UpdateSubscriptionSuccess          = "Subscription updated successful"
+	GetSubscriptionChangesSuccess      = "Get subscription changes successful"
+	PaySubscriptionSuccess             = "Payment link issued successful"
+	ChangeDeliveryAddressSuccess       = "Address change scheduled successful"
+	CancelAddressChangeSuccess         = "Address change cancelled successful"
+	GetAddressChangesSuccess           = "Get address changes successful"
 	GetNewSubscriptionsStatsSuccess    = "Get new subscriptions stats success"
 	GetMRRStatsSuccess                 = "Get MRR stats success"
 	GetTotalActiveSubscriptionsSuccess = "Get total active subscriptions success"
@@ -86,6 +136,151 @@ const (
 	WebhookProcessedSuccess            = "Webhook processed successful"
 )
 
+// Payment Domain
+const (
+	ReconciliationReportNotFound = "Reconciliation report not found"
+
+	FailedGetPayments              = "Failed to get payments"
+	FailedSavePayment              = "Failed to save payment"
+	FailedSavePaymentNotification  = "Failed to save payment notification"
+	FailedGetInvoices              = "Failed to get invoices"
+	FailedGetReconciliationOrders  = "Failed to get orders to reconcile"
+	FailedGetReconciliationReports = "Failed to get reconciliation reports"
+	FailedSaveReconciliationReport = "Failed to save reconciliation report"
+
+	GetSubscriptionPaymentsSuccess  = "Get subscription payments successful"
+	GetPaymentsSuccess              = "Get payments successful"
+	GetSubscriptionInvoicesSuccess  = "Get subscription invoices successful"
+	GetReconciliationReportsSuccess = "Get reconciliation reports successful"
+	GetReconciliationReportSuccess  = "Get reconciliation report successful"
+)
+
+// Delivery Domain
+const (
+	DeliveryNotFound      = "Delivery not found"
+	DeliveryNotSkippable  = "Only scheduled deliveries of an active subscription can be skipped"
+	DeliveryNotSkipped    = "Delivery is not skipped"
+	DeliveryCutoffPassed  = "Changes to this delivery closed at the cutoff"
+	SkipCreditAlreadyUsed = "Credit from this skip has already been used"
+	DeliveryNotPickable   = "Only scheduled deliveries due today can be picked up"
+	DeliveryNotPickedUp   = "Delivery has not been picked up"
+	DeliveryHeldByOther   = "Delivery was picked up by another courier"
+	ProofPhotoRequired    = "A proof photo is required to mark a delivery as delivered"
+
+	FailedGetDeliveries        = "Failed to get deliveries"
+	FailedSyncDeliveries       = "Failed to sync deliveries"
+	FailedSkipDelivery         = "Failed to skip delivery"
+	FailedUnskipDelivery       = "Failed to unskip delivery"
+	FailedUpdateDeliveryStatus = "Failed to update delivery status"
+
+	GetDeliveriesSuccess        = "Get deliveries successful"
+	GetDeliveryManifestSuccess  = "Get delivery manifest successful"
+	GetRouteManifestSuccess     = "Get route manifest successful"
+	SkipDeliverySuccess         = "Delivery skipped successful"
+	UnskipDeliverySuccess       = "Delivery unskipped successful"
+	UpdateDeliveryStatusSuccess = "Delivery status updated successful"
+)
+
+// Promo Domain
+const (
+	PromoNotFound          = "Promo not found"
+	PromoCodeAlreadyExists = "Promo code already exists"
+	PromoAlreadyRedeemed   = "Promo has been redeemed and can only be deactivated"
+	InvalidPromoPercentage = "Percentage discount cannot exceed 100"
+	PromoNotValid          = "Promo code is invalid or has expired"
+	PromoFullyRedeemed     = "Promo code has reached its redemption limit"
+	PromoUserLimitReached  = "Promo code has already been used the maximum number of times"
+	PromoMealPlanExcluded  = "Promo code does not apply to this meal plan"
+	PromoMinSpendNotMet    = "Order does not reach the promo's minimum spend"
+
+	FailedGetAllPromos        = "Failed to get all promos"
+	FailedGetPromoByID        = "Failed to get promo by ID"
+	FailedSavePromo           = "Failed to save promo"
+	FailedDeletePromo         = "Failed to delete promo"
+	FailedGetPromoRedemptions = "Failed to get promo redemptions"
+	FailedReleasePromo        = "Failed to release promo redemption"
+
+	CreatePromoSuccess  = "Create promo successful"
+	GetAllPromosSuccess = "Get all promos successful"
+	GetPromoByIDSuccess = "Get promo by ID successful"
+	UpdatePromoSuccess  = "Update promo successful"
+	DeletePromoSuccess  = "Delete promo successful"
+)
+
+// Refund Domain
+const (
+	RefundNotFound        = "Refund not found"
+	RefundAlreadyReviewed = "Refund has already been approved or rejected"
+
+	FailedGetRefunds    = "Failed to get refunds"
+	FailedSaveRefund    = "Failed to save refund"
+	FailedRequestRefund = "Failed to request refund from payment gateway"
+
+	GetRefundsSuccess             = "Get refunds successful"
+	GetSubscriptionRefundsSuccess = "Get subscription refunds successful"
+	ApproveRefundSuccess          = "Refund approved successful"
+	RejectRefundSuccess           = "Refund rejected successful"
+)
+
+// Delivery Zone Domain
+const (
+	DeliveryZoneNotFound          = "Delivery zone not found"
+	DeliveryZoneNameAlreadyExists = "Delivery zone name already exists"
+	DeliveryZoneInUse             = "Delivery zone has subscriptions and can only be deactivated"
+	InvalidDeliveryZonePolygon    = "Polygon points must be valid latitude and longitude pairs"
+	AddressNotCovered             = "We do not deliver to this address yet"
+	AddressAreaRequired           = "City or postal code is required to check delivery coverage"
+	DeliveryDayNotServed          = "We do not deliver to this address on one or more of the chosen days"
+
+	FailedGetAllDeliveryZones  = "Failed to get all delivery zones"
+	FailedGetDeliveryZoneByID  = "Failed to get delivery zone by ID"
+	FailedSaveDeliveryZone     = "Failed to save delivery zone"
+	FailedDeleteDeliveryZone   = "Failed to delete delivery zone"
+	FailedCheckAddressCoverage = "Failed to check address coverage"
+
+	CreateDeliveryZoneSuccess  = "Create delivery zone successful"
+	GetAllDeliveryZonesSuccess = "Get all delivery zones successful"
+	GetDeliveryZoneByIDSuccess = "Get delivery zone by ID successful"
+	UpdateDeliveryZoneSuccess  = "Update delivery zone successful"
+	DeleteDeliveryZoneSuccess  = "Delete delivery zone successful"
+)
+
+// Wallet Domain
+const (
+	InsufficientWalletBalance = "Wallet balance is too low"
+
+	FailedGetWallet           = "Failed to get wallet"
+	FailedAdjustWallet        = "Failed to adjust wallet"
+	FailedReleaseWalletCredit = "Failed to return wallet credit"
+
+	GetWalletSuccess    = "Get wallet successful"
+	AdjustWalletSuccess = "Wallet adjusted successful"
+)
+
+// Gift Domain
+const (
+	GiftNotFound       = "Gift not found"
+	GiftAlreadyClaimed = "Gift has already been claimed"
+	GiftNotClaimable   = "Gift is not paid yet or its subscription has ended"
+	CannotClaimOwnGift = "You cannot claim a gift you bought"
+
+	FailedGetGifts  = "Failed to get gifts"
+	FailedSaveGift  = "Failed to save gift"
+	FailedClaimGift = "Failed to claim gift"
+
+	GetGiftsSuccess  = "Get gifts successful"
+	ClaimGiftSuccess = "Gift claimed successful"
+)
+
+// Kitchen Domain
+const (
+	FailedGetKitchenForecast    = "Failed to get kitchen forecast"
+	FailedExportKitchenForecast = "Failed to export kitchen forecast"
+	FailedGetMealLabels         = "Failed to get meal labels"
+
+	GetKitchenForecastSuccess = "Get kitchen forecast successful"
+)
+
 // Others
 const (
 	FailedHashPassword           = "Failed to hash password"
@@ -106,21 +301,33 @@ const (
 	FileSizeExceedsLimit         = "File size exceeds the limit"
 	InvalidFileType              = "Invalid file type. Only JPG, JPEG, and PNG are allowed."
 	InvalidOrderID               = "Invalid order ID"
+	InvalidDate                  = "Invalid date format. Use YYYY-MM-DD."
 	InvalidTransactionStatus     = "Invalid transaction status"
+	InvalidSignatureKey          = "Invalid signature key"
+	InvalidPaymentNotification   = "Invalid payment notification"
+	GrossAmountMismatch          = "Gross amount does not match subscription price"
 )
 
 // Handler
 const (
-	FailedParsingRequestBody    = "Failed parsing request body"
-	FailedParsingRequestParams  = "Failed parsing request params"
-	FailedValidateRequest       = "Failed to validate request"
-	MissingAccessToken          = "Missing access token"
-	InvalidAccessToken          = "Invalid access token"
-	InvalidOrMissingBearerToken = "Invalid or missing bearer token"
-	InvalidFormData             = "Invalid form data"
-	FileIsRequired              = "File is required"
-	FailedToOpenFile            = "Failed to open file"
-	InvalidMealPlanID           = "Invalid meal plan ID"
-	InvalidSubscriptionID       = "Invalid subscription ID"
-	AdminAccessRequired         = "Admin access required"
+	FailedParsingRequestBody      = "Failed parsing request body"
+	FailedParsingRequestParams    = "Failed parsing request params"
+	FailedValidateRequest         = "Failed to validate request"
+	MissingAccessToken            = "Missing access token"
+	InvalidAccessToken            = "Invalid access token"
+	InvalidOrMissingBearerToken   = "Invalid or missing bearer token"
+	InvalidFormData               = "Invalid form data"
+	FileIsRequired                = "File is required"
+	FailedToOpenFile              = "Failed to open file"
+	InvalidMealPlanID             = "Invalid meal plan ID"
+	InvalidSubscriptionID         = "Invalid subscription ID"
+	InvalidDeliveryID             = "Invalid delivery ID"
+	InvalidPromoID                = "Invalid promo ID"
+	InvalidRefundID               = "Invalid refund ID"
+	InvalidReconciliationReportID = "Invalid reconciliation report ID"
+	InvalidUserID                 = "Invalid user ID"
+	InvalidUserAddressID          = "Invalid address ID"
+	InvalidDeliveryZoneID         = "Invalid delivery zone ID"
+	AdminAccessRequired           = "Admin access required"
+	CourierAccessRequired         = "Courier access required"
 )
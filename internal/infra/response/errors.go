@@ -1,6 +1,7 @@
 package response
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
@@ -58,6 +59,18 @@ var validationMessages = map[string]string{
 	"max":      "The {field} field must be at most {param} characters long.",
 	"uuid":     "The {field} field must be a valid UUID format.",
 	"numeric":  "The {field} field must be a number.",
+	"datetime": "The {field} field must be a date in {param} format.",
+	"unique":   "The {field} field must not contain duplicates.",
+	"oneof":    "The {field} field must be one of {param}.",
+	"alphanum": "The {field} field must only contain letters and numbers.",
+	"gt":       "The {field} field must be greater than {param}.",
+	"gte":      "The {field} field must be at least {param}.",
+
+	"meal_type":      "The {field} field must be one of breakfast, lunch or dinner.",
+	"weekday":        "The {field} field must be a day of the week from monday to sunday.",
+	"not_past":       "The {field} field must not be in the past.",
+	"date_gtefield":  "The {field} field must not be before the start date.",
+	"max_days_after": "The {field} field must be at most {param1} days after the start date.",
 }
 
 func ErrValidation(errs validator.ValidationErrors) *Err {
@@ -78,6 +91,12 @@ func ErrValidation(errs validator.ValidationErrors) *Err {
 
 		msg = strings.Replace(msg, "{param}", param, -1)
 
+		// Tags taking several space-separated params refer to each one by
+		// position, e.g. {param1} for "StartDate 30".
+		for i, p := range strings.Fields(param) {
+			msg = strings.Replace(msg, "{param"+strconv.Itoa(i)+"}", p, -1)
+		}
+
 		errorsMap[field] = msg
 	}
 
@@ -14,3 +14,14 @@ func (m *Middleware) Authorization(ctx *fiber.Ctx) error {
 
 	return ctx.Next()
 }
+
+// CourierAuthorization lets couriers through, and admins too so they can
+// dispatch and correct deliveries themselves.
+func (m *Middleware) CourierAuthorization(ctx *fiber.Ctx) error {
+	role, ok := ctx.Locals("role").(entity.UserRole)
+	if !ok || (role != entity.RoleCourier && role != entity.RoleAdmin) {
+		return res.ErrForbidden(res.CourierAccessRequired)
+	}
+
+	return ctx.Next()
+}
@@ -0,0 +1,73 @@
+package geo
+
+import (
+	"fmt"
+
+	gojson "github.com/goccy/go-json"
+)
+
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Polygon is a closed ring of points; the last point connects back to the
+// first.
+type Polygon []Point
+
+// ParsePolygon reads a polygon stored as a JSON array of [latitude,
+// longitude] pairs.
+func ParsePolygon(raw string) (Polygon, error) {
+	var pairs [][]float64
+	if err := gojson.Unmarshal([]byte(raw), &pairs); err != nil {
+		return nil, err
+	}
+
+	polygon := make(Polygon, 0, len(pairs))
+	for _, pair := range pairs {
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("polygon point %v is not a [latitude, longitude] pair", pair)
+		}
+
+		polygon = append(polygon, Point{Latitude: pair[0], Longitude: pair[1]})
+	}
+
+	return polygon, nil
+}
+
+// Encode writes the polygon as a JSON array of [latitude, longitude] pairs,
+// the form ParsePolygon reads.
+func (p Polygon) Encode() string {
+	pairs := make([][]float64, 0, len(p))
+	for _, point := range p {
+		pairs = append(pairs, []float64{point.Latitude, point.Longitude})
+	}
+
+	raw, _ := gojson.Marshal(pairs)
+	return string(raw)
+}
+
+// Contains reports whether the point lies inside the polygon, by counting
+// how many edges a ray cast from it crosses. Delivery zones span a few
+// dozen kilometres at most, so treating coordinates as planar is accurate
+// enough.
+func (p Polygon) Contains(point Point) bool {
+	if len(p) < 3 {
+		return false
+	}
+
+	inside := false
+	for i, j := 0, len(p)-1; i < len(p); j, i = i, i+1 {
+		a, b := p[i], p[j]
+		if (a.Latitude > point.Latitude) == (b.Latitude > point.Latitude) {
+			continue
+		}
+
+		crossing := (b.Longitude-a.Longitude)*(point.Latitude-a.Latitude)/(b.Latitude-a.Latitude) + a.Longitude
+		if point.Longitude < crossing {
+			inside = !inside
+		}
+	}
+
+	return inside
+}
@@ -0,0 +1,112 @@
+package invoice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/domain/dto"
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pdf"
+)
+
+// Invoice is everything printed on the PDF issued for a settled payment.
+type Invoice struct {
+	Number        string
+	IssuedAt      time.Time
+	PaidAt        time.Time
+	PaymentMethod string
+	Email         string
+	Subscription  dto.SubscriptionResponse
+	PeriodStart   time.Time
+	PeriodEnd     time.Time
+	Items         []Item
+	Total         int64
+}
+
+// Item is one line of the breakdown, in whole rupiah. Discounts and credit
+// are negative.
+type Item struct {
+	Name   string
+	Amount int64
+}
+
+// Render lays the invoice out as a PDF.
+func Render(inv Invoice) []byte {
+	sub := inv.Subscription
+	d := pdf.New()
+
+	d.Text(pdf.Bold, 20, "SEA Catering")
+	d.Text(pdf.Regular, 10, "Healthy Meal, Anytime, Anywhere")
+	d.Advance(12)
+
+	d.Text(pdf.Bold, 14, "INVOICE - PAID")
+	d.Row(pdf.Regular, 10, "Invoice number", inv.Number)
+	d.Row(pdf.Regular, 10, "Issued", inv.IssuedAt.Format("2 January 2006"))
+	d.Row(pdf.Regular, 10, "Paid", inv.PaidAt.Format("2 January 2006 15:04 MST"))
+	d.Row(pdf.Regular, 10, "Payment method", paymentMethod(inv.PaymentMethod))
+	d.Advance(12)
+
+	d.Text(pdf.Bold, 12, "Billed to")
+	d.Text(pdf.Regular, 10, sub.Name)
+	d.Text(pdf.Regular, 10, inv.Email)
+	d.Text(pdf.Regular, 10, sub.PhoneNumber)
+	d.Text(pdf.Regular, 10, sub.DeliveryAddress)
+	d.Advance(12)
+
+	d.Text(pdf.Bold, 12, "Subscription")
+	d.Row(pdf.Regular, 10, "Subscription ID", sub.ID.String())
+	d.Row(pdf.Regular, 10, "Meal plan", sub.MealPlan.Name)
+	d.Row(pdf.Regular, 10, "Meal types", strings.Join(sub.MealTypes, ", "))
+	d.Row(pdf.Regular, 10, "Delivery days", strings.Join(sub.DeliveryDays, ", "))
+	if sub.Allergies != nil && *sub.Allergies != "" {
+		d.Text(pdf.Regular, 10, "Allergies: "+*sub.Allergies)
+	}
+	d.Row(pdf.Regular, 10, "Period", fmt.Sprintf("%s - %s", inv.PeriodStart.Format("2 Jan 2006"), inv.PeriodEnd.Format("2 Jan 2006")))
+	d.Advance(12)
+
+	d.Row(pdf.Bold, 10, "Description", "Amount")
+	d.Rule()
+	for _, item := range inv.Items {
+		d.Row(pdf.Regular, 10, item.Name, rupiah(item.Amount))
+	}
+	d.Rule()
+	d.Row(pdf.Bold, 12, "Total paid", rupiah(inv.Total))
+	d.Advance(24)
+
+	d.Text(pdf.Regular, 9, "All amounts are in Indonesian rupiah.")
+	d.Text(pdf.Regular, 9, "Thank you for eating with SEA Catering.")
+
+	return d.Bytes()
+}
+
+// rupiah formats an amount the Indonesian way, e.g. Rp180.600.
+func rupiah(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	var grouped strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte('.')
+		}
+		grouped.WriteRune(digit)
+	}
+
+	return sign + "Rp" + grouped.String()
+}
+
+// paymentMethod turns a payment type such as bank_transfer into
+// "Bank transfer".
+func paymentMethod(paymentType string) string {
+	if paymentType == "" {
+		return "-"
+	}
+
+	method := strings.ReplaceAll(paymentType, "_", " ")
+	return strings.ToUpper(method[:1]) + method[1:]
+}
@@ -0,0 +1,108 @@
+package label
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Ablebil/sea-catering-be/internal/pkg/pdf"
+)
+
+// Label is what is printed on the box of a single meal.
+type Label struct {
+	Date        time.Time
+	Name        string
+	PhoneNumber string
+	Address     string
+	MealPlan    string
+	MealType    string
+	Allergies   string
+	Notes       string
+}
+
+// Labels are laid out two across and five down on an A4 sheet.
+const (
+	columns = 2
+	rows    = 5
+	padding = 10.0
+)
+
+// Render lays the labels out as a PDF, in the order given.
+func Render(labels []Label) []byte {
+	d := pdf.New()
+
+	if len(labels) == 0 {
+		d.Text(pdf.Regular, 10, "No meals to label.")
+		return d.Bytes()
+	}
+
+	width := (pdf.PageWidth - 2*pdf.Margin) / columns
+	height := (pdf.PageHeight - 2*pdf.Margin) / rows
+
+	for i, l := range labels {
+		slot := i % (columns * rows)
+		if i > 0 && slot == 0 {
+			d.NewPage()
+		}
+
+		x := pdf.Margin + float64(slot%columns)*width
+		top := pdf.PageHeight - pdf.Margin - float64(slot/columns)*height
+		d.Box(x, top-height, width, height)
+
+		w := &writer{doc: d, x: x + padding, y: top - padding, bottom: top - height + padding, width: width - 2*padding}
+		w.line(pdf.Bold, 11, l.Name, 1)
+		w.line(pdf.Bold, 9, mealType(l.MealType)+" - "+l.MealPlan, 1)
+		w.line(pdf.Regular, 8, l.Date.Format("Mon, 2 Jan 2006")+"  "+l.PhoneNumber, 1)
+		w.line(pdf.Regular, 8, l.Address, 2)
+
+		if l.Allergies != "" {
+			w.line(pdf.Bold, 9, "ALLERGIES: "+l.Allergies, 2)
+		}
+
+		if l.Notes != "" {
+			w.line(pdf.Regular, 8, "Notes: "+l.Notes, 1)
+		}
+	}
+
+	return d.Bytes()
+}
+
+// writer fills a label from the top down, dropping whatever does not fit.
+type writer struct {
+	doc    *pdf.Document
+	x      float64
+	y      float64
+	bottom float64
+	width  float64
+}
+
+// line writes s over at most maxLines lines, cutting the last one short with
+// an ellipsis when s is longer.
+func (w *writer) line(f pdf.Font, size float64, s string, maxLines int) {
+	lines := pdf.Wrap(s, pdf.MaxChars(size, w.width))
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		last := lines[maxLines-1]
+		if cut := pdf.MaxChars(size, w.width) - 3; len(last) > cut {
+			last = last[:cut]
+		}
+		lines[maxLines-1] = last + "..."
+	}
+
+	for _, text := range lines {
+		if w.y-size*1.3 < w.bottom {
+			return
+		}
+
+		w.y -= size * 1.3
+		w.doc.TextAt(f, size, w.x, w.y, text)
+	}
+}
+
+// mealType turns a meal type such as lunch into "Lunch".
+func mealType(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
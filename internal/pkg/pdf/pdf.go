@@ -0,0 +1,217 @@
+// Package pdf writes simple A4 documents. It only uses the standard PDF
+// fonts, which every reader ships with, so no font has to be embedded.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+type Font string
+
+const (
+	Regular  Font = "F1"
+	Bold     Font = "F2"
+	Mono     Font = "F3"
+	MonoBold Font = "F4"
+)
+
+// A4 in points, with the same margin on every side.
+const (
+	PageWidth  = 595.0
+	PageHeight = 842.0
+	Margin     = 50.0
+)
+
+// Document lays out text from the top of an A4 page down, starting new pages
+// as they fill up. Text and boxes can also be placed at a fixed position on
+// the current page, for layouts such as label sheets.
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.NewPage()
+	return d
+}
+
+func (d *Document) NewPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = PageHeight - Margin
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// Advance moves down by height, breaking onto a new page when the line would
+// run into the bottom margin.
+func (d *Document) Advance(height float64) {
+	if d.y-height < Margin {
+		d.NewPage()
+	}
+
+	d.y -= height
+}
+
+// Text writes s at the left margin, wrapping it over as many lines as needed.
+func (d *Document) Text(f Font, size float64, s string) {
+	for _, line := range Wrap(sanitize(s), MaxChars(size, PageWidth-2*Margin)) {
+		d.Advance(size * 1.5)
+		d.show(f, size, Margin, d.y, line)
+	}
+}
+
+// Row writes left at the left margin and right flush against the right
+// margin. right is set in Courier, whose fixed glyph width of 0.6 em is what
+// makes right-aligning it possible.
+func (d *Document) Row(f Font, size float64, left string, right string) {
+	d.Advance(size * 1.5)
+	d.show(f, size, Margin, d.y, sanitize(left))
+
+	rightFont := Mono
+	if f == Bold {
+		rightFont = MonoBold
+	}
+
+	right = sanitize(right)
+	d.show(rightFont, size, PageWidth-Margin-float64(len(right))*size*0.6, d.y, right)
+}
+
+// Rule draws a thin line across the page.
+func (d *Document) Rule() {
+	d.Advance(8)
+	fmt.Fprintf(d.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", Margin, d.y, PageWidth-Margin, d.y)
+}
+
+// TextAt writes a single line of s with its baseline at x, y on the current
+// page, measured in points from the bottom left corner.
+func (d *Document) TextAt(f Font, size float64, x float64, y float64, s string) {
+	d.show(f, size, x, y, sanitize(s))
+}
+
+// Box draws the outline of a rectangle whose bottom left corner is at x, y
+// on the current page.
+func (d *Document) Box(x float64, y float64, width float64, height float64) {
+	fmt.Fprintf(d.page(), "0.5 w %.2f %.2f %.2f %.2f re S\n", x, y, width, height)
+}
+
+func (d *Document) show(f Font, size float64, x float64, y float64, s string) {
+	if s == "" {
+		return
+	}
+
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", f, size, x, y, escape(s))
+}
+
+// Bytes assembles the PDF: catalog, page tree, the four fonts, then a page
+// and content stream per page, followed by the cross-reference table.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	var offsets []int
+
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	// Objects 1 to 6 are fixed, so page i is object 7+2i and its content
+	// stream the one after it.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 7+2*i)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	for _, name := range []string{"Helvetica", "Helvetica-Bold", "Courier", "Courier-Bold"} {
+		object(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", name))
+	}
+
+	for i, content := range d.pages {
+		object(fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %g %g] /Resources << /Font << /F1 3 0 R /F2 4 0 R /F3 5 0 R /F4 6 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, 8+2*i,
+		))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// MaxChars is roughly how many characters of the given font size fit in
+// width. Helvetica is proportional, so it assumes an average glyph of half
+// the font size, which errs on the short side for most text.
+func MaxChars(size float64, width float64) int {
+	return int(width / (size * 0.5))
+}
+
+// sanitize converts s to the single-byte encoding of the standard fonts.
+// WinAnsiEncoding matches Latin-1 for printable ASCII and accented letters
+// such as "é"; anything else is replaced with a question mark.
+func sanitize(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\t' || r == '\n' || r == '\r':
+			b.WriteByte(' ')
+		case r >= 0x20 && r <= 0x7e, r >= 0xa0 && r <= 0xff:
+			b.WriteByte(byte(r))
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
+
+// escape quotes the characters that are special inside a PDF string.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// Wrap breaks s into lines of at most maxChars characters, splitting words
+// only when they are longer than a whole line.
+func Wrap(s string, maxChars int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(s) {
+		for len(word) > maxChars {
+			if line != "" {
+				lines = append(lines, line)
+				line = ""
+			}
+
+			lines = append(lines, word[:maxChars])
+			word = word[maxChars:]
+		}
+
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) <= maxChars:
+			line += " " + word
+		default:
+			lines = append(lines, line)
+			line = word
+		}
+	}
+
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	return lines
+}
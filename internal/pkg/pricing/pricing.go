@@ -0,0 +1,179 @@
+package pricing
+
+import (
+	"math"
+	"strings"
+)
+
+// Money is an amount in whole rupiah. Prices are computed on integers so the
+// amount stored on a subscription is exactly the amount charged.
+type Money int64
+
+// FromFloat converts a decimal column value to Money, rounding to the
+// nearest rupiah.
+func FromFloat(amount float64) Money {
+	return Money(math.Round(amount))
+}
+
+func (m Money) Float64() float64 {
+	return float64(m)
+}
+
+// A billing period covers 4.3 weeks, kept as a fraction to stay exact.
+const (
+	weeksPerPeriodNum   = 43
+	weeksPerPeriodDenom = 10
+)
+
+type ItemKind string
+
+const (
+	ItemMealType ItemKind = "meal_type"
+	ItemDiscount ItemKind = "discount"
+	ItemFee      ItemKind = "fee"
+	ItemTax      ItemKind = "tax"
+)
+
+type Item struct {
+	ID     string
+	Kind   ItemKind
+	Name   string
+	Amount Money
+}
+
+type Order struct {
+	MealPlanPrice   Money
+	MealTypes       []string
+	DeliveryDays    []string
+	DeliveryAddress string
+	// DeliveryZone is the zone the address was matched to, if any.
+	DeliveryZone *Zone
+}
+
+// Zone is a delivery zone with the fee it charges per delivery day.
+type Zone struct {
+	Name string
+	Fee  Money
+}
+
+// Quote is the itemized price of one billing period. BasePrice is the price
+// of a single meal, PerMealType what one meal type costs over the period and
+// PerDay what one delivery day a week costs over the period.
+type Quote struct {
+	BasePrice   Money
+	PerMealType Money
+	PerDay      Money
+	Items       []Item
+	Subtotal    Money
+	Discount    Money
+	Fees        Money
+	Tax         Money
+	Total       Money
+}
+
+// Add appends an item and keeps the totals in step. Discounts are given as
+// negative amounts.
+func (q *Quote) Add(item Item) {
+	q.Items = append(q.Items, item)
+
+	switch item.Kind {
+	case ItemMealType:
+		q.Subtotal += item.Amount
+	case ItemDiscount:
+		q.Discount -= item.Amount
+	case ItemFee:
+		q.Fees += item.Amount
+	case ItemTax:
+		q.Tax += item.Amount
+	}
+
+	q.Total += item.Amount
+}
+
+// Rule adds discount, fee or tax items on top of the meal lines.
+type Rule interface {
+	Apply(order Order, quote *Quote)
+}
+
+type PricingItf interface {
+	Quote(order Order, discounts ...Rule) *Quote
+}
+
+type Pricing struct {
+	rules []Rule
+}
+
+func NewPricing(rules ...Rule) PricingItf {
+	return &Pricing{rules: rules}
+}
+
+// Quote prices an order with one line per meal type. Each line is rounded on
+// its own, so the lines always add up to the total that gets charged.
+// Discounts for this order apply first, then the rules given to NewPricing
+// in order, so fees and tax are worked out on the discounted price.
+func (p *Pricing) Quote(order Order, discounts ...Rule) *Quote {
+	mealTypes := int64(len(order.MealTypes))
+	deliveryDays := int64(len(order.DeliveryDays))
+
+	quote := &Quote{
+		BasePrice:   order.MealPlanPrice,
+		PerMealType: divRound(int64(order.MealPlanPrice)*deliveryDays*weeksPerPeriodNum, weeksPerPeriodDenom),
+		PerDay:      divRound(int64(order.MealPlanPrice)*mealTypes*weeksPerPeriodNum, weeksPerPeriodDenom),
+	}
+
+	for _, mealType := range order.MealTypes {
+		quote.Add(Item{
+			ID:     "meal-" + mealType,
+			Kind:   ItemMealType,
+			Name:   strings.ToUpper(mealType[:1]) + mealType[1:] + " meals",
+			Amount: quote.PerMealType,
+		})
+	}
+
+	for _, discount := range discounts {
+		discount.Apply(order, quote)
+	}
+
+	for _, rule := range p.rules {
+		rule.Apply(order, quote)
+	}
+
+	return quote
+}
+
+// Prorate scales amount to the given number of days out of periodDays.
+func Prorate(amount Money, days int, periodDays int) Money {
+	if periodDays <= 0 {
+		return 0
+	}
+
+	return divRound(int64(amount)*int64(days), int64(periodDays))
+}
+
+// PerMeal splits the price of a billing period over the meals delivered in
+// it.
+func PerMeal(total Money, mealTypes int, deliveryDays int) Money {
+	meals := int64(mealTypes) * int64(deliveryDays) * weeksPerPeriodNum
+	if meals <= 0 {
+		return 0
+	}
+
+	return divRound(int64(total)*weeksPerPeriodDenom, meals)
+}
+
+func Min(a Money, b Money) Money {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// divRound divides rounding half away from zero.
+func divRound(a int64, b int64) Money {
+	if (a < 0) != (b < 0) {
+		return Money((a - b/2) / b)
+	}
+
+	return Money((a + b/2) / b)
+}
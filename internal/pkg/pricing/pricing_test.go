@@ -0,0 +1,270 @@
+package pricing
+
+import "testing"
+
+func TestDivRound(t *testing.T) {
+	tests := []struct {
+		a, b int64
+		want Money
+	}{
+		{a: 4, b: 2, want: 2},
+		{a: 5, b: 2, want: 3},
+		{a: 7, b: 2, want: 4},
+		{a: 14, b: 10, want: 1},
+		{a: 15, b: 10, want: 2},
+		{a: 1, b: 3, want: 0},
+		{a: 2, b: 3, want: 1},
+		{a: -5, b: 2, want: -3},
+		{a: -15, b: 10, want: -2},
+		{a: -14, b: 10, want: -1},
+		{a: -1, b: 3, want: 0},
+		{a: 5, b: -2, want: -3},
+	}
+
+	for _, tt := range tests {
+		if got := divRound(tt.a, tt.b); got != tt.want {
+			t.Errorf("divRound(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFromFloat(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   Money
+	}{
+		{amount: 180600, want: 180600},
+		{amount: 180599.5, want: 180600},
+		{amount: 180599.49, want: 180599},
+		{amount: 0.99999999, want: 1},
+		{amount: -2.5, want: -3},
+	}
+
+	for _, tt := range tests {
+		if got := FromFloat(tt.amount); got != tt.want {
+			t.Errorf("FromFloat(%v) = %d, want %d", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestProrate(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     Money
+		days       int
+		periodDays int
+		want       Money
+	}{
+		{name: "exact", amount: 1000, days: 15, periodDays: 30, want: 500},
+		{name: "half rounds up", amount: 1001, days: 15, periodDays: 30, want: 501},
+		{name: "below half rounds down", amount: 1000, days: 7, periodDays: 30, want: 233},
+		{name: "negative half rounds away from zero", amount: -1001, days: 15, periodDays: 30, want: -501},
+		{name: "negative below half rounds toward zero", amount: -1000, days: 7, periodDays: 30, want: -233},
+		{name: "no days left", amount: 1000, days: 0, periodDays: 30, want: 0},
+		{name: "empty period", amount: 1000, days: 5, periodDays: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Prorate(tt.amount, tt.days, tt.periodDays); got != tt.want {
+				t.Errorf("Prorate(%d, %d, %d) = %d, want %d", tt.amount, tt.days, tt.periodDays, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerMeal(t *testing.T) {
+	tests := []struct {
+		name         string
+		total        Money
+		mealTypes    int
+		deliveryDays int
+		want         Money
+	}{
+		{name: "exact", total: 301000, mealTypes: 1, deliveryDays: 7, want: 10000},
+		{name: "rounded", total: 100, mealTypes: 1, deliveryDays: 1, want: 23},
+		{name: "no meals", total: 100, mealTypes: 0, deliveryDays: 7, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PerMeal(tt.total, tt.mealTypes, tt.deliveryDays); got != tt.want {
+				t.Errorf("PerMeal(%d, %d, %d) = %d, want %d", tt.total, tt.mealTypes, tt.deliveryDays, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		name        string
+		price       Money
+		mealTypes   []string
+		days        int
+		perMealType Money
+		perDay      Money
+	}{
+		{name: "exact", price: 30000, mealTypes: []string{"lunch", "dinner"}, days: 5, perMealType: 645000, perDay: 258000},
+		{name: "half rounds up", price: 5, mealTypes: []string{"lunch"}, days: 1, perMealType: 22, perDay: 22},
+		{name: "below half rounds down", price: 1, mealTypes: []string{"lunch"}, days: 1, perMealType: 4, perDay: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := NewPricing().Quote(Order{
+				MealPlanPrice: tt.price,
+				MealTypes:     tt.mealTypes,
+				DeliveryDays:  make([]string, tt.days),
+			})
+
+			if quote.PerMealType != tt.perMealType || quote.PerDay != tt.perDay {
+				t.Fatalf("per meal type %d, per day %d, want %d and %d", quote.PerMealType, quote.PerDay, tt.perMealType, tt.perDay)
+			}
+
+			if want := tt.perMealType * Money(len(tt.mealTypes)); quote.Subtotal != want || quote.Total != want {
+				t.Fatalf("subtotal %d, total %d, want %d", quote.Subtotal, quote.Total, want)
+			}
+		})
+	}
+}
+
+func TestDiscount(t *testing.T) {
+	tests := []struct {
+		name     string
+		subtotal Money
+		discount Discount
+		want     Money
+	}{
+		{name: "percentage", subtotal: 100000, discount: Discount{BasisPoints: 1000}, want: 10000},
+		{name: "percentage half rounds up", subtotal: 5, discount: Discount{BasisPoints: 1000}, want: 1},
+		{name: "percentage rounds to nearest", subtotal: 12345, discount: Discount{BasisPoints: 1500}, want: 1852},
+		{name: "percentage under cap", subtotal: 100000, discount: Discount{BasisPoints: 1000, Max: 20000}, want: 10000},
+		{name: "percentage capped", subtotal: 100000, discount: Discount{BasisPoints: 5000, Max: 20000}, want: 20000},
+		{name: "fixed", subtotal: 100000, discount: Discount{Amount: 30000}, want: 30000},
+		{name: "fixed capped at total", subtotal: 100000, discount: Discount{Amount: 150000}, want: 100000},
+		{name: "cap ignored for fixed", subtotal: 100000, discount: Discount{Amount: 30000, Max: 20000}, want: 30000},
+		{name: "nothing off", subtotal: 100000, discount: Discount{}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := &Quote{}
+			quote.Add(Item{Kind: ItemMealType, Amount: tt.subtotal})
+
+			tt.discount.Apply(Order{}, quote)
+
+			if quote.Discount != tt.want {
+				t.Fatalf("discount %d, want %d", quote.Discount, tt.want)
+			}
+
+			if quote.Total != tt.subtotal-tt.want {
+				t.Fatalf("total %d, want %d", quote.Total, tt.subtotal-tt.want)
+			}
+
+			if tt.want == 0 && len(quote.Items) != 1 {
+				t.Fatalf("got %d items, want no discount line", len(quote.Items))
+			}
+		})
+	}
+}
+
+func TestTax(t *testing.T) {
+	tests := []struct {
+		name  string
+		total Money
+		rate  float64
+		want  Money
+	}{
+		{name: "exact", total: 100000, rate: 11, want: 11000},
+		{name: "half rounds up", total: 1005, rate: 11, want: 111},
+		{name: "fractional rate", total: 100000, rate: 2.5, want: 2500},
+		{name: "zero rate", total: 100000, rate: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := &Quote{}
+			quote.Add(Item{Kind: ItemMealType, Amount: tt.total})
+
+			NewTax("PPN", tt.rate).Apply(Order{}, quote)
+
+			if quote.Tax != tt.want || quote.Total != tt.total+tt.want {
+				t.Fatalf("tax %d, total %d, want %d and %d", quote.Tax, quote.Total, tt.want, tt.total+tt.want)
+			}
+		})
+	}
+}
+
+func TestTaxOnDiscountedSubtotal(t *testing.T) {
+	fees, err := NewZoneDeliveryFee([]string{"depok:2000"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	order := Order{
+		MealPlanPrice:   30000,
+		MealTypes:       []string{"lunch"},
+		DeliveryDays:    []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+		DeliveryAddress: "Jl. Margonda, Depok",
+	}
+
+	// Subtotal 645000, less 10% is 580500, plus a 43000 delivery fee is
+	// 623500, and 11% PPN on that is 68585.
+	quote := NewPricing(fees, NewTax("PPN", 11)).Quote(order, Discount{ID: "promo", BasisPoints: 1000})
+
+	want := Quote{Subtotal: 645000, Discount: 64500, Fees: 43000, Tax: 68585, Total: 692085}
+	if quote.Subtotal != want.Subtotal || quote.Discount != want.Discount || quote.Fees != want.Fees || quote.Tax != want.Tax || quote.Total != want.Total {
+		t.Fatalf("got subtotal %d, discount %d, fees %d, tax %d, total %d, want %d, %d, %d, %d, %d",
+			quote.Subtotal, quote.Discount, quote.Fees, quote.Tax, quote.Total,
+			want.Subtotal, want.Discount, want.Fees, want.Tax, want.Total)
+	}
+
+	var sum Money
+	for _, item := range quote.Items {
+		sum += item.Amount
+	}
+
+	if sum != quote.Total {
+		t.Fatalf("items add up to %d, total is %d", sum, quote.Total)
+	}
+}
+
+func TestZoneDeliveryFee(t *testing.T) {
+	rule, err := NewZoneDeliveryFee([]string{"jakarta:5000", " south jakarta : 10000 ", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	days := []string{"monday", "wednesday", "friday"}
+
+	tests := []struct {
+		name  string
+		order Order
+		want  Money
+	}{
+		{name: "longest name wins", order: Order{DeliveryDays: days, DeliveryAddress: "Jl. Fatmawati, South Jakarta"}, want: 129000},
+		{name: "matched case-insensitively", order: Order{DeliveryDays: days, DeliveryAddress: "JL. THAMRIN, JAKARTA"}, want: 64500},
+		{name: "base area is free", order: Order{DeliveryDays: days, DeliveryAddress: "Jl. Pajajaran, Bogor"}, want: 0},
+		{name: "matched zone wins over address", order: Order{DeliveryDays: days[:2], DeliveryAddress: "Jakarta", DeliveryZone: &Zone{Name: "Depok", Fee: 2000}}, want: 17200},
+		{name: "free zone", order: Order{DeliveryDays: days, DeliveryAddress: "Jakarta", DeliveryZone: &Zone{Name: "Central"}}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := &Quote{}
+			rule.Apply(tt.order, quote)
+
+			if quote.Fees != tt.want {
+				t.Fatalf("fees %d, want %d", quote.Fees, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewZoneDeliveryFeeInvalid(t *testing.T) {
+	for _, entry := range []string{"bekasi", "bekasi:abc", "bekasi:-1"} {
+		if _, err := NewZoneDeliveryFee([]string{entry}); err == nil {
+			t.Errorf("NewZoneDeliveryFee(%q) returned no error", entry)
+		}
+	}
+}
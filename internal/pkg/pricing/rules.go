@@ -0,0 +1,143 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Discount takes a percentage (in basis points, 1000 being 10%) or a fixed
+// amount off the subtotal. Max caps a percentage discount when set, and the
+// discount never exceeds what is left to pay.
+type Discount struct {
+	ID          string
+	Name        string
+	BasisPoints int64
+	Amount      Money
+	Max         Money
+}
+
+func (d Discount) Apply(order Order, quote *Quote) {
+	amount := d.Amount
+	if d.BasisPoints > 0 {
+		amount = divRound(int64(quote.Subtotal)*d.BasisPoints, 10000)
+		if d.Max > 0 {
+			amount = Min(amount, d.Max)
+		}
+	}
+
+	amount = Min(amount, quote.Total)
+	if amount <= 0 {
+		return
+	}
+
+	quote.Add(Item{
+		ID:     d.ID,
+		Kind:   ItemDiscount,
+		Name:   d.Name,
+		Amount: -amount,
+	})
+}
+
+// ZoneDeliveryFee charges a fee for every delivery day to addresses outside
+// the base area. An order matched to a delivery zone pays that zone's fee.
+// Otherwise a configured zone matches when its name appears in the delivery
+// address; addresses matching no zone are delivered for free.
+type ZoneDeliveryFee struct {
+	zones []zoneFee
+}
+
+type zoneFee struct {
+	name string
+	fee  Money
+}
+
+// NewZoneDeliveryFee builds the rule from "zone:fee" entries, such as
+// "bekasi:10000", where fee is charged per delivery day.
+func NewZoneDeliveryFee(entries []string) (*ZoneDeliveryFee, error) {
+	rule := &ZoneDeliveryFee{}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, fee, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid delivery zone fee %q, want zone:fee", entry)
+		}
+
+		amount, err := strconv.ParseInt(strings.TrimSpace(fee), 10, 64)
+		if err != nil || amount < 0 {
+			return nil, fmt.Errorf("invalid delivery zone fee %q, want zone:fee", entry)
+		}
+
+		rule.zones = append(rule.zones, zoneFee{name: strings.ToLower(strings.TrimSpace(name)), fee: Money(amount)})
+	}
+
+	// Longer names are tried first so "south jakarta" wins over "jakarta".
+	sort.SliceStable(rule.zones, func(i, j int) bool {
+		return len(rule.zones[i].name) > len(rule.zones[j].name)
+	})
+
+	return rule, nil
+}
+
+func (r *ZoneDeliveryFee) Apply(order Order, quote *Quote) {
+	if order.DeliveryZone != nil {
+		addDeliveryFee(order, quote, order.DeliveryZone.Name, order.DeliveryZone.Fee)
+		return
+	}
+
+	address := strings.ToLower(order.DeliveryAddress)
+	for _, zone := range r.zones {
+		if strings.Contains(address, zone.name) {
+			addDeliveryFee(order, quote, zone.name, zone.fee)
+			return
+		}
+	}
+}
+
+func addDeliveryFee(order Order, quote *Quote, zone string, perDay Money) {
+	fee := divRound(int64(perDay)*int64(len(order.DeliveryDays))*weeksPerPeriodNum, weeksPerPeriodDenom)
+	if fee <= 0 {
+		return
+	}
+
+	quote.Add(Item{
+		ID:     "delivery-fee",
+		Kind:   ItemFee,
+		Name:   fmt.Sprintf("Delivery fee (%s)", zone),
+		Amount: fee,
+	})
+}
+
+// Tax adds VAT on everything charged before it, after discounts and fees.
+type Tax struct {
+	Name        string
+	BasisPoints int64
+}
+
+// NewTax builds a tax rule from a percentage rate, such as 11 for PPN.
+func NewTax(name string, rate float64) Tax {
+	return Tax{
+		Name:        name,
+		BasisPoints: int64(math.Round(rate * 100)),
+	}
+}
+
+func (t Tax) Apply(order Order, quote *Quote) {
+	amount := divRound(int64(quote.Total)*t.BasisPoints, 10000)
+	if amount <= 0 {
+		return
+	}
+
+	quote.Add(Item{
+		ID:     "tax",
+		Kind:   ItemTax,
+		Name:   t.Name,
+		Amount: amount,
+	})
+}
@@ -24,6 +24,13 @@ func NewScheduler(subscriptionUsecase subscriptionUsecase.SubscriptionUsecaseItf
 
 func (s *Scheduler) Start() {
 	s.cron.AddFunc("0 0 * * *", s.updateExpiredSubscriptions)
+	s.cron.AddFunc("5 0 * * *", s.updatePausedSubscriptions)
+	s.cron.AddFunc("10 0 * * *", s.applyAddressChanges)
+	s.cron.AddFunc("0 1 * * *", s.processRenewals)
+	s.cron.AddFunc("0 9 * * *", s.sendExpiryReminders)
+	s.cron.AddFunc("*/15 * * * *", s.cancelStalePendingSubscriptions)
+	s.cron.AddFunc("*/15 * * * *", s.cancelStaleSubscriptionChanges)
+	s.cron.AddFunc("30 2 * * *", s.reconcilePayments)
 	s.cron.AddFunc("0 * * * *", s.removeUnverifiedUsers)
 	s.cron.Start()
 	log.Println("Scheduler started")
@@ -41,6 +48,55 @@ func (s *Scheduler) updateExpiredSubscriptions() {
 	}
 }
 
+func (s *Scheduler) updatePausedSubscriptions() {
+	log.Println("Updating paused subscriptions...")
+	if err := s.subscriptionUsecase.UpdatePausedSubscriptions(); err != nil {
+		log.Printf("Error updating paused subscriptions: %v", err)
+	}
+}
+
+func (s *Scheduler) applyAddressChanges() {
+	log.Println("Applying subscription address changes...")
+	if err := s.subscriptionUsecase.ApplyDueAddressChanges(); err != nil {
+		log.Printf("Error applying subscription address changes: %v", err)
+	}
+}
+
+func (s *Scheduler) processRenewals() {
+	log.Println("Processing subscription renewals...")
+	if err := s.subscriptionUsecase.ProcessRenewals(); err != nil {
+		log.Printf("Error processing subscription renewals: %v", err)
+	}
+}
+
+func (s *Scheduler) sendExpiryReminders() {
+	log.Println("Sending subscription expiry reminders...")
+	if err := s.subscriptionUsecase.SendExpiryReminders(); err != nil {
+		log.Printf("Error sending subscription expiry reminders: %v", err)
+	}
+}
+
+func (s *Scheduler) cancelStalePendingSubscriptions() {
+	log.Println("Cancelling stale pending subscriptions...")
+	if err := s.subscriptionUsecase.CancelStalePendingSubscriptions(); err != nil {
+		log.Printf("Error cancelling stale pending subscriptions: %v", err)
+	}
+}
+
+func (s *Scheduler) cancelStaleSubscriptionChanges() {
+	log.Println("Cancelling stale subscription changes...")
+	if err := s.subscriptionUsecase.CancelStaleSubscriptionChanges(); err != nil {
+		log.Printf("Error cancelling stale subscription changes: %v", err)
+	}
+}
+
+func (s *Scheduler) reconcilePayments() {
+	log.Println("Reconciling payments...")
+	if err := s.subscriptionUsecase.ReconcilePayments(); err != nil {
+		log.Printf("Error reconciling payments: %v", err)
+	}
+}
+
 func (s *Scheduler) removeUnverifiedUsers() {
 	log.Println("Removing unverified users...")
 	if err := s.userUsecase.RemoveUnverifiedUsers(); err != nil {
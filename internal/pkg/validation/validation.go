@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+const dateLayout = "2006-01-02"
+
+var mealTypes = map[string]bool{
+	"breakfast": true,
+	"lunch":     true,
+	"dinner":    true,
+}
+
+var weekdays = map[string]bool{
+	"monday":    true,
+	"tuesday":   true,
+	"wednesday": true,
+	"thursday":  true,
+	"friday":    true,
+	"saturday":  true,
+	"sunday":    true,
+}
+
+// New returns the shared validator with the domain tags registered:
+//
+//	meal_type                   breakfast, lunch or dinner
+//	weekday                     monday to sunday
+//	not_past                    a YYYY-MM-DD date no earlier than today
+//	date_gtefield=Field         a YYYY-MM-DD date no earlier than Field
+//	max_days_after=Field N      a YYYY-MM-DD date at most N days after Field
+func New() *validator.Validate {
+	v := validator.New()
+
+	v.RegisterValidation("meal_type", func(fl validator.FieldLevel) bool {
+		return mealTypes[fl.Field().String()]
+	})
+
+	v.RegisterValidation("weekday", func(fl validator.FieldLevel) bool {
+		return weekdays[fl.Field().String()]
+	})
+
+	v.RegisterValidation("not_past", func(fl validator.FieldLevel) bool {
+		date, err := time.ParseInLocation(dateLayout, fl.Field().String(), time.Local)
+		if err != nil {
+			return true
+		}
+
+		now := time.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		return !date.Before(today)
+	})
+
+	v.RegisterValidation("date_gtefield", func(fl validator.FieldLevel) bool {
+		date, other, ok := parseDatePair(fl, fl.Param())
+		if !ok {
+			return true
+		}
+
+		return !date.Before(other)
+	})
+
+	v.RegisterValidation("max_days_after", func(fl validator.FieldLevel) bool {
+		params := strings.Fields(fl.Param())
+		if len(params) != 2 {
+			return false
+		}
+
+		maxDays, err := strconv.Atoi(params[1])
+		if err != nil {
+			return false
+		}
+
+		date, other, ok := parseDatePair(fl, params[0])
+		if !ok {
+			return true
+		}
+
+		return !date.After(other.AddDate(0, 0, maxDays))
+	})
+
+	return v
+}
+
+// parseDatePair parses the current field and its sibling field as dates. It
+// reports false when either is not a valid date, leaving that to datetime.
+func parseDatePair(fl validator.FieldLevel, otherField string) (time.Time, time.Time, bool) {
+	date, err := time.Parse(dateLayout, fl.Field().String())
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	otherValue := fl.Parent().FieldByName(otherField)
+	if !otherValue.IsValid() || otherValue.Kind() != reflect.String {
+		return time.Time{}, time.Time{}, false
+	}
+
+	other, err := time.Parse(dateLayout, otherValue.String())
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+
+	return date, other, true
+}